@@ -1,16 +1,105 @@
 package middleware
 
 import (
+	apiKeys "crop_connect/business/api_keys"
+	"crop_connect/business/policies"
+	"crop_connect/business/sessions"
+	"crop_connect/business/users"
+	"crop_connect/constant"
 	"crop_connect/helper"
+	"errors"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+var (
+	sessionUseCase sessions.UseCase
+	userUseCase    users.UseCase
+	policyUseCase  policies.UseCase
+	apiKeyUseCase  apiKeys.UseCase
+)
+
+// InitAuth gives the auth middlewares the session store used to reject revoked access tokens,
+// the users used to reject accounts that are no longer active, the policy that grants permissions to roles and the api keys of partner integrations.
+func InitAuth(su sessions.UseCase, uu users.UseCase, pu policies.UseCase, aku apiKeys.UseCase) {
+	sessionUseCase = su
+	userUseCase = uu
+	policyUseCase = pu
+	apiKeyUseCase = aku
+}
+
+// getPayload only accepts access tokens that carry a jti, have not been revoked and belong to an active account.
+func getPayload(c echo.Context) (helper.JWTCustomClaims, error) {
+	claims, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return helper.JWTCustomClaims{}, err
+	}
+
+	if claims.Type == constant.TokenTypeRefresh {
+		return helper.JWTCustomClaims{}, errors.New("refresh token tidak bisa digunakan untuk akses")
+	}
+
+	if claims.Type == constant.TokenTypeTwoFactor {
+		return helper.JWTCustomClaims{}, errors.New("token dua faktor tidak bisa digunakan untuk akses")
+	}
+
+	if claims.ID == "" {
+		return helper.JWTCustomClaims{}, errors.New("token tidak valid")
+	}
+
+	if sessionUseCase != nil {
+		isRevoked, err := sessionUseCase.IsRevoked(claims.ID)
+		if err != nil {
+			return helper.JWTCustomClaims{}, err
+		} else if isRevoked {
+			return helper.JWTCustomClaims{}, errors.New("token telah dicabut")
+		}
+	}
+
+	if userUseCase != nil {
+		userID, err := primitive.ObjectIDFromHex(claims.UID)
+		if err != nil {
+			return helper.JWTCustomClaims{}, err
+		}
+
+		if _, err := userUseCase.CheckActive(userID); err != nil {
+			return helper.JWTCustomClaims{}, err
+		}
+	}
+
+	return claims, nil
+}
+
+// getAPIKeyPayload authenticates the api key header as the user of the key and keeps the claims on the context,
+// so handlers read them through helper.GetPayloadFromToken the same way as a bearer token.
+func getAPIKeyPayload(c echo.Context) (helper.JWTCustomClaims, []string, error) {
+	apiKey, user, err := apiKeyUseCase.Authenticate(c.Request().Header.Get(constant.APIKeyHeader))
+	if err != nil {
+		return helper.JWTCustomClaims{}, nil, err
+	}
+
+	if _, err := userUseCase.CheckActive(user.ID); err != nil {
+		return helper.JWTCustomClaims{}, nil, err
+	}
+
+	claims := helper.JWTCustomClaims{
+		UID:  user.ID.Hex(),
+		Role: user.Role,
+		Type: constant.TokenTypeAPIKey,
+	}
+	claims.ID = apiKey.ID.Hex()
+
+	c.Set(constant.ContextKeyAPIKeyClaims, claims)
+	return claims, apiKey.Scopes, nil
+}
+
+// Authenticated only accepts bearer tokens, the routes behind it manage the account itself which an api key is never scoped for.
 func Authenticated() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			_, err := helper.GetPayloadFromToken(c)
+			_, err := getPayload(c)
 			if err != nil {
 				return echo.NewHTTPError(http.StatusUnauthorized, helper.BaseResponse{
 					Status:  http.StatusUnauthorized,
@@ -24,10 +113,24 @@ func Authenticated() echo.MiddlewareFunc {
 	}
 }
 
-func CheckOneRole(role string) echo.MiddlewareFunc {
+// Authorize lets the request through when the role in the token has the permission in the policy matrix.
+// A request with an api key instead of a bearer token also needs a scope of the key that covers the permission.
+// Roles that have to use two factor authentication get no permission until the account has enrolled it.
+func Authorize(permission string) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			token, err := helper.GetPayloadFromToken(c)
+			var (
+				token     helper.JWTCustomClaims
+				scopeList []string
+				err       error
+			)
+
+			if c.Request().Header.Get(constant.APIKeyHeader) != "" && apiKeyUseCase != nil {
+				token, scopeList, err = getAPIKeyPayload(c)
+			} else {
+				token, err = getPayload(c)
+			}
+
 			if err != nil {
 				return echo.NewHTTPError(http.StatusUnauthorized, helper.BaseResponse{
 					Status:  http.StatusUnauthorized,
@@ -36,7 +139,19 @@ func CheckOneRole(role string) echo.MiddlewareFunc {
 				})
 			}
 
-			if token.Role == role {
+			if users.IsTwoFactorRequired(token.Role) && userUseCase != nil {
+				userID, _ := primitive.ObjectIDFromHex(token.UID)
+				if statusCode, err := userUseCase.CheckTwoFactor(userID); err != nil {
+					return c.JSON(statusCode, helper.BaseResponse{
+						Status:  statusCode,
+						Message: err.Error(),
+						Data:    nil,
+					})
+				}
+			}
+
+			isScoped := token.Type != constant.TokenTypeAPIKey || policyUseCase.CanScope(scopeList, permission)
+			if policyUseCase.Can(token.Role, permission) && isScoped {
 				return next(c)
 			}
 
@@ -49,29 +164,17 @@ func CheckOneRole(role string) echo.MiddlewareFunc {
 	}
 }
 
-func CheckManyRole(roles []string) echo.MiddlewareFunc {
+// TokenFromQuery moves the access token of the token query parameter into the Authorization header, browsers cannot set headers on an EventSource.
+// A request that already has the header keeps it.
+func TokenFromQuery() echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
-			token, err := helper.GetPayloadFromToken(c)
-			if err != nil {
-				return echo.NewHTTPError(http.StatusUnauthorized, helper.BaseResponse{
-					Status:  http.StatusUnauthorized,
-					Message: "token tidak valid",
-					Data:    nil,
-				})
-			}
-
-			for _, role := range roles {
-				if token.Role == role {
-					return next(c)
-				}
+			token := c.QueryParam("token")
+			if token != "" && c.Request().Header.Get(echo.HeaderAuthorization) == "" {
+				c.Request().Header.Set(echo.HeaderAuthorization, "Bearer "+token)
 			}
 
-			return c.JSON(http.StatusForbidden, helper.BaseResponse{
-				Status:  http.StatusForbidden,
-				Message: "forbidden",
-				Data:    nil,
-			})
+			return next(c)
 		}
 	}
 }
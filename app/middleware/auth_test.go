@@ -0,0 +1,79 @@
+package middleware
+
+import (
+	"crop_connect/business/sessions"
+	"crop_connect/constant"
+	"crop_connect/driver"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/helper"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func TestGetPayload(t *testing.T) {
+	helper.JWTSecretKey = "secret"
+
+	db := memoryDriver.Init()
+	sessionUseCase = sessions.NewUseCase(driver.NewSessionMemoryRepository(db), driver.NewRevokedTokenMemoryRepository(db), driver.NewUnitOfWorkMemory(db))
+	defer func() { sessionUseCase = nil }()
+
+	userID := primitive.NewObjectID()
+
+	tokenPair, _, err := sessionUseCase.Create(userID, constant.RoleBuyer)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	revokedTokenPair, _, err := sessionUseCase.Create(userID, constant.RoleBuyer)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	if _, err := sessionUseCase.Revoke(db.Sessions[1].ID, userID); err != nil {
+		t.Fatalf("Revoke() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		wantErr bool
+	}{
+		{
+			name:  "an access token of an active session is accepted",
+			token: tokenPair.AccessToken,
+		},
+		{
+			name:    "a refresh token is rejected",
+			token:   "Bearer " + tokenPair.RefreshToken,
+			wantErr: true,
+		},
+		{
+			name:    "an access token of a revoked session is rejected",
+			token:   revokedTokenPair.AccessToken,
+			wantErr: true,
+		},
+		{
+			name:    "an access token without a jti is rejected",
+			token:   "Bearer " + helper.GenerateToken(userID.Hex(), constant.RoleBuyer, "", "", constant.TokenTypeAccess, time.Now().Add(time.Hour)),
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", tt.token)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			_, err := getPayload(c)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("getPayload() error = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
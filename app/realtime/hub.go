@@ -0,0 +1,123 @@
+package realtime
+
+import (
+	"context"
+	"crop_connect/business/events"
+	"errors"
+	"sync"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// bufferSize is how many events a slow stream may fall behind before new ones are dropped for it.
+const bufferSize = 32
+
+// Hub keeps the open streams of every user in memory, so each instance of the app only pushes the changes made through it.
+type Hub struct {
+	mu          sync.Mutex
+	subscribers map[primitive.ObjectID]map[chan events.Domain]struct{}
+	isClosed    bool
+	wg          sync.WaitGroup
+}
+
+func NewHub() *Hub {
+	return &Hub{
+		subscribers: map[primitive.ObjectID]map[chan events.Domain]struct{}{},
+	}
+}
+
+func (h *Hub) Publish(domain events.Domain) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.isClosed {
+		return
+	}
+
+	sent := map[primitive.ObjectID]bool{}
+	for _, userID := range domain.UserIDs {
+		if userID == primitive.NilObjectID || sent[userID] {
+			continue
+		}
+
+		sent[userID] = true
+		for stream := range h.subscribers[userID] {
+			select {
+			case stream <- domain:
+			default:
+			}
+		}
+	}
+}
+
+// Subscribe opens a stream for the user, the returned function closes it and must be called once the client is gone.
+// After Shutdown the stream is returned already closed.
+func (h *Hub) Subscribe(userID primitive.ObjectID) (<-chan events.Domain, func()) {
+	stream := make(chan events.Domain, bufferSize)
+
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.isClosed {
+		close(stream)
+		return stream, func() {}
+	}
+
+	if h.subscribers[userID] == nil {
+		h.subscribers[userID] = map[chan events.Domain]struct{}{}
+	}
+
+	h.subscribers[userID][stream] = struct{}{}
+	h.wg.Add(1)
+
+	var once sync.Once
+	return stream, func() {
+		once.Do(func() {
+			h.mu.Lock()
+			defer h.mu.Unlock()
+
+			if _, ok := h.subscribers[userID][stream]; ok {
+				delete(h.subscribers[userID], stream)
+				if len(h.subscribers[userID]) == 0 {
+					delete(h.subscribers, userID)
+				}
+
+				close(stream)
+			}
+
+			h.wg.Done()
+		})
+	}
+}
+
+// Shutdown closes every stream so the handlers return and the http server can finish, then waits for them to unsubscribe.
+func (h *Hub) Shutdown(ctx context.Context) error {
+	h.mu.Lock()
+	if h.isClosed {
+		h.mu.Unlock()
+		return errors.New("hub sudah dihentikan")
+	}
+
+	h.isClosed = true
+	for userID, streams := range h.subscribers {
+		for stream := range streams {
+			close(stream)
+		}
+
+		delete(h.subscribers, userID)
+	}
+	h.mu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		h.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -3,30 +3,56 @@ package route
 import (
 	_middleware "crop_connect/app/middleware"
 	"crop_connect/constant"
+	apiKeys "crop_connect/controller/api_keys"
+	auditEvents "crop_connect/controller/audit_events"
 	"crop_connect/controller/batchs"
 	"crop_connect/controller/commodities"
+	"crop_connect/controller/conversations"
+	"crop_connect/controller/disputes"
+	emailVerifications "crop_connect/controller/email_verifications"
+	"crop_connect/controller/events"
 	forgotPassword "crop_connect/controller/forgot_password"
 	"crop_connect/controller/harvests"
+	jobHistories "crop_connect/controller/job_histories"
+	"crop_connect/controller/notifications"
+	"crop_connect/controller/payments"
+	"crop_connect/controller/policies"
 	"crop_connect/controller/proposals"
+	"crop_connect/controller/ratings"
 	"crop_connect/controller/regions"
+	"crop_connect/controller/shipments"
 	"crop_connect/controller/transactions"
 	treatmentRecords "crop_connect/controller/treatment_records"
 	"crop_connect/controller/users"
+	"crop_connect/controller/webhooks"
 	"net/http"
 
 	"github.com/labstack/echo/v4"
 )
 
 type ControllerList struct {
-	UserController            *users.Controller
-	CommodityController       *commodities.Controller
-	ProposalController        *proposals.Controller
-	TransactionController     *transactions.Controller
-	BatchController           *batchs.Controller
-	TreatmentRecordController *treatmentRecords.Controller
-	HarvestController         *harvests.Controller
-	RegionController          *regions.Controller
-	ForgotPasswordController  *forgotPassword.Controller
+	UserController              *users.Controller
+	CommodityController         *commodities.Controller
+	ProposalController          *proposals.Controller
+	TransactionController       *transactions.Controller
+	BatchController             *batchs.Controller
+	TreatmentRecordController   *treatmentRecords.Controller
+	HarvestController           *harvests.Controller
+	RegionController            *regions.Controller
+	ForgotPasswordController    *forgotPassword.Controller
+	PaymentController           *payments.Controller
+	ShipmentController          *shipments.Controller
+	NotificationController      *notifications.Controller
+	JobHistoryController        *jobHistories.Controller
+	EmailVerificationController *emailVerifications.Controller
+	PolicyController            *policies.Controller
+	AuditEventController        *auditEvents.Controller
+	DisputeController           *disputes.Controller
+	RatingController            *ratings.Controller
+	ConversationController      *conversations.Controller
+	EventController             *events.Controller
+	WebhookController           *webhooks.Controller
+	APIKeyController            *apiKeys.Controller
 }
 
 func (ctrl *ControllerList) Init(e *echo.Echo) {
@@ -48,88 +74,177 @@ func (ctrl *ControllerList) Init(e *echo.Echo) {
 
 	user := apiV1.Group("/user")
 	user.POST("/register", ctrl.UserController.Register)
-	user.POST("/register-validator", ctrl.UserController.RegisterValidator, _middleware.CheckOneRole(constant.RoleAdmin))
+	user.POST("/register-validator", ctrl.UserController.RegisterValidator, _middleware.Authorize(constant.PermissionUserCreateValidator))
 	user.POST("/login", ctrl.UserController.Login)
+	user.POST("/login/two-factor", ctrl.UserController.LoginTwoFactor)
+	user.POST("/refresh", ctrl.UserController.Refresh)
+	user.POST("/logout", ctrl.UserController.Logout, _middleware.Authenticated())
 	user.GET("/profile", ctrl.UserController.GetProfile, _middleware.Authenticated())
 	user.PUT("/profile", ctrl.UserController.UpdateProfile, _middleware.Authenticated())
-	user.GET("", ctrl.UserController.GetByPaginationAndQueryForAdmin, _middleware.CheckOneRole(constant.RoleAdmin))
-	user.GET("/:user-id", ctrl.UserController.GetByIDForAdmin, _middleware.CheckOneRole(constant.RoleAdmin))
+	user.DELETE("/profile", ctrl.UserController.Delete, _middleware.Authenticated())
+	user.GET("", ctrl.UserController.GetByPaginationAndQueryForAdmin, _middleware.Authorize(constant.PermissionUserRead))
+	user.GET("/:user-id", ctrl.UserController.GetByIDForAdmin, _middleware.Authorize(constant.PermissionUserRead))
+	user.PUT("/:user-id/suspend", ctrl.UserController.Suspend, _middleware.Authorize(constant.PermissionUserSuspend))
+	user.PUT("/:user-id/reactivate", ctrl.UserController.Reactivate, _middleware.Authorize(constant.PermissionUserSuspend))
+	user.PUT("/:user-id/areas", ctrl.UserController.UpdateAreas, _middleware.Authorize(constant.PermissionUserAssignArea))
 	user.GET("/farmer", ctrl.UserController.GetFarmerByPaginationAndQueryForBuyer)
 	user.GET("/farmer/:farmer-id", ctrl.UserController.GetFarmerByIDForBuyer)
 	user.PUT("/change-password", ctrl.UserController.UpdatePassword, _middleware.Authenticated())
-	user.GET("/statistic-new-user", ctrl.UserController.StatisticNewUserByYear, _middleware.CheckOneRole(constant.RoleAdmin))
-	user.GET("/statistic-validator", ctrl.UserController.CountTotalValidatorByYear, _middleware.CheckManyRole([]string{constant.RoleAdmin, constant.RoleValidator}))
+	user.POST("/two-factor/setup", ctrl.UserController.SetupTwoFactor, _middleware.Authenticated())
+	user.POST("/two-factor/enable", ctrl.UserController.EnableTwoFactor, _middleware.Authenticated())
+	user.POST("/two-factor/disable", ctrl.UserController.DisableTwoFactor, _middleware.Authenticated())
+	user.POST("/two-factor/recovery-codes", ctrl.UserController.RegenerateRecoveryCodes, _middleware.Authenticated())
+	user.GET("/statistic-new-user", ctrl.UserController.StatisticNewUserByYear, _middleware.Authorize(constant.PermissionUserStatistic))
+	user.GET("/statistic-validator", ctrl.UserController.CountTotalValidatorByYear, _middleware.Authorize(constant.PermissionValidatorStatistic))
 
 	forgotPassword := user.Group("/forgot-password")
 	forgotPassword.POST("", ctrl.ForgotPasswordController.Generate)
 	forgotPassword.GET("/:token", ctrl.ForgotPasswordController.ValidateToken)
 	forgotPassword.PUT("/:token", ctrl.ForgotPasswordController.ResetPassword)
 
+	emailVerification := user.Group("/verify-email")
+	emailVerification.POST("", ctrl.EmailVerificationController.Generate)
+	emailVerification.PUT("/:token", ctrl.EmailVerificationController.Verify)
+
 	commodity := apiV1.Group("/commodity")
 	commodity.GET("", ctrl.CommodityController.GetForBuyer)
-	commodity.GET("/farmer", ctrl.CommodityController.GetForFarmer, _middleware.CheckOneRole(constant.RoleFarmer))
-	commodity.POST("", ctrl.CommodityController.Create, _middleware.CheckOneRole(constant.RoleFarmer))
+	commodity.GET("/farmer", ctrl.CommodityController.GetForFarmer, _middleware.Authorize(constant.PermissionCommodityManage))
+	commodity.POST("", ctrl.CommodityController.Create, _middleware.Authorize(constant.PermissionCommodityManage))
 	commodity.GET("/:commodity-id", ctrl.CommodityController.GetByID)
-	commodity.PUT("/:commodity-id", ctrl.CommodityController.Update, _middleware.CheckOneRole(constant.RoleFarmer))
-	commodity.DELETE("/:commodity-id", ctrl.CommodityController.Delete, _middleware.CheckOneRole(constant.RoleFarmer))
-	commodity.GET("/statistic-total", ctrl.CommodityController.CountTotalCommodity, _middleware.CheckManyRole([]string{constant.RoleAdmin, constant.RoleValidator}))
+	commodity.PUT("/:commodity-id", ctrl.CommodityController.Update, _middleware.Authorize(constant.PermissionCommodityManage))
+	commodity.DELETE("/:commodity-id", ctrl.CommodityController.Delete, _middleware.Authorize(constant.PermissionCommodityManage))
+	commodity.GET("/statistic-total", ctrl.CommodityController.CountTotalCommodity, _middleware.Authorize(constant.PermissionCommodityStatistic))
 	commodity.GET("/farmer-total/:farmer-id", ctrl.CommodityController.CountTotalCommodityByFarmer)
-	commodity.GET("/perennials", ctrl.CommodityController.GetPerennialsByFarmerID, _middleware.CheckOneRole(constant.RoleFarmer))
+	commodity.GET("/perennials", ctrl.CommodityController.GetPerennialsByFarmerID, _middleware.Authorize(constant.PermissionCommodityManage))
 
 	proposal := apiV1.Group("/proposal")
 	proposal.GET("/commodity/:commodity-id", ctrl.ProposalController.GetByCommodityIDForBuyer)
-	proposal.POST("/:commodity-id", ctrl.ProposalController.Create, _middleware.CheckOneRole(constant.RoleFarmer))
-	proposal.GET("/:proposal-id", ctrl.ProposalController.GetByID, _middleware.CheckManyRole([]string{constant.RoleFarmer, constant.RoleAdmin, constant.RoleValidator}))
-	proposal.PUT("/:proposal-id", ctrl.ProposalController.Update, _middleware.CheckOneRole(constant.RoleFarmer))
-	proposal.DELETE("/:proposal-id", ctrl.ProposalController.Delete, _middleware.CheckOneRole(constant.RoleFarmer))
-	proposal.PUT("/validate/:proposal-id", ctrl.ProposalController.ValidateByValidator, _middleware.CheckOneRole(constant.RoleValidator))
+	proposal.GET("/queue", ctrl.ProposalController.GetQueue, _middleware.Authorize(constant.PermissionProposalQueue))
+	proposal.POST("/:commodity-id", ctrl.ProposalController.Create, _middleware.Authorize(constant.PermissionProposalManage))
+	proposal.GET("/:proposal-id", ctrl.ProposalController.GetByID, _middleware.Authorize(constant.PermissionProposalRead))
+	proposal.PUT("/:proposal-id", ctrl.ProposalController.Update, _middleware.Authorize(constant.PermissionProposalManage))
+	proposal.DELETE("/:proposal-id", ctrl.ProposalController.Delete, _middleware.Authorize(constant.PermissionProposalManage))
+	proposal.PUT("/validate/:proposal-id", ctrl.ProposalController.ValidateByValidator, _middleware.Authorize(constant.PermissionProposalValidate))
+	proposal.PUT("/assign/:proposal-id", ctrl.ProposalController.Assign, _middleware.Authorize(constant.PermissionProposalAssign))
 	proposal.GET("/id/:proposal-id", ctrl.ProposalController.GetByIDAccepted)
-	proposal.GET("/statistic", ctrl.ProposalController.StatisticByYear, _middleware.CheckOneRole(constant.RoleAdmin))
+	proposal.GET("/statistic", ctrl.ProposalController.StatisticByYear, _middleware.Authorize(constant.PermissionProposalStatistic))
 	proposal.GET("/farmer-total/:farmer-id", ctrl.ProposalController.CountTotalProposalByFarmer)
-	proposal.GET("", ctrl.ProposalController.GetByPaginationAndQuery, _middleware.CheckManyRole([]string{constant.RoleFarmer, constant.RoleValidator}))
-	proposal.GET("/perennials/:commodity-id", ctrl.ProposalController.GetForPerennials, _middleware.CheckOneRole(constant.RoleFarmer))
+	proposal.GET("", ctrl.ProposalController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionProposalList))
+	proposal.GET("/perennials/:commodity-id", ctrl.ProposalController.GetForPerennials, _middleware.Authorize(constant.PermissionProposalManage))
 
 	transaction := apiV1.Group("/transaction")
-	transaction.GET("", ctrl.TransactionController.GetUserTransactionWithPagination, _middleware.CheckManyRole([]string{constant.RoleBuyer, constant.RoleFarmer}))
-	transaction.POST("", ctrl.TransactionController.Create, _middleware.CheckOneRole(constant.RoleBuyer))
-	transaction.GET("/:transaction-id", ctrl.TransactionController.GetByID, _middleware.CheckManyRole([]string{constant.RoleBuyer, constant.RoleFarmer}))
-	transaction.PUT("/:transaction-id", ctrl.TransactionController.MakeDecision, _middleware.CheckOneRole(constant.RoleFarmer))
-	transaction.GET("/statistic", ctrl.TransactionController.StatisticByYear, _middleware.CheckManyRole([]string{constant.RoleAdmin, constant.RoleFarmer}))
-	transaction.GET("/statistic-province", ctrl.TransactionController.StatisticTopProvince, _middleware.CheckOneRole(constant.RoleAdmin))
-	transaction.GET("/statistic-commodity", ctrl.TransactionController.StatisticTopCommodity, _middleware.CheckManyRole([]string{constant.RoleAdmin, constant.RoleFarmer}))
+	transaction.GET("", ctrl.TransactionController.GetUserTransactionWithPagination, _middleware.Authorize(constant.PermissionTransactionRead))
+	transaction.POST("", ctrl.TransactionController.Create, _middleware.Authorize(constant.PermissionTransactionCreate))
+	transaction.GET("/:transaction-id", ctrl.TransactionController.GetByID, _middleware.Authorize(constant.PermissionTransactionRead))
+	transaction.PUT("/:transaction-id", ctrl.TransactionController.MakeDecision, _middleware.Authorize(constant.PermissionTransactionDecide))
+	transaction.GET("/statistic", ctrl.TransactionController.StatisticByYear, _middleware.Authorize(constant.PermissionTransactionStatistic))
+	transaction.GET("/statistic-province", ctrl.TransactionController.StatisticTopProvince, _middleware.Authorize(constant.PermissionTransactionStatisticProvince))
+	transaction.GET("/statistic-commodity", ctrl.TransactionController.StatisticTopCommodity, _middleware.Authorize(constant.PermissionTransactionStatistic))
 	transaction.GET("/total-commodity/:commodity-id", ctrl.TransactionController.CountByCommodityID)
-	transaction.PUT("/cancel/:transaction-id", ctrl.TransactionController.CancelOnPending, _middleware.CheckOneRole(constant.RoleBuyer))
+	transaction.PUT("/cancel/:transaction-id", ctrl.TransactionController.CancelOnPending, _middleware.Authorize(constant.PermissionTransactionCancel))
+	transaction.POST("/offer/:transaction-id", ctrl.TransactionController.MakeOffer, _middleware.Authorize(constant.PermissionTransactionNegotiate))
+	transaction.PUT("/offer/accept/:transaction-id", ctrl.TransactionController.AcceptOffer, _middleware.Authorize(constant.PermissionTransactionNegotiate))
+
+	payment := apiV1.Group("/payment")
+	payment.POST("/webhook", ctrl.PaymentController.Webhook)
+	payment.POST("/:transaction-id", ctrl.PaymentController.Create, _middleware.Authorize(constant.PermissionPaymentCreate))
+	payment.GET("/transaction/:transaction-id", ctrl.PaymentController.GetByTransactionID, _middleware.Authorize(constant.PermissionPaymentRead))
+	payment.PUT("/refund/:payment-id", ctrl.PaymentController.Refund, _middleware.Authorize(constant.PermissionPaymentRefund))
 
 	batch := apiV1.Group("/batch")
-	batch.GET("", ctrl.BatchController.GetByPaginationAndQuery, _middleware.CheckManyRole([]string{constant.RoleFarmer, constant.RoleValidator}))
-	batch.POST("/create/:proposal-id", ctrl.BatchController.CreateForPerennials, _middleware.CheckOneRole(constant.RoleFarmer))
+	batch.GET("", ctrl.BatchController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionBatchRead))
+	batch.POST("/create/:proposal-id", ctrl.BatchController.CreateForPerennials, _middleware.Authorize(constant.PermissionBatchManage))
 	batch.GET("/commodity/:commodity-id", ctrl.BatchController.GetByCommodityID)
-	batch.GET("/statistic-total", ctrl.BatchController.CountByYear, _middleware.CheckManyRole([]string{constant.RoleAdmin, constant.RoleValidator}))
-	batch.GET("/:batch-id", ctrl.BatchController.GetByID, _middleware.CheckManyRole([]string{constant.RoleFarmer, constant.RoleValidator}))
+	batch.GET("/statistic-total", ctrl.BatchController.CountByYear, _middleware.Authorize(constant.PermissionBatchStatistic))
+	batch.GET("/:batch-id", ctrl.BatchController.GetByID, _middleware.Authorize(constant.PermissionBatchRead))
 	batch.GET("/transaction/commodity/:commodity-id", ctrl.BatchController.GetForTransactionByCommodityID)
 	batch.GET("/transaction/id/:batch-id", ctrl.BatchController.GetForTransactionByID)
-	batch.GET("/harvest/all", ctrl.BatchController.GetForHarvestByCommmodityID, _middleware.CheckOneRole(constant.RoleFarmer))
-	// batch.PUT("/cancel/:batch-id", ctrl.BatchController.Cancel, _middleware.CheckOneRole(constant.RoleFarmer))
+	batch.GET("/harvest/all", ctrl.BatchController.GetForHarvestByCommmodityID, _middleware.Authorize(constant.PermissionBatchManage))
+	batch.PUT("/cancel/:batch-id", ctrl.BatchController.Cancel, _middleware.Authorize(constant.PermissionBatchManage))
 
 	treatmentRecord := apiV1.Group("/treatment-record")
-	treatmentRecord.GET("", ctrl.TreatmentRecordController.GetByPaginationAndQuery, _middleware.CheckManyRole([]string{constant.RoleFarmer, constant.RoleValidator}))
-	treatmentRecord.POST("/:batch-id", ctrl.TreatmentRecordController.RequestToFarmer, _middleware.CheckOneRole(constant.RoleValidator))
-	treatmentRecord.GET("/:treatment-record-id", ctrl.TreatmentRecordController.GetByID, _middleware.CheckManyRole([]string{constant.RoleValidator, constant.RoleFarmer}))
-	treatmentRecord.PUT("/:treatment-record-id", ctrl.TreatmentRecordController.FillTreatmentRecord, _middleware.CheckOneRole(constant.RoleFarmer))
-	treatmentRecord.PUT("/validate/:treatment-record-id", ctrl.TreatmentRecordController.Validate, _middleware.CheckOneRole(constant.RoleValidator))
-	treatmentRecord.PUT("/note/:treatment-record-id", ctrl.TreatmentRecordController.UpdateNotes, _middleware.CheckOneRole(constant.RoleValidator))
-	treatmentRecord.GET("/statistic", ctrl.TreatmentRecordController.StatisticByYear, _middleware.CheckManyRole([]string{constant.RoleAdmin, constant.RoleValidator}))
-	treatmentRecord.GET("/statistic-total", ctrl.TreatmentRecordController.CountByYear, _middleware.CheckOneRole(constant.RoleValidator))
+	treatmentRecord.GET("", ctrl.TreatmentRecordController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionTreatmentRecordRead))
+	treatmentRecord.POST("/:batch-id", ctrl.TreatmentRecordController.RequestToFarmer, _middleware.Authorize(constant.PermissionTreatmentRecordRequest))
+	treatmentRecord.GET("/:treatment-record-id", ctrl.TreatmentRecordController.GetByID, _middleware.Authorize(constant.PermissionTreatmentRecordRead))
+	treatmentRecord.PUT("/:treatment-record-id", ctrl.TreatmentRecordController.FillTreatmentRecord, _middleware.Authorize(constant.PermissionTreatmentRecordFill))
+	treatmentRecord.PUT("/validate/:treatment-record-id", ctrl.TreatmentRecordController.Validate, _middleware.Authorize(constant.PermissionTreatmentRecordValidate))
+	treatmentRecord.PUT("/note/:treatment-record-id", ctrl.TreatmentRecordController.UpdateNotes, _middleware.Authorize(constant.PermissionTreatmentRecordValidate))
+	treatmentRecord.GET("/statistic", ctrl.TreatmentRecordController.StatisticByYear, _middleware.Authorize(constant.PermissionTreatmentRecordStatistic))
+	treatmentRecord.GET("/statistic-total", ctrl.TreatmentRecordController.CountByYear, _middleware.Authorize(constant.PermissionTreatmentRecordCount))
 	treatmentRecord.GET("/batch", ctrl.TreatmentRecordController.GetByBatchID)
 
 	harvest := apiV1.Group("/harvest")
-	harvest.GET("", ctrl.HarvestController.GetByPaginationAndQuery, _middleware.CheckManyRole([]string{constant.RoleFarmer, constant.RoleValidator}))
+	harvest.GET("", ctrl.HarvestController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionHarvestRead))
 	harvest.GET("/batch", ctrl.HarvestController.GetByBatchID)
-	harvest.POST("/:batch-id", ctrl.HarvestController.SubmitHarvest, _middleware.CheckOneRole(constant.RoleFarmer))
-	harvest.PUT("/validate/:harvest-id", ctrl.HarvestController.Validate, _middleware.CheckOneRole(constant.RoleValidator))
-	harvest.GET("/statistic-total", ctrl.HarvestController.CountByYear, _middleware.CheckOneRole(constant.RoleAdmin))
-	harvest.PUT("/:harvest-id", ctrl.HarvestController.Update, _middleware.CheckOneRole(constant.RoleFarmer))
-	harvest.GET("/:harvest-id", ctrl.HarvestController.GetByID, _middleware.CheckManyRole([]string{constant.RoleFarmer, constant.RoleValidator}))
+	harvest.POST("/:batch-id", ctrl.HarvestController.SubmitHarvest, _middleware.Authorize(constant.PermissionHarvestSubmit))
+	harvest.PUT("/validate/:harvest-id", ctrl.HarvestController.Validate, _middleware.Authorize(constant.PermissionHarvestValidate))
+	harvest.GET("/statistic-total", ctrl.HarvestController.CountByYear, _middleware.Authorize(constant.PermissionHarvestStatistic))
+	harvest.PUT("/:harvest-id", ctrl.HarvestController.Update, _middleware.Authorize(constant.PermissionHarvestSubmit))
+	harvest.GET("/:harvest-id", ctrl.HarvestController.GetByID, _middleware.Authorize(constant.PermissionHarvestRead))
+
+	shipment := apiV1.Group("/shipment")
+	shipment.GET("", ctrl.ShipmentController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionShipmentRead))
+	shipment.GET("/:shipment-id", ctrl.ShipmentController.GetByID, _middleware.Authorize(constant.PermissionShipmentRead))
+	shipment.GET("/transaction/:transaction-id", ctrl.ShipmentController.GetByTransactionID, _middleware.Authorize(constant.PermissionShipmentRead))
+	shipment.PUT("/dispatch/:shipment-id", ctrl.ShipmentController.Dispatch, _middleware.Authorize(constant.PermissionShipmentDispatch))
+	shipment.PUT("/confirm/:shipment-id", ctrl.ShipmentController.ConfirmReceipt, _middleware.Authorize(constant.PermissionShipmentConfirm))
+
+	notification := apiV1.Group("/notification")
+	notification.GET("", ctrl.NotificationController.GetByPaginationAndQuery, _middleware.Authenticated())
+	notification.GET("/unread-count", ctrl.NotificationController.CountUnread, _middleware.Authenticated())
+	notification.PUT("/read", ctrl.NotificationController.MarkAllAsRead, _middleware.Authenticated())
+	notification.PUT("/read/:notification-id", ctrl.NotificationController.MarkAsRead, _middleware.Authenticated())
+
+	jobHistory := apiV1.Group("/job-history")
+	jobHistory.GET("", ctrl.JobHistoryController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionJobHistoryRead))
+
+	auditEvent := apiV1.Group("/audit-event")
+	auditEvent.GET("", ctrl.AuditEventController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionAuditEventRead))
+	auditEvent.GET("/:entity-type/:entity-id", ctrl.AuditEventController.GetByEntity, _middleware.Authorize(constant.PermissionAuditEventHistory))
+
+	dispute := apiV1.Group("/dispute")
+	dispute.GET("", ctrl.DisputeController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionDisputeRead))
+	dispute.GET("/:dispute-id", ctrl.DisputeController.GetByID, _middleware.Authorize(constant.PermissionDisputeRead))
+	dispute.POST("/:transaction-id", ctrl.DisputeController.Open, _middleware.Authorize(constant.PermissionDisputeOpen))
+	dispute.POST("/message/:dispute-id", ctrl.DisputeController.AddMessage, _middleware.Authorize(constant.PermissionDisputeMessage))
+	dispute.PUT("/resolve/:dispute-id", ctrl.DisputeController.Resolve, _middleware.Authorize(constant.PermissionDisputeResolve))
+
+	rating := apiV1.Group("/rating")
+	rating.GET("/farmer/:farmer-id", ctrl.RatingController.GetByFarmerID)
+	rating.GET("/commodity/:commodity-id", ctrl.RatingController.GetByCommodityID)
+	rating.POST("/:transaction-id", ctrl.RatingController.Create, _middleware.Authorize(constant.PermissionRatingCreate))
+
+	conversation := apiV1.Group("/conversation")
+	conversation.POST("", ctrl.ConversationController.Start, _middleware.Authorize(constant.PermissionConversationStart))
+	conversation.GET("", ctrl.ConversationController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionConversationRead))
+	conversation.GET("/:conversation-id", ctrl.ConversationController.GetByID, _middleware.Authorize(constant.PermissionConversationRead))
+	conversation.GET("/message/:conversation-id", ctrl.ConversationController.GetMessages, _middleware.Authorize(constant.PermissionConversationRead))
+	conversation.POST("/message/:conversation-id", ctrl.ConversationController.SendMessage, _middleware.Authorize(constant.PermissionConversationMessage))
+	conversation.PUT("/read/:conversation-id", ctrl.ConversationController.MarkAsRead, _middleware.Authorize(constant.PermissionConversationMessage))
+
+	event := apiV1.Group("/event")
+	event.GET("/stream", ctrl.EventController.Stream, _middleware.TokenFromQuery(), _middleware.Authenticated())
+
+	webhook := apiV1.Group("/webhook")
+	webhook.POST("", ctrl.WebhookController.Create, _middleware.Authorize(constant.PermissionWebhookManage))
+	webhook.GET("", ctrl.WebhookController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionWebhookManage))
+	webhook.GET("/delivery", ctrl.WebhookController.GetDeliveriesByPaginationAndQuery, _middleware.Authorize(constant.PermissionWebhookManage))
+	webhook.GET("/delivery/:delivery-id", ctrl.WebhookController.GetDeliveryByID, _middleware.Authorize(constant.PermissionWebhookManage))
+	webhook.POST("/delivery/replay/:delivery-id", ctrl.WebhookController.Replay, _middleware.Authorize(constant.PermissionWebhookManage))
+	webhook.POST("/test-receiver", ctrl.WebhookController.TestReceiver)
+	webhook.GET("/:webhook-id", ctrl.WebhookController.GetByID, _middleware.Authorize(constant.PermissionWebhookManage))
+	webhook.PUT("/:webhook-id", ctrl.WebhookController.Update, _middleware.Authorize(constant.PermissionWebhookManage))
+	webhook.DELETE("/:webhook-id", ctrl.WebhookController.Delete, _middleware.Authorize(constant.PermissionWebhookManage))
+
+	apiKey := apiV1.Group("/api-key")
+	apiKey.POST("", ctrl.APIKeyController.Create, _middleware.Authorize(constant.PermissionAPIKeyManage))
+	apiKey.GET("", ctrl.APIKeyController.GetByPaginationAndQuery, _middleware.Authorize(constant.PermissionAPIKeyManage))
+	apiKey.GET("/scope", ctrl.APIKeyController.GetScopes, _middleware.Authorize(constant.PermissionAPIKeyManage))
+	apiKey.GET("/:api-key-id", ctrl.APIKeyController.GetByID, _middleware.Authorize(constant.PermissionAPIKeyManage))
+	apiKey.PUT("/revoke/:api-key-id", ctrl.APIKeyController.Revoke, _middleware.Authorize(constant.PermissionAPIKeyManage))
+
+	policy := apiV1.Group("/policy")
+	policy.GET("", ctrl.PolicyController.GetMatrix, _middleware.Authorize(constant.PermissionPolicyRead))
 
 	region := apiV1.Group("/region")
 	region.GET("/province", ctrl.RegionController.GetByCountry)
@@ -0,0 +1,134 @@
+package scheduler
+
+import (
+	"context"
+	jobHistories "crop_connect/business/job_histories"
+	"crop_connect/constant"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Job is run every Interval, Run returns how many records it affected.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func() (int, error)
+}
+
+// Scheduler runs every job on its own ticker and records each run in the job history.
+type Scheduler struct {
+	jobHistoryRepository jobHistories.Repository
+	jobs                 []Job
+	logger               echo.Logger
+	stop                 chan struct{}
+	wg                   sync.WaitGroup
+}
+
+func NewScheduler(jhr jobHistories.Repository, jobs []Job, logger echo.Logger) *Scheduler {
+	return &Scheduler{
+		jobHistoryRepository: jhr,
+		jobs:                 jobs,
+		logger:               logger,
+		stop:                 make(chan struct{}),
+	}
+}
+
+// Start runs every job with a positive interval, a job with an interval of zero is disabled.
+func (s *Scheduler) Start() {
+	for _, job := range s.jobs {
+		if job.Interval <= 0 {
+			s.logger.Infof("scheduled job %s is disabled", job.Name)
+			continue
+		}
+
+		s.wg.Add(1)
+		go s.schedule(job)
+	}
+}
+
+// Shutdown stops the tickers and waits for the running jobs to finish.
+func (s *Scheduler) Shutdown(ctx context.Context) error {
+	close(s.stop)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Wait blocks until every job has returned after Shutdown.
+func (s *Scheduler) Wait() {
+	s.wg.Wait()
+}
+
+func (s *Scheduler) schedule(job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+			s.run(job)
+		}
+	}
+}
+
+func (s *Scheduler) run(job Job) {
+	jobHistory := jobHistories.Domain{
+		ID:        primitive.NewObjectID(),
+		Name:      job.Name,
+		Status:    constant.JobHistoryStatusSuccess,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	affected, err := s.runJob(job)
+	if err != nil {
+		jobHistory.Status = constant.JobHistoryStatusFailed
+		jobHistory.Error = err.Error()
+	}
+
+	jobHistory.Affected = affected
+	jobHistory.FinishedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	if _, err := s.jobHistoryRepository.Create(context.Background(), &jobHistory); err != nil {
+		s.logger.Errorf("scheduled job %s: failed to record history: %s", job.Name, err.Error())
+	}
+}
+
+// runJob records a panic of the job as its error, so it cannot take the whole process down.
+func (s *Scheduler) runJob(job Job) (affected int, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			s.logger.Errorf("scheduled job %s: panic: %v", job.Name, r)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+
+	return job.Run()
+}
+
+// ParseInterval reads an interval such as "1h" or "30m", an empty or invalid value falls back to defaultInterval.
+func ParseInterval(value string, defaultInterval time.Duration) time.Duration {
+	interval, err := time.ParseDuration(value)
+	if err != nil {
+		return defaultInterval
+	}
+
+	return interval
+}
@@ -0,0 +1,60 @@
+package scheduler
+
+import (
+	"crop_connect/constant"
+	"crop_connect/driver"
+	memoryDriver "crop_connect/driver/memory"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+func TestRun(t *testing.T) {
+	tests := []struct {
+		name         string
+		run          func() (int, error)
+		wantStatus   string
+		wantAffected int
+		wantError    string
+	}{
+		{
+			name:         "a successful run records how many records it affected",
+			run:          func() (int, error) { return 3, nil },
+			wantStatus:   constant.JobHistoryStatusSuccess,
+			wantAffected: 3,
+		},
+		{
+			name:         "a failed run records its error",
+			run:          func() (int, error) { return 1, errors.New("gagal") },
+			wantStatus:   constant.JobHistoryStatusFailed,
+			wantAffected: 1,
+			wantError:    "gagal",
+		},
+		{
+			name:       "a panicking run is recorded as failed instead of stopping the process",
+			run:        func() (int, error) { panic("nil map") },
+			wantStatus: constant.JobHistoryStatusFailed,
+			wantError:  "panic: nil map",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memoryDriver.Init()
+			s := NewScheduler(driver.NewJobHistoryMemoryRepository(db), nil, echo.New().Logger)
+
+			s.run(Job{Name: "test", Interval: time.Minute, Run: tt.run})
+
+			if len(db.JobHistories) != 1 {
+				t.Fatalf("job histories = %d, want 1", len(db.JobHistories))
+			}
+
+			jobHistory := db.JobHistories[0]
+			if jobHistory.Status != tt.wantStatus || jobHistory.Affected != tt.wantAffected || jobHistory.Error != tt.wantError {
+				t.Errorf("job history = %s %d %q, want %s %d %q", jobHistory.Status, jobHistory.Affected, jobHistory.Error, tt.wantStatus, tt.wantAffected, tt.wantError)
+			}
+		})
+	}
+}
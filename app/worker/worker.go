@@ -0,0 +1,180 @@
+package worker
+
+import (
+	"context"
+	"crop_connect/business/jobs"
+	"crop_connect/constant"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+const (
+	pollInterval      = 2 * time.Second
+	lockDuration      = 5 * time.Minute
+	lockRenewInterval = lockDuration / 3
+	baseBackoff       = 30 * time.Second
+	maxBackoff        = 1 * time.Hour
+)
+
+// Pool runs the queued jobs with a fixed number of goroutines polling the job repository.
+type Pool struct {
+	jobRepository jobs.Repository
+	handlers      map[string]jobs.Handler
+	size          int
+	logger        echo.Logger
+	stop          chan struct{}
+	wg            sync.WaitGroup
+}
+
+func NewPool(jr jobs.Repository, handlers map[string]jobs.Handler, size int, logger echo.Logger) *Pool {
+	if size < 1 {
+		size = 1
+	}
+
+	return &Pool{
+		jobRepository: jr,
+		handlers:      handlers,
+		size:          size,
+		logger:        logger,
+		stop:          make(chan struct{}),
+	}
+}
+
+func (p *Pool) Start() {
+	for i := 0; i < p.size; i++ {
+		p.wg.Add(1)
+		go p.work()
+	}
+}
+
+// Shutdown stops claiming new jobs and waits for the running ones to finish.
+// A job still running when ctx is done keeps its lock and is claimed again once the lock expires.
+func (p *Pool) Shutdown(ctx context.Context) error {
+	close(p.stop)
+
+	done := make(chan struct{})
+	go func() {
+		p.wg.Wait()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Wait blocks until every worker has returned after Shutdown.
+func (p *Pool) Wait() {
+	p.wg.Wait()
+}
+
+func (p *Pool) work() {
+	defer p.wg.Done()
+
+	for {
+		select {
+		case <-p.stop:
+			return
+		default:
+		}
+
+		now := time.Now()
+		job, err := p.jobRepository.ClaimNext(primitive.NewDateTimeFromTime(now), primitive.NewDateTimeFromTime(now.Add(lockDuration)))
+		if err != nil {
+			select {
+			case <-p.stop:
+				return
+			case <-time.After(pollInterval):
+			}
+			continue
+		}
+
+		p.run(job)
+	}
+}
+
+func (p *Pool) run(job jobs.Domain) {
+	handler, ok := p.handlers[job.Type]
+
+	var err error
+	if !ok {
+		err = fmt.Errorf("handler untuk job %s tidak ditemukan", job.Type)
+	} else {
+		stopRenewing := p.renewLock(job.ID)
+		err = handler(job.Payload)
+		stopRenewing()
+	}
+
+	now := time.Now()
+	job.UpdatedAt = primitive.NewDateTimeFromTime(now)
+	job.LockedUntil = 0
+
+	if err == nil {
+		job.Status = constant.JobStatusDone
+		job.LastError = ""
+		job.FinishedAt = primitive.NewDateTimeFromTime(now)
+	} else if !ok || job.Attempts >= job.MaxAttempts {
+		job.Status = constant.JobStatusDead
+		job.LastError = err.Error()
+		job.FinishedAt = primitive.NewDateTimeFromTime(now)
+	} else {
+		job.Status = constant.JobStatusPending
+		job.LastError = err.Error()
+		job.RunAt = primitive.NewDateTimeFromTime(now.Add(backoff(job.Attempts)))
+	}
+
+	if _, err := p.jobRepository.Update(&job); err != nil {
+		p.logger.Errorf("job %s: failed to update status: %s", job.ID.Hex(), err.Error())
+	}
+}
+
+// renewLock keeps extending the lock of a running job until the returned function is called, which also waits for a renewal in progress.
+func (p *Pool) renewLock(id primitive.ObjectID) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+
+	go func() {
+		defer close(stopped)
+
+		ticker := time.NewTicker(lockRenewInterval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				err := p.jobRepository.ExtendLock(id, primitive.NewDateTimeFromTime(time.Now().Add(lockDuration)))
+				if err != nil {
+					p.logger.Errorf("job %s: failed to extend lock: %s", id.Hex(), err.Error())
+				}
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// backoff doubles the delay after every failed attempt, starting from baseBackoff.
+func backoff(attempts int) time.Duration {
+	delay := baseBackoff
+	for i := 1; i < attempts && delay < maxBackoff; i++ {
+		delay *= 2
+	}
+
+	if delay > maxBackoff {
+		return maxBackoff
+	}
+
+	return delay
+}
@@ -0,0 +1,56 @@
+package api_keys
+
+import (
+	"crop_connect/business/users"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is a key an admin issues to a partner integration, it acts as its user but only within its scopes.
+// Only the hash of the key is stored, Prefix is kept to recognise the key in listings.
+type Domain struct {
+	ID         primitive.ObjectID
+	UserID     primitive.ObjectID
+	Name       string
+	Prefix     string
+	KeyHash    string
+	Scopes     []string
+	ExpiresAt  primitive.DateTime
+	LastUsedAt primitive.DateTime
+	RevokedAt  primitive.DateTime
+	CreatedBy  primitive.ObjectID
+	CreatedAt  primitive.DateTime
+	UpdatedAt  primitive.DateTime
+}
+
+type Query struct {
+	Skip      int64
+	Limit     int64
+	Sort      string
+	Order     int
+	UserID    primitive.ObjectID
+	IsRevoked *bool
+}
+
+type Repository interface {
+	// Create
+	Create(domain *Domain) (Domain, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, error)
+	GetByKeyHash(keyHash string) (Domain, error)
+	GetByQuery(query Query) ([]Domain, int, error)
+	// Update
+	Update(domain *Domain) (Domain, error)
+	UpdateLastUsedAt(id primitive.ObjectID, lastUsedAt primitive.DateTime) error
+}
+
+type UseCase interface {
+	// Create
+	Create(domain *Domain) (Domain, string, int, error)
+	// Read
+	Authenticate(key string) (Domain, users.Domain, error)
+	GetByID(id primitive.ObjectID) (Domain, int, error)
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	// Update
+	Revoke(id primitive.ObjectID) (Domain, int, error)
+}
@@ -0,0 +1,192 @@
+package api_keys
+
+import (
+	"crop_connect/business/policies"
+	"crop_connect/business/users"
+	"crop_connect/constant"
+	"crop_connect/util"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+// lastUsedInterval limits how often the last use of a key is written, a busy integration would otherwise write on every request.
+const lastUsedInterval = time.Minute
+
+type APIKeyUseCase struct {
+	apiKeyRepository Repository
+	userRepository   users.Repository
+	policyUseCase    policies.UseCase
+}
+
+func NewUseCase(akr Repository, ur users.Repository, pu policies.UseCase) UseCase {
+	return &APIKeyUseCase{
+		apiKeyRepository: akr,
+		userRepository:   ur,
+		policyUseCase:    pu,
+	}
+}
+
+var errorAPIKey = errors.New("api key tidak valid")
+
+/*
+Util
+*/
+
+func hashKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+func generateKey() (string, error) {
+	secret := make([]byte, 24)
+	if _, err := rand.Read(secret); err != nil {
+		return "", err
+	}
+
+	return constant.APIKeyPrefix + hex.EncodeToString(secret), nil
+}
+
+/*
+Create
+*/
+
+// Create returns the key itself next to the saved domain, it cannot be shown again because only its hash is kept.
+func (aku *APIKeyUseCase) Create(domain *Domain) (Domain, string, int, error) {
+	user, err := aku.userRepository.GetByID(domain.UserID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, "", http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, "", http.StatusInternalServerError, errors.New("gagal mendapatkan user")
+	}
+
+	if user.Role == constant.RoleAdmin {
+		return Domain{}, "", http.StatusBadRequest, errors.New("api key hanya untuk petani, validator, dan pembeli")
+	}
+
+	if user.Status != constant.UserStatusActive {
+		return Domain{}, "", http.StatusBadRequest, errors.New("user tidak aktif")
+	}
+
+	for _, scope := range domain.Scopes {
+		if !util.CheckStringOnArray(aku.policyUseCase.GetScopes(), scope) {
+			return Domain{}, "", http.StatusBadRequest, fmt.Errorf("scope %s tidak tersedia", scope)
+		}
+	}
+
+	if !domain.ExpiresAt.Time().After(time.Now()) {
+		return Domain{}, "", http.StatusBadRequest, errors.New("tanggal kedaluwarsa harus setelah hari ini")
+	}
+
+	key, err := generateKey()
+	if err != nil {
+		return Domain{}, "", http.StatusInternalServerError, errors.New("gagal membuat api key")
+	}
+
+	domain.ID = primitive.NewObjectID()
+	domain.Prefix = key[:len(constant.APIKeyPrefix)+8]
+	domain.KeyHash = hashKey(key)
+	domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = aku.apiKeyRepository.Create(domain)
+	if err != nil {
+		return Domain{}, "", http.StatusInternalServerError, errors.New("gagal membuat api key")
+	}
+
+	return *domain, key, http.StatusCreated, nil
+}
+
+/*
+Read
+*/
+
+// Authenticate finds the key and its user, the caller still checks that the user is active.
+func (aku *APIKeyUseCase) Authenticate(key string) (Domain, users.Domain, error) {
+	if !strings.HasPrefix(key, constant.APIKeyPrefix) {
+		return Domain{}, users.Domain{}, errorAPIKey
+	}
+
+	apiKey, err := aku.apiKeyRepository.GetByKeyHash(hashKey(key))
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, users.Domain{}, errorAPIKey
+	} else if err != nil {
+		return Domain{}, users.Domain{}, errors.New("gagal mendapatkan api key")
+	}
+
+	now := time.Now()
+	if apiKey.RevokedAt != 0 {
+		return Domain{}, users.Domain{}, errors.New("api key telah dicabut")
+	}
+
+	if !apiKey.ExpiresAt.Time().After(now) {
+		return Domain{}, users.Domain{}, errors.New("api key sudah kedaluwarsa")
+	}
+
+	user, err := aku.userRepository.GetByID(apiKey.UserID)
+	if err != nil {
+		return Domain{}, users.Domain{}, errors.New("user tidak ditemukan")
+	}
+
+	if now.Sub(apiKey.LastUsedAt.Time()) >= lastUsedInterval {
+		apiKey.LastUsedAt = primitive.NewDateTimeFromTime(now)
+		// the request is served either way, a missed write only makes the last use look older
+		_ = aku.apiKeyRepository.UpdateLastUsedAt(apiKey.ID, apiKey.LastUsedAt)
+	}
+
+	return apiKey, user, nil
+}
+
+func (aku *APIKeyUseCase) GetByID(id primitive.ObjectID) (Domain, int, error) {
+	apiKey, err := aku.apiKeyRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("api key tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan api key")
+	}
+
+	return apiKey, http.StatusOK, nil
+}
+
+func (aku *APIKeyUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	apiKeys, totalData, err := aku.apiKeyRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan api key")
+	}
+
+	return apiKeys, totalData, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+func (aku *APIKeyUseCase) Revoke(id primitive.ObjectID) (Domain, int, error) {
+	apiKey, err := aku.apiKeyRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("api key tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan api key")
+	}
+
+	if apiKey.RevokedAt != 0 {
+		return Domain{}, http.StatusConflict, errors.New("api key sudah dicabut")
+	}
+
+	apiKey.RevokedAt = primitive.NewDateTimeFromTime(time.Now())
+	apiKey.UpdatedAt = apiKey.RevokedAt
+
+	apiKey, err = aku.apiKeyRepository.Update(&apiKey)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mencabut api key")
+	}
+
+	return apiKey, http.StatusOK, nil
+}
@@ -0,0 +1,47 @@
+package audit_events
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is one status transition of an entity, events are only ever appended.
+type Domain struct {
+	ID         primitive.ObjectID
+	EntityType string
+	EntityID   primitive.ObjectID
+	ActorID    primitive.ObjectID
+	ActorRole  string
+	OldStatus  string
+	NewStatus  string
+	Reason     string
+	CreatedAt  primitive.DateTime
+}
+
+type Query struct {
+	Skip       int64
+	Limit      int64
+	Sort       string
+	Order      int
+	EntityType string
+	EntityID   primitive.ObjectID
+	ActorID    primitive.ObjectID
+	ActorRole  string
+	NewStatus  string
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	CreateMany(ctx context.Context, domains []Domain) error
+	// Read
+	GetByQuery(query Query) ([]Domain, int, error)
+	GetByEntity(entityType string, entityID primitive.ObjectID) ([]Domain, error)
+}
+
+type UseCase interface {
+	// Read
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	GetByEntity(entityType string, entityID primitive.ObjectID) ([]Domain, int, error)
+}
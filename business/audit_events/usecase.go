@@ -0,0 +1,75 @@
+package audit_events
+
+import (
+	"crop_connect/constant"
+	"crop_connect/util"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditEventUseCase struct {
+	auditEventRepository Repository
+}
+
+func NewUseCase(aer Repository) UseCase {
+	return &AuditEventUseCase{
+		auditEventRepository: aer,
+	}
+}
+
+var entityTypes = []string{
+	constant.AuditEntityProposal,
+	constant.AuditEntityTransaction,
+	constant.AuditEntityBatch,
+	constant.AuditEntityTreatmentRecord,
+	constant.AuditEntityHarvest,
+	constant.AuditEntityDispute,
+}
+
+/*
+Util
+*/
+
+// NewEvent builds the event of one transition, the caller saves it together with the entity it describes.
+func NewEvent(entityType string, entityID primitive.ObjectID, actorID primitive.ObjectID, actorRole string, oldStatus string, newStatus string, reason string) Domain {
+	return Domain{
+		ID:         primitive.NewObjectID(),
+		EntityType: entityType,
+		EntityID:   entityID,
+		ActorID:    actorID,
+		ActorRole:  actorRole,
+		OldStatus:  oldStatus,
+		NewStatus:  newStatus,
+		Reason:     reason,
+		CreatedAt:  primitive.NewDateTimeFromTime(time.Now()),
+	}
+}
+
+/*
+Read
+*/
+
+func (aeu *AuditEventUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	auditEvents, totalData, err := aeu.auditEventRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan riwayat status")
+	}
+
+	return auditEvents, totalData, http.StatusOK, nil
+}
+
+func (aeu *AuditEventUseCase) GetByEntity(entityType string, entityID primitive.ObjectID) ([]Domain, int, error) {
+	if !util.CheckStringOnArray(entityTypes, entityType) {
+		return []Domain{}, http.StatusBadRequest, errors.New("tipe entitas tidak valid")
+	}
+
+	auditEvents, err := aeu.auditEventRepository.GetByEntity(entityType, entityID)
+	if err != nil {
+		return []Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan riwayat status")
+	}
+
+	return auditEvents, http.StatusOK, nil
+}
@@ -1,6 +1,10 @@
 package batchs
 
-import "go.mongodb.org/mongo-driver/bson/primitive"
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
 
 type Domain struct {
 	ID                   primitive.ObjectID
@@ -9,9 +13,13 @@ type Domain struct {
 	EstimatedHarvestDate primitive.DateTime
 	Status               string
 	CancelReason         string
+	RemainingQuantity    float64
+	IsQuantityTracked    bool
 	IsAvailable          bool
+	IsOverdue            bool
 	CreatedAt            primitive.DateTime
 	UpdatedAt            primitive.DateTime
+	Version              int
 }
 
 type Query struct {
@@ -27,7 +35,7 @@ type Query struct {
 
 type Repository interface {
 	// Create
-	Create(domain *Domain) (Domain, error)
+	Create(ctx context.Context, domain *Domain) (Domain, error)
 	// Read
 	GetByID(id primitive.ObjectID) (Domain, error)
 	CountByProposalCode(proposalCode primitive.ObjectID) (int, error)
@@ -39,8 +47,10 @@ type Repository interface {
 	GetForTransactionByCommodityCode(commodityCode primitive.ObjectID) ([]Domain, error)
 	GetForTransactionByID(id primitive.ObjectID) (Domain, error)
 	GetForHarvestByFarmerID(farmerID primitive.ObjectID) ([]Domain, error)
+	GetPlantingByProposalID(proposalID primitive.ObjectID) (Domain, error)
+	GetOverdue(now primitive.DateTime) ([]Domain, error)
 	// Update
-	Update(domain *Domain) (Domain, error)
+	Update(ctx context.Context, domain *Domain) (Domain, error)
 	// Delete
 }
 
@@ -56,6 +66,6 @@ type UseCase interface {
 	GetForTransactionByID(id primitive.ObjectID) (Domain, int, error)
 	GetForHarvestByFarmerID(farmerID primitive.ObjectID) ([]Domain, int, error)
 	// Update
-	// Cancel(domain *Domain, farmerID primitive.ObjectID) (int, error)
+	MarkOverdue() (int, int, error)
 	// Delete
 }
@@ -1,9 +1,14 @@
 package batchs
 
 import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
 	"crop_connect/business/commodities"
+	"crop_connect/business/notifications"
 	"crop_connect/business/proposals"
 	"crop_connect/constant"
+	"crop_connect/helper"
+	"crop_connect/util"
 	"errors"
 	"fmt"
 	"net/http"
@@ -14,19 +19,36 @@ import (
 )
 
 type BatchUseCase struct {
-	batchRepository     Repository
-	proposalRepository  proposals.Repository
-	commodityRepository commodities.Repository
+	batchRepository        Repository
+	proposalRepository     proposals.Repository
+	commodityRepository    commodities.Repository
+	notificationRepository notifications.Repository
+	auditEventRepository   auditEvents.Repository
 }
 
-func NewUseCase(br Repository, pr proposals.Repository, cr commodities.Repository) UseCase {
+func NewUseCase(br Repository, pr proposals.Repository, cr commodities.Repository, nr notifications.Repository, aer auditEvents.Repository) UseCase {
 	return &BatchUseCase{
-		batchRepository:     br,
-		proposalRepository:  pr,
-		commodityRepository: cr,
+		batchRepository:        br,
+		proposalRepository:     pr,
+		commodityRepository:    cr,
+		notificationRepository: nr,
+		auditEventRepository:   aer,
 	}
 }
 
+/*
+Util
+*/
+
+// transitions lists the statuses a batch may move to, a harvested or cancelled batch is final.
+var transitions = map[string][]string{
+	constant.BatchStatusPlanting: {constant.BatchStatusHarvest, constant.BatchStatusCancel},
+}
+
+func CanTransition(from string, to string) bool {
+	return util.CheckStringOnArray(transitions[from], to)
+}
+
 /*
 Create
 */
@@ -63,8 +85,10 @@ func (bu *BatchUseCase) Create(proposalID primitive.ObjectID, farmerID primitive
 	proposal.IsAvailable = false
 	proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-	_, err = bu.proposalRepository.Update(&proposal)
-	if err != nil {
+	_, err = bu.proposalRepository.Update(context.Background(), &proposal)
+	if helper.IsConflictError(err) {
+		return http.StatusConflict, errors.New("proposal telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
 		return http.StatusInternalServerError, errors.New("gagal mengubah proposal")
 	}
 
@@ -80,14 +104,19 @@ func (bu *BatchUseCase) Create(proposalID primitive.ObjectID, farmerID primitive
 		EstimatedHarvestDate: primitive.NewDateTimeFromTime(time.Now().AddDate(0, 0, commodity.PlantingPeriod)),
 		Status:               constant.BatchStatusPlanting,
 		IsAvailable:          true,
+		RemainingQuantity:    proposal.EstimatedTotalHarvest,
+		IsQuantityTracked:    true,
 		CreatedAt:            primitive.NewDateTimeFromTime(time.Now()),
 	}
 
-	_, err = bu.batchRepository.Create(domain)
+	_, err = bu.batchRepository.Create(context.Background(), domain)
 	if err != nil {
 		return http.StatusInternalServerError, errors.New("gagal membuat batch")
 	}
 
+	auditEvent := auditEvents.NewEvent(constant.AuditEntityBatch, domain.ID, farmerID, constant.RoleFarmer, "", domain.Status, "")
+	_, _ = bu.auditEventRepository.Create(context.Background(), &auditEvent)
+
 	return http.StatusCreated, nil
 }
 
@@ -180,60 +209,50 @@ func (bc *BatchUseCase) GetForHarvestByFarmerID(farmerID primitive.ObjectID) ([]
 Update
 */
 
-// func (bu *BatchUseCase) Cancel(domain *Domain, farmerID primitive.ObjectID) (int, error) {
-// 	batch, err := bu.batchRepository.GetByID(domain.ID)
-// 	if err == mongo.ErrNoDocuments {
-// 		return http.StatusNotFound, errors.New("batch tidak ditemukan")
-// 	} else if err != nil {
-// 		return http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
-// 	}
-
-// 	transaction, err := bu.transactionRepository.GetByID(batch.TransactionID)
-// 	if err == mongo.ErrNoDocuments {
-// 		return http.StatusNotFound, errors.New("transaksi tidak ditemukan")
-// 	} else if err != nil {
-// 		return http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
-// 	}
-
-// 	transaction.Status = constant.TransactionStatusCancel
-// 	_, err = bu.transactionRepository.Update(&transaction)
-// 	if err != nil {
-// 		return http.StatusInternalServerError, errors.New("gagal membatalkan transaksi")
-// 	}
-
-// 	proposal, err := bu.proposalRepository.GetByID(transaction.ProposalID)
-// 	if err == mongo.ErrNoDocuments {
-// 		return http.StatusNotFound, errors.New("proposal tidak ditemukan")
-// 	} else if err != nil {
-// 		return http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
-// 	}
-
-// 	commodity, err := bu.commodityRepository.GetByID(proposal.CommodityID)
-// 	if err == mongo.ErrNoDocuments {
-// 		return http.StatusNotFound, errors.New("komoditas tidak ditemukan")
-// 	} else if err != nil {
-// 		return http.StatusInternalServerError, errors.New("gagal mendapatkan periode tanam")
-// 	}
-
-// 	if commodity.FarmerID != farmerID {
-// 		return http.StatusForbidden, errors.New("tidak memiliki akses")
-// 	}
-
-// 	if batch.Status == constant.BatchStatusCancel {
-// 		return http.StatusBadRequest, errors.New("batch sudah dibatalkan")
-// 	}
-
-// 	batch.Status = constant.BatchStatusCancel
-// 	batch.CancelReason = domain.CancelReason
-// 	batch.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
-
-// 	_, err = bu.batchRepository.Update(&batch)
-// 	if err != nil {
-// 		return http.StatusInternalServerError, errors.New("gagal membatalkan batch")
-// 	}
-
-// 	return http.StatusOK, nil
-// }
+// MarkOverdue flags every planting batch past its estimated harvest date and returns how many were flagged.
+func (bu *BatchUseCase) MarkOverdue() (int, int, error) {
+	batchs, err := bu.batchRepository.GetOverdue(primitive.NewDateTimeFromTime(time.Now()))
+	if err != nil {
+		return 0, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
+	}
+
+	totalOverdue := 0
+	for _, batch := range batchs {
+		batch.IsOverdue = true
+		batch.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+		_, err := bu.batchRepository.Update(context.Background(), &batch)
+		if helper.IsConflictError(err) {
+			continue
+		} else if err != nil {
+			return totalOverdue, http.StatusInternalServerError, errors.New("gagal memperbarui batch")
+		}
+
+		totalOverdue++
+
+		proposal, err := bu.proposalRepository.GetByIDWithoutDeleted(batch.ProposalID)
+		if err != nil {
+			continue
+		}
+
+		commodity, err := bu.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
+		if err != nil {
+			continue
+		}
+
+		_, _ = bu.notificationRepository.Create(context.Background(), &notifications.Domain{
+			ID:          primitive.NewObjectID(),
+			UserID:      commodity.FarmerID,
+			Type:        constant.NotificationTypeBatchOverdue,
+			Title:       "Batch melewati perkiraan panen",
+			Message:     fmt.Sprintf("Batch %s sudah melewati perkiraan tanggal panen, segera kirim hasil panen", batch.Name),
+			ReferenceID: batch.ID,
+			CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+		})
+	}
+
+	return totalOverdue, http.StatusOK, nil
+}
 
 /*
 Delete
@@ -1,6 +1,7 @@
 package commodities
 
 import (
+	"context"
 	"crop_connect/helper"
 	"mime/multipart"
 
@@ -19,6 +20,8 @@ type Domain struct {
 	PricePerKg     int
 	IsPerennials   bool
 	IsAvailable    bool
+	RatingAverage  float64
+	RatingCount    int
 	CreatedAt      primitive.DateTime
 	UpdatedAt      primitive.DateTime
 	DeletedAt      primitive.DateTime
@@ -58,6 +61,7 @@ type Repository interface {
 	GetPerennialsByFarmerID(farmerID primitive.ObjectID) ([]Domain, error)
 	// Update
 	Update(domain *Domain) (Domain, error)
+	AddRating(ctx context.Context, code primitive.ObjectID, score int) error
 	// Delete
 	Delete(id primitive.ObjectID) error
 }
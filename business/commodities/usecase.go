@@ -1,6 +1,8 @@
 package commodities
 
 import (
+	"context"
+	"crop_connect/business/jobs"
 	"crop_connect/business/users"
 	"crop_connect/constant"
 	"crop_connect/helper"
@@ -17,13 +19,15 @@ import (
 type CommodityUseCase struct {
 	commoditiesRepository Repository
 	userRepository        users.Repository
+	jobUseCase            jobs.UseCase
 	cloudinary            cloudinary.Function
 }
 
-func NewUseCase(cr Repository, ur users.Repository, cldry cloudinary.Function) UseCase {
+func NewUseCase(cr Repository, ur users.Repository, ju jobs.UseCase, cldry cloudinary.Function) UseCase {
 	return &CommodityUseCase{
 		commoditiesRepository: cr,
 		userRepository:        ur,
+		jobUseCase:            ju,
 		cloudinary:            cldry,
 	}
 }
@@ -54,9 +58,12 @@ func (cu *CommodityUseCase) Create(domain *Domain, images []*multipart.FileHeade
 
 		_, err = cu.commoditiesRepository.Create(domain)
 		if err != nil {
-			err = cu.cloudinary.DeleteManyByURL(constant.CloudinaryFolderCommodities, domain.ImageURLs)
-			if err != nil {
-				return http.StatusInternalServerError, err
+			if len(domain.ImageURLs) > 0 {
+				// the uploaded images are orphaned, a failed enqueue only leaves them in cloudinary
+				_ = cu.jobUseCase.Enqueue(context.Background(), constant.JobTypeDeleteImages, jobs.DeleteImagesPayload{
+					Folder: constant.CloudinaryFolderCommodities,
+					URLs:   domain.ImageURLs,
+				})
 			}
 
 			return http.StatusInternalServerError, errors.New("gagal membuat komoditas")
@@ -174,6 +181,8 @@ func (cu *CommodityUseCase) Update(domain *Domain, updateImage []*helper.UpdateI
 	domain.ID = primitive.NewObjectID()
 	domain.Code = commodity.Code
 	domain.IsPerennials = commodity.IsPerennials
+	domain.RatingAverage = commodity.RatingAverage
+	domain.RatingCount = commodity.RatingCount
 	domain.CreatedAt = commodity.CreatedAt
 	domain.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
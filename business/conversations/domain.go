@@ -0,0 +1,80 @@
+package conversations
+
+import (
+	"context"
+	"mime/multipart"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is a thread between a buyer and a farmer about one commodity, proposal or transaction.
+type Domain struct {
+	ID            primitive.ObjectID
+	BuyerID       primitive.ObjectID
+	FarmerID      primitive.ObjectID
+	SubjectType   string
+	SubjectID     primitive.ObjectID
+	LastMessage   string
+	LastMessageAt primitive.DateTime
+	CreatedAt     primitive.DateTime
+}
+
+// Message is stored apart from its conversation so long threads can be paged. ReadAt is set once the other participant reads it.
+type Message struct {
+	ID             primitive.ObjectID
+	ConversationID primitive.ObjectID
+	SenderID       primitive.ObjectID
+	Message        string
+	Images         []string
+	ReadAt         primitive.DateTime
+	CreatedAt      primitive.DateTime
+}
+
+type Query struct {
+	Skip        int64
+	Limit       int64
+	Sort        string
+	Order       int
+	BuyerID     primitive.ObjectID
+	FarmerID    primitive.ObjectID
+	SubjectType string
+	SubjectID   primitive.ObjectID
+}
+
+type MessageQuery struct {
+	Skip           int64
+	Limit          int64
+	Sort           string
+	Order          int
+	ConversationID primitive.ObjectID
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	CreateMessage(ctx context.Context, message *Message) (Message, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, error)
+	GetByBuyerIDAndSubject(buyerID primitive.ObjectID, subjectType string, subjectID primitive.ObjectID) (Domain, error)
+	GetByQuery(query Query) ([]Domain, int, error)
+	GetMessagesByQuery(query MessageQuery) ([]Message, int, error)
+	CountUnreadMessages(conversationID primitive.ObjectID, readerID primitive.ObjectID) (int, error)
+	// Update
+	Update(ctx context.Context, domain *Domain) (Domain, error)
+	MarkMessagesAsRead(conversationID primitive.ObjectID, readerID primitive.ObjectID, readAt primitive.DateTime) error
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	Start(domain *Domain, message *Message, images []*multipart.FileHeader) (Domain, int, error)
+	SendMessage(message *Message, images []*multipart.FileHeader) (Message, int, error)
+	// Read
+	GetByID(id primitive.ObjectID, userID primitive.ObjectID, role string) (Domain, int, error)
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	GetMessages(query MessageQuery, userID primitive.ObjectID, role string) ([]Message, int, int, error)
+	CountUnreadMessages(conversationID primitive.ObjectID, readerID primitive.ObjectID) (int, int, error)
+	// Update
+	MarkAsRead(id primitive.ObjectID, userID primitive.ObjectID) (int, error)
+	// Delete
+}
@@ -0,0 +1,328 @@
+package conversations
+
+import (
+	"context"
+	"crop_connect/business/commodities"
+	"crop_connect/business/jobs"
+	"crop_connect/business/notifications"
+	"crop_connect/business/proposals"
+	"crop_connect/business/transactions"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/constant"
+	"crop_connect/helper/cloudinary"
+	"crop_connect/util"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ConversationUseCase struct {
+	conversationRepository Repository
+	commodityRepository    commodities.Repository
+	proposalRepository     proposals.Repository
+	transactionRepository  transactions.Repository
+	notificationRepository notifications.Repository
+	jobUseCase             jobs.UseCase
+	cloudinary             cloudinary.Function
+	unitOfWork             unitOfWork.UnitOfWork
+}
+
+func NewUseCase(cr Repository, cmr commodities.Repository, pr proposals.Repository, tr transactions.Repository, nr notifications.Repository, ju jobs.UseCase, cldry cloudinary.Function, uow unitOfWork.UnitOfWork) UseCase {
+	return &ConversationUseCase{
+		conversationRepository: cr,
+		commodityRepository:    cmr,
+		proposalRepository:     pr,
+		transactionRepository:  tr,
+		notificationRepository: nr,
+		jobUseCase:             ju,
+		cloudinary:             cldry,
+		unitOfWork:             uow,
+	}
+}
+
+/*
+Util
+*/
+
+// farmerOfSubject returns the farmer the buyer is talking to, a transaction can only be discussed by its own buyer.
+func (cu *ConversationUseCase) farmerOfSubject(subjectType string, subjectID primitive.ObjectID, buyerID primitive.ObjectID) (primitive.ObjectID, int, error) {
+	commodityID := subjectID
+
+	switch subjectType {
+	case constant.ConversationSubjectTransaction:
+		transaction, err := cu.transactionRepository.GetByID(subjectID)
+		if err == mongo.ErrNoDocuments || (err == nil && transaction.BuyerID != buyerID) {
+			return primitive.NilObjectID, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+		} else if err != nil {
+			return primitive.NilObjectID, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+		}
+
+		subjectID = transaction.ProposalID
+		fallthrough
+	case constant.ConversationSubjectProposal:
+		proposal, err := cu.proposalRepository.GetByIDWithoutDeleted(subjectID)
+		if err == mongo.ErrNoDocuments {
+			return primitive.NilObjectID, http.StatusNotFound, errors.New("proposal tidak ditemukan")
+		} else if err != nil {
+			return primitive.NilObjectID, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+		}
+
+		commodityID = proposal.CommodityID
+	}
+
+	commodity, err := cu.commodityRepository.GetByIDWithoutDeleted(commodityID)
+	if err == mongo.ErrNoDocuments {
+		return primitive.NilObjectID, http.StatusNotFound, errors.New("komoditas tidak ditemukan")
+	} else if err != nil {
+		return primitive.NilObjectID, http.StatusInternalServerError, errors.New("gagal mendapatkan komoditas")
+	}
+
+	return commodity.FarmerID, http.StatusOK, nil
+}
+
+func isParticipant(conversation *Domain, userID primitive.ObjectID, role string) bool {
+	return role == constant.RoleAdmin || conversation.BuyerID == userID || conversation.FarmerID == userID
+}
+
+// send stores the message, moves the conversation to the top of the list and notifies the other participant.
+func (cu *ConversationUseCase) send(ctx context.Context, conversation *Domain, message *Message) error {
+	_, err := cu.conversationRepository.CreateMessage(ctx, message)
+	if err != nil {
+		return fmt.Errorf("gagal mengirim pesan: %w", err)
+	}
+
+	conversation.LastMessage = message.Message
+	conversation.LastMessageAt = message.CreatedAt
+
+	_, err = cu.conversationRepository.Update(ctx, conversation)
+	if err != nil {
+		return fmt.Errorf("gagal memperbarui percakapan: %w", err)
+	}
+
+	recipientID := conversation.FarmerID
+	if message.SenderID == conversation.FarmerID {
+		recipientID = conversation.BuyerID
+	}
+
+	_, err = cu.notificationRepository.Create(ctx, &notifications.Domain{
+		ID:          primitive.NewObjectID(),
+		UserID:      recipientID,
+		Type:        constant.NotificationTypeConversationMessage,
+		Title:       "Pesan baru",
+		Message:     message.Message,
+		ReferenceID: conversation.ID,
+		CreatedAt:   message.CreatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat notifikasi: %w", err)
+	}
+
+	return nil
+}
+
+func (cu *ConversationUseCase) uploadImages(images []*multipart.FileHeader) ([]string, error) {
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	imageURLs, err := cu.cloudinary.UploadManyWithGeneratedFilename(constant.CloudinaryFolderConversations, images)
+	if err != nil {
+		return nil, errors.New("gagal mengunggah gambar")
+	}
+
+	return imageURLs, nil
+}
+
+func (cu *ConversationUseCase) deleteImages(imageURLs []string) {
+	if len(imageURLs) == 0 {
+		return
+	}
+
+	_ = cu.jobUseCase.Enqueue(context.Background(), constant.JobTypeDeleteImages, jobs.DeleteImagesPayload{
+		Folder: constant.CloudinaryFolderConversations,
+		URLs:   imageURLs,
+	})
+}
+
+/*
+Create
+*/
+
+// Start opens a conversation with the first message, a buyer asking again about the same subject continues the existing one.
+func (cu *ConversationUseCase) Start(domain *Domain, message *Message, images []*multipart.FileHeader) (Domain, int, error) {
+	if !util.CheckStringOnArray([]string{constant.ConversationSubjectCommodity, constant.ConversationSubjectProposal, constant.ConversationSubjectTransaction}, domain.SubjectType) {
+		return Domain{}, http.StatusBadRequest, fmt.Errorf("subjek percakapan hanya tersedia %s, %s, dan %s", constant.ConversationSubjectCommodity, constant.ConversationSubjectProposal, constant.ConversationSubjectTransaction)
+	}
+
+	conversation, err := cu.conversationRepository.GetByBuyerIDAndSubject(domain.BuyerID, domain.SubjectType, domain.SubjectID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan percakapan")
+	}
+
+	isNew := err == mongo.ErrNoDocuments
+	if isNew {
+		farmerID, statusCode, err := cu.farmerOfSubject(domain.SubjectType, domain.SubjectID, domain.BuyerID)
+		if err != nil {
+			return Domain{}, statusCode, err
+		}
+
+		conversation = Domain{
+			ID:          primitive.NewObjectID(),
+			BuyerID:     domain.BuyerID,
+			FarmerID:    farmerID,
+			SubjectType: domain.SubjectType,
+			SubjectID:   domain.SubjectID,
+			CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+		}
+	}
+
+	imageURLs, err := cu.uploadImages(images)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	message.ID = primitive.NewObjectID()
+	message.ConversationID = conversation.ID
+	message.SenderID = domain.BuyerID
+	message.Images = imageURLs
+	message.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	err = cu.unitOfWork.Execute(func(ctx context.Context) error {
+		if isNew {
+			_, err := cu.conversationRepository.Create(ctx, &conversation)
+			if err != nil {
+				return fmt.Errorf("gagal membuat percakapan: %w", err)
+			}
+		}
+
+		return cu.send(ctx, &conversation, message)
+	})
+	if err != nil {
+		cu.deleteImages(imageURLs)
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	if isNew {
+		return conversation, http.StatusCreated, nil
+	}
+
+	return conversation, http.StatusOK, nil
+}
+
+func (cu *ConversationUseCase) SendMessage(message *Message, images []*multipart.FileHeader) (Message, int, error) {
+	conversation, err := cu.conversationRepository.GetByID(message.ConversationID)
+	if err == mongo.ErrNoDocuments {
+		return Message{}, http.StatusNotFound, errors.New("percakapan tidak ditemukan")
+	} else if err != nil {
+		return Message{}, http.StatusInternalServerError, errors.New("gagal mendapatkan percakapan")
+	}
+
+	// admins may read every conversation but only the buyer and the farmer take part in it
+	if conversation.BuyerID != message.SenderID && conversation.FarmerID != message.SenderID {
+		return Message{}, http.StatusNotFound, errors.New("percakapan tidak ditemukan")
+	}
+
+	imageURLs, err := cu.uploadImages(images)
+	if err != nil {
+		return Message{}, http.StatusInternalServerError, err
+	}
+
+	message.ID = primitive.NewObjectID()
+	message.Images = imageURLs
+	message.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	err = cu.unitOfWork.Execute(func(ctx context.Context) error {
+		return cu.send(ctx, &conversation, message)
+	})
+	if err != nil {
+		cu.deleteImages(imageURLs)
+		return Message{}, http.StatusInternalServerError, err
+	}
+
+	return *message, http.StatusCreated, nil
+}
+
+/*
+Read
+*/
+
+func (cu *ConversationUseCase) GetByID(id primitive.ObjectID, userID primitive.ObjectID, role string) (Domain, int, error) {
+	conversation, err := cu.conversationRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("percakapan tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan percakapan")
+	}
+
+	if !isParticipant(&conversation, userID, role) {
+		return Domain{}, http.StatusNotFound, errors.New("percakapan tidak ditemukan")
+	}
+
+	return conversation, http.StatusOK, nil
+}
+
+func (cu *ConversationUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	conversations, totalData, err := cu.conversationRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan percakapan")
+	}
+
+	return conversations, totalData, http.StatusOK, nil
+}
+
+func (cu *ConversationUseCase) GetMessages(query MessageQuery, userID primitive.ObjectID, role string) ([]Message, int, int, error) {
+	_, statusCode, err := cu.GetByID(query.ConversationID, userID, role)
+	if err != nil {
+		return []Message{}, 0, statusCode, err
+	}
+
+	messages, totalData, err := cu.conversationRepository.GetMessagesByQuery(query)
+	if err != nil {
+		return []Message{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan pesan")
+	}
+
+	return messages, totalData, http.StatusOK, nil
+}
+
+func (cu *ConversationUseCase) CountUnreadMessages(conversationID primitive.ObjectID, readerID primitive.ObjectID) (int, int, error) {
+	total, err := cu.conversationRepository.CountUnreadMessages(conversationID, readerID)
+	if err != nil {
+		return 0, http.StatusInternalServerError, errors.New("gagal menghitung pesan yang belum dibaca")
+	}
+
+	return total, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+// MarkAsRead sets the read receipt on every message the other participant sent so far.
+func (cu *ConversationUseCase) MarkAsRead(id primitive.ObjectID, userID primitive.ObjectID) (int, error) {
+	conversation, err := cu.conversationRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("percakapan tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan percakapan")
+	}
+
+	if conversation.BuyerID != userID && conversation.FarmerID != userID {
+		return http.StatusNotFound, errors.New("percakapan tidak ditemukan")
+	}
+
+	err = cu.conversationRepository.MarkMessagesAsRead(conversation.ID, userID, primitive.NewDateTimeFromTime(time.Now()))
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal menandai pesan sebagai telah dibaca")
+	}
+
+	return http.StatusOK, nil
+}
+
+/*
+Delete
+*/
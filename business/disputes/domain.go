@@ -0,0 +1,76 @@
+package disputes
+
+import (
+	"context"
+	"mime/multipart"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Message struct {
+	ID         primitive.ObjectID
+	SenderID   primitive.ObjectID
+	SenderRole string
+	Message    string
+	Images     []string
+	CreatedAt  primitive.DateTime
+}
+
+// Domain is a complaint of a buyer about a delivered harvest, mediated by the validator of the proposal or an admin.
+type Domain struct {
+	ID             primitive.ObjectID
+	TransactionID  primitive.ObjectID
+	ShipmentID     primitive.ObjectID
+	BuyerID        primitive.ObjectID
+	FarmerID       primitive.ObjectID
+	ValidatorID    primitive.ObjectID
+	Reason         string
+	Description    string
+	Evidence       []string
+	Messages       []Message
+	Status         string
+	Outcome        string
+	RefundAmount   float64
+	ResolutionNote string
+	ResolverID     primitive.ObjectID
+	Version        int
+	CreatedAt      primitive.DateTime
+	UpdatedAt      primitive.DateTime
+	ResolvedAt     primitive.DateTime
+}
+
+type Query struct {
+	Skip        int64
+	Limit       int64
+	Sort        string
+	Order       int
+	BuyerID     primitive.ObjectID
+	FarmerID    primitive.ObjectID
+	ValidatorID primitive.ObjectID
+	Status      string
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, error)
+	GetOpenByTransactionID(transactionID primitive.ObjectID) (Domain, error)
+	GetByQuery(query Query) ([]Domain, int, error)
+	// Update
+	AddMessage(ctx context.Context, id primitive.ObjectID, message *Message) error
+	Update(ctx context.Context, domain *Domain) (Domain, error)
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	Open(domain *Domain, buyerID primitive.ObjectID, images []*multipart.FileHeader) (Domain, int, error)
+	// Read
+	GetByID(id primitive.ObjectID, userID primitive.ObjectID, role string) (Domain, int, error)
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	// Update
+	AddMessage(id primitive.ObjectID, message *Message, images []*multipart.FileHeader) (Domain, int, error)
+	Resolve(domain *Domain, resolverID primitive.ObjectID, role string) (Domain, int, error)
+	// Delete
+}
@@ -0,0 +1,373 @@
+package disputes
+
+import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
+	"crop_connect/business/jobs"
+	"crop_connect/business/notifications"
+	"crop_connect/business/proposals"
+	"crop_connect/business/shipments"
+	"crop_connect/business/transactions"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/constant"
+	"crop_connect/helper"
+	"crop_connect/helper/cloudinary"
+	"crop_connect/util"
+	"errors"
+	"fmt"
+	"mime/multipart"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DisputeUseCase struct {
+	disputeRepository      Repository
+	transactionRepository  transactions.Repository
+	shipmentRepository     shipments.Repository
+	proposalRepository     proposals.Repository
+	notificationRepository notifications.Repository
+	auditEventRepository   auditEvents.Repository
+	jobUseCase             jobs.UseCase
+	cloudinary             cloudinary.Function
+	unitOfWork             unitOfWork.UnitOfWork
+}
+
+func NewUseCase(dr Repository, tr transactions.Repository, sr shipments.Repository, pr proposals.Repository, nr notifications.Repository, aer auditEvents.Repository, ju jobs.UseCase, cldry cloudinary.Function, uow unitOfWork.UnitOfWork) UseCase {
+	return &DisputeUseCase{
+		disputeRepository:      dr,
+		transactionRepository:  tr,
+		shipmentRepository:     sr,
+		proposalRepository:     pr,
+		notificationRepository: nr,
+		auditEventRepository:   aer,
+		jobUseCase:             ju,
+		cloudinary:             cldry,
+		unitOfWork:             uow,
+	}
+}
+
+/*
+Util
+*/
+
+// isParticipant allows the buyer and farmer of the dispute, its validator and every admin.
+func isParticipant(dispute *Domain, userID primitive.ObjectID, role string) bool {
+	return role == constant.RoleAdmin || dispute.BuyerID == userID || dispute.FarmerID == userID || dispute.ValidatorID == userID
+}
+
+func (du *DisputeUseCase) notify(ctx context.Context, userIDs []primitive.ObjectID, notificationType string, title string, message string, disputeID primitive.ObjectID) error {
+	for _, userID := range userIDs {
+		if userID == primitive.NilObjectID {
+			continue
+		}
+
+		_, err := du.notificationRepository.Create(ctx, &notifications.Domain{
+			ID:          primitive.NewObjectID(),
+			UserID:      userID,
+			Type:        notificationType,
+			Title:       title,
+			Message:     message,
+			ReferenceID: disputeID,
+			CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+		})
+		if err != nil {
+			return fmt.Errorf("gagal membuat notifikasi: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (du *DisputeUseCase) uploadImages(images []*multipart.FileHeader) ([]string, error) {
+	if len(images) == 0 {
+		return nil, nil
+	}
+
+	imageURLs, err := du.cloudinary.UploadManyWithGeneratedFilename(constant.CloudinaryFolderDisputes, images)
+	if err != nil {
+		return nil, errors.New("gagal mengunggah gambar")
+	}
+
+	return imageURLs, nil
+}
+
+// deleteImages removes uploaded images in the background when the dispute they belong to could not be saved.
+func (du *DisputeUseCase) deleteImages(imageURLs []string) {
+	if len(imageURLs) == 0 {
+		return
+	}
+
+	_ = du.jobUseCase.Enqueue(context.Background(), constant.JobTypeDeleteImages, jobs.DeleteImagesPayload{
+		Folder: constant.CloudinaryFolderDisputes,
+		URLs:   imageURLs,
+	})
+}
+
+/*
+Create
+*/
+
+func (du *DisputeUseCase) Open(domain *Domain, buyerID primitive.ObjectID, images []*multipart.FileHeader) (Domain, int, error) {
+	if !util.CheckStringOnArray([]string{constant.DisputeReasonCondition, constant.DisputeReasonWeight}, domain.Reason) {
+		return Domain{}, http.StatusBadRequest, fmt.Errorf("alasan sengketa hanya tersedia %s dan %s", constant.DisputeReasonCondition, constant.DisputeReasonWeight)
+	} else if len(images) == 0 {
+		return Domain{}, http.StatusBadRequest, errors.New("bukti gambar tidak boleh kosong")
+	}
+
+	transaction, err := du.transactionRepository.GetByID(domain.TransactionID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	if transaction.BuyerID != buyerID {
+		return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if !util.CheckStringOnArray([]string{constant.TransactionStatusPaid, constant.TransactionStatusPartiallyRefunded}, transaction.Status) {
+		return Domain{}, http.StatusBadRequest, errors.New("sengketa hanya dapat diajukan untuk transaksi yang sudah dibayar")
+	}
+
+	shipment, err := du.shipmentRepository.GetByTransactionID(transaction.ID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusBadRequest, errors.New("hasil panen belum dikirim")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman")
+	}
+
+	if shipment.Status != constant.ShipmentStatusDelivered {
+		return Domain{}, http.StatusBadRequest, errors.New("sengketa hanya dapat diajukan setelah hasil panen diterima")
+	}
+
+	_, err = du.disputeRepository.GetOpenByTransactionID(transaction.ID)
+	if err == nil {
+		return Domain{}, http.StatusConflict, errors.New("transaksi masih memiliki sengketa yang belum selesai")
+	} else if err != mongo.ErrNoDocuments {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan sengketa")
+	}
+
+	// the validator of the proposal mediates, a proposal without one is left to the admins
+	proposal, err := du.proposalRepository.GetByID(transaction.ProposalID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+	}
+
+	evidence, err := du.uploadImages(images)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	domain.ID = primitive.NewObjectID()
+	domain.ShipmentID = shipment.ID
+	domain.BuyerID = buyerID
+	domain.FarmerID = shipment.FarmerID
+	domain.ValidatorID = proposal.ValidatorID
+	domain.Evidence = evidence
+	domain.Messages = []Message{}
+	domain.Status = constant.DisputeStatusOpen
+	domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	auditEvent := auditEvents.NewEvent(constant.AuditEntityDispute, domain.ID, buyerID, constant.RoleBuyer, "", domain.Status, domain.Reason)
+
+	err = du.unitOfWork.Execute(func(ctx context.Context) error {
+		_, err := du.disputeRepository.Create(ctx, domain)
+		if err != nil {
+			return fmt.Errorf("gagal membuat sengketa: %w", err)
+		}
+
+		_, err = du.auditEventRepository.Create(ctx, &auditEvent)
+		if err != nil {
+			return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+		}
+
+		return du.notify(ctx, []primitive.ObjectID{domain.FarmerID, domain.ValidatorID}, constant.NotificationTypeDisputeOpened, "Sengketa baru", "Pembeli mengajukan sengketa atas hasil panen yang diterima", domain.ID)
+	})
+	if err != nil {
+		du.deleteImages(evidence)
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return *domain, http.StatusCreated, nil
+}
+
+/*
+Read
+*/
+
+func (du *DisputeUseCase) GetByID(id primitive.ObjectID, userID primitive.ObjectID, role string) (Domain, int, error) {
+	dispute, err := du.disputeRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("sengketa tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan sengketa")
+	}
+
+	if !isParticipant(&dispute, userID, role) {
+		return Domain{}, http.StatusNotFound, errors.New("sengketa tidak ditemukan")
+	}
+
+	return dispute, http.StatusOK, nil
+}
+
+func (du *DisputeUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	disputes, totalData, err := du.disputeRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan sengketa")
+	}
+
+	return disputes, totalData, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+func (du *DisputeUseCase) AddMessage(id primitive.ObjectID, message *Message, images []*multipart.FileHeader) (Domain, int, error) {
+	dispute, statusCode, err := du.GetByID(id, message.SenderID, message.SenderRole)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	if dispute.Status != constant.DisputeStatusOpen {
+		return Domain{}, http.StatusBadRequest, errors.New("sengketa sudah diselesaikan")
+	}
+
+	imageURLs, err := du.uploadImages(images)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	message.ID = primitive.NewObjectID()
+	message.Images = imageURLs
+	message.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	recipients := []primitive.ObjectID{}
+	for _, userID := range []primitive.ObjectID{dispute.BuyerID, dispute.FarmerID, dispute.ValidatorID} {
+		if userID != message.SenderID {
+			recipients = append(recipients, userID)
+		}
+	}
+
+	err = du.unitOfWork.Execute(func(ctx context.Context) error {
+		err := du.disputeRepository.AddMessage(ctx, dispute.ID, message)
+		if err != nil {
+			return fmt.Errorf("gagal mengirim pesan: %w", err)
+		}
+
+		return du.notify(ctx, recipients, constant.NotificationTypeDisputeMessage, "Pesan sengketa", "Ada pesan baru pada sengketa anda", dispute.ID)
+	})
+	if err != nil {
+		du.deleteImages(imageURLs)
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	dispute.Messages = append(dispute.Messages, *message)
+
+	return dispute, http.StatusOK, nil
+}
+
+// Resolve closes an open dispute, a refund outcome is paid back by a job and moves the transaction to refunded or partially refunded.
+func (du *DisputeUseCase) Resolve(domain *Domain, resolverID primitive.ObjectID, role string) (Domain, int, error) {
+	if !util.CheckStringOnArray([]string{constant.DisputeOutcomeRefund, constant.DisputeOutcomePartialRefund, constant.DisputeOutcomeDismissed}, domain.Outcome) {
+		return Domain{}, http.StatusBadRequest, fmt.Errorf("hasil sengketa hanya tersedia %s, %s, dan %s", constant.DisputeOutcomeRefund, constant.DisputeOutcomePartialRefund, constant.DisputeOutcomeDismissed)
+	}
+
+	dispute, err := du.disputeRepository.GetByID(domain.ID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("sengketa tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan sengketa")
+	}
+
+	if role == constant.RoleValidator && dispute.ValidatorID != resolverID {
+		return Domain{}, http.StatusForbidden, errors.New("sengketa ditugaskan kepada validator lain")
+	} else if dispute.Status != constant.DisputeStatusOpen {
+		return Domain{}, http.StatusBadRequest, errors.New("sengketa sudah diselesaikan")
+	}
+
+	transaction, err := du.transactionRepository.GetByID(dispute.TransactionID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	// a settled harvest changes what the buyer finally paid
+	paidPrice := transaction.TotalPrice
+	if transaction.FinalPrice > 0 {
+		paidPrice = transaction.FinalPrice
+	}
+
+	switch domain.Outcome {
+	case constant.DisputeOutcomeRefund:
+		domain.RefundAmount = paidPrice
+	case constant.DisputeOutcomePartialRefund:
+		if domain.RefundAmount <= 0 || domain.RefundAmount >= paidPrice {
+			return Domain{}, http.StatusBadRequest, errors.New("jumlah pengembalian sebagian harus lebih dari 0 dan kurang dari total harga transaksi")
+		}
+	default:
+		domain.RefundAmount = 0
+	}
+
+	if domain.Outcome != constant.DisputeOutcomeDismissed && !util.CheckStringOnArray([]string{constant.TransactionStatusPaid, constant.TransactionStatusPartiallyRefunded}, transaction.Status) {
+		return Domain{}, http.StatusBadRequest, errors.New("dana transaksi sudah tidak dapat dikembalikan")
+	}
+
+	auditEvent := auditEvents.NewEvent(constant.AuditEntityDispute, dispute.ID, resolverID, role, dispute.Status, constant.DisputeStatusResolved, domain.Outcome)
+
+	dispute.Status = constant.DisputeStatusResolved
+	dispute.Outcome = domain.Outcome
+	dispute.RefundAmount = domain.RefundAmount
+	dispute.ResolutionNote = domain.ResolutionNote
+	dispute.ResolverID = resolverID
+	dispute.ResolvedAt = primitive.NewDateTimeFromTime(time.Now())
+	dispute.UpdatedAt = dispute.ResolvedAt
+
+	err = du.unitOfWork.Execute(func(ctx context.Context) error {
+		dispute := dispute
+
+		_, err := du.disputeRepository.Update(ctx, &dispute)
+		if helper.IsConflictError(err) {
+			return err
+		} else if err != nil {
+			return fmt.Errorf("gagal menyelesaikan sengketa: %w", err)
+		}
+
+		if dispute.Outcome != constant.DisputeOutcomeDismissed {
+			// an amount of 0 refunds every payment of the transaction, top ups included
+			amount := dispute.RefundAmount
+			if dispute.Outcome == constant.DisputeOutcomeRefund {
+				amount = 0
+			}
+
+			err := du.jobUseCase.Enqueue(ctx, constant.JobTypeRefundPayment, jobs.RefundPaymentPayload{
+				TransactionID: transaction.ID.Hex(),
+				Amount:        amount,
+				Reason:        "sengketa: " + dispute.ResolutionNote,
+			})
+			if err != nil {
+				return fmt.Errorf("gagal menjadwalkan pengembalian dana: %w", err)
+			}
+		}
+
+		_, err = du.auditEventRepository.Create(ctx, &auditEvent)
+		if err != nil {
+			return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+		}
+
+		return du.notify(ctx, []primitive.ObjectID{dispute.BuyerID, dispute.FarmerID}, constant.NotificationTypeDisputeResolved, "Sengketa selesai", "Sengketa atas hasil panen telah diselesaikan", dispute.ID)
+	})
+	if helper.IsConflictError(err) {
+		return Domain{}, http.StatusConflict, errors.New("sengketa telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return dispute, http.StatusOK, nil
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,35 @@
+package email_verifications
+
+import "go.mongodb.org/mongo-driver/bson/primitive"
+
+type Domain struct {
+	ID        primitive.ObjectID
+	UserID    primitive.ObjectID
+	Email     string
+	Token     string
+	IsUsed    bool
+	CreatedAt primitive.DateTime
+	UpdatedAt primitive.DateTime
+	ExpiredAt primitive.DateTime
+}
+
+type Repository interface {
+	// Create
+	Create(domain *Domain) (Domain, error)
+	// Read
+	GetByToken(token string) (Domain, error)
+	// Update
+	Update(domain *Domain) (Domain, error)
+	// Delete
+	HardDelete(id primitive.ObjectID) error
+	HardDeleteExpired(now primitive.DateTime) (int, error)
+}
+
+type UseCase interface {
+	// Create
+	Generate(appDomain string, email string) (int, error)
+	// Update
+	Verify(token string) (int, error)
+	// Delete
+	PurgeExpired() (int, int, error)
+}
@@ -0,0 +1,136 @@
+package email_verifications
+
+import (
+	"context"
+	"crop_connect/business/jobs"
+	"crop_connect/business/users"
+	"crop_connect/constant"
+	"crop_connect/util"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EmailVerificationUseCase struct {
+	emailVerificationRepository Repository
+	userRepository              users.Repository
+	jobUseCase                  jobs.UseCase
+}
+
+func NewUseCase(evr Repository, ur users.Repository, ju jobs.UseCase) UseCase {
+	return &EmailVerificationUseCase{
+		emailVerificationRepository: evr,
+		userRepository:              ur,
+		jobUseCase:                  ju,
+	}
+}
+
+var errorResponse = errors.New("token tidak dapat digunakan")
+
+/*
+Create
+*/
+
+// Generate only sends a link to accounts that are still unverified, the response is the same either way so it cannot be used to look up emails.
+func (evu *EmailVerificationUseCase) Generate(appDomain string, email string) (int, error) {
+	user, err := evu.userRepository.GetByEmail(email)
+	if err != nil {
+		return http.StatusCreated, errors.New("email tidak terdaftar")
+	}
+
+	if user.Status != constant.UserStatusUnverified {
+		return http.StatusCreated, errors.New("email telah diverifikasi")
+	}
+
+	domain := Domain{
+		ID:        primitive.NewObjectID(),
+		UserID:    user.ID,
+		Email:     email,
+		Token:     util.GenerateUUID(),
+		IsUsed:    false,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+		ExpiredAt: primitive.NewDateTimeFromTime(time.Now().Add(24 * time.Hour)),
+	}
+	_, err = evu.emailVerificationRepository.Create(&domain)
+	if err != nil {
+		return http.StatusCreated, errors.New("gagal membuat token")
+	}
+
+	err = evu.jobUseCase.Enqueue(context.Background(), constant.JobTypeSendEmail, jobs.SendEmailPayload{
+		Subject:        "Verifikasi email Crop Connect",
+		Template:       constant.MailgunEmailVerificationTemplate,
+		RecipientEmail: domain.Email,
+		Variable: map[string]string{
+			"name":   user.Name,
+			"domain": appDomain,
+			"token":  domain.Token,
+		},
+	})
+	if err != nil {
+		if err := evu.emailVerificationRepository.HardDelete(domain.ID); err != nil {
+			return http.StatusCreated, errors.New("gagal menghapus token")
+		}
+
+		return http.StatusCreated, errors.New("gagal mengirim email")
+	}
+
+	return http.StatusCreated, nil
+}
+
+/*
+Update
+*/
+
+func (evu *EmailVerificationUseCase) Verify(token string) (int, error) {
+	emailVerification, err := evu.emailVerificationRepository.GetByToken(token)
+	if err != nil {
+		return http.StatusForbidden, errorResponse
+	}
+
+	if emailVerification.IsUsed {
+		return http.StatusForbidden, errorResponse
+	} else if emailVerification.ExpiredAt.Time().Before(time.Now()) {
+		return http.StatusForbidden, errorResponse
+	}
+
+	user, err := evu.userRepository.GetByID(emailVerification.UserID)
+	if err != nil || user.Email != emailVerification.Email {
+		return http.StatusForbidden, errorResponse
+	}
+
+	if user.Status != constant.UserStatusUnverified {
+		return http.StatusForbidden, errorResponse
+	}
+
+	emailVerification.IsUsed = true
+	emailVerification.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	_, err = evu.emailVerificationRepository.Update(&emailVerification)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal memperbarui token")
+	}
+
+	user.Status = constant.UserStatusActive
+	user.EmailVerifiedAt = primitive.NewDateTimeFromTime(time.Now())
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	_, err = evu.userRepository.Update(&user)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal memverifikasi email")
+	}
+
+	return http.StatusOK, nil
+}
+
+/*
+Delete
+*/
+
+func (evu *EmailVerificationUseCase) PurgeExpired() (int, int, error) {
+	totalDeleted, err := evu.emailVerificationRepository.HardDeleteExpired(primitive.NewDateTimeFromTime(time.Now()))
+	if err != nil {
+		return 0, http.StatusInternalServerError, errors.New("gagal menghapus token")
+	}
+
+	return totalDeleted, http.StatusOK, nil
+}
@@ -0,0 +1,12 @@
+package emails
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type UseCase interface {
+	// Create
+	SendToUser(ctx context.Context, userID primitive.ObjectID, template string, variable map[string]string) error
+}
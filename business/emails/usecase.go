@@ -0,0 +1,54 @@
+package emails
+
+import (
+	"context"
+	"crop_connect/business/jobs"
+	"crop_connect/business/users"
+	"crop_connect/constant"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type EmailUseCase struct {
+	userRepository users.Repository
+	jobUseCase     jobs.UseCase
+}
+
+func NewUseCase(ur users.Repository, ju jobs.UseCase) UseCase {
+	return &EmailUseCase{
+		userRepository: ur,
+		jobUseCase:     ju,
+	}
+}
+
+/*
+Create
+*/
+
+// SendToUser looks up the email of the user and queues the template with the subject from the template registry.
+// The name of the user is always available to the template as "name".
+func (eu *EmailUseCase) SendToUser(ctx context.Context, userID primitive.ObjectID, template string, variable map[string]string) error {
+	user, err := eu.userRepository.GetByID(userID)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan pengguna: %w", err)
+	}
+
+	mailVariable := map[string]string{
+		"name": user.Name,
+	}
+	for key, value := range variable {
+		mailVariable[key] = value
+	}
+
+	err = eu.jobUseCase.Enqueue(ctx, constant.JobTypeSendEmail, jobs.SendEmailPayload{
+		Template:       template,
+		RecipientEmail: user.Email,
+		Variable:       mailVariable,
+	})
+	if err != nil {
+		return fmt.Errorf("gagal mengirim email: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,39 @@
+package events
+
+import (
+	auditEvents "crop_connect/business/audit_events"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is a committed status transition pushed to the users it concerns, events are not stored so a user that is not connected only sees the change on the next fetch.
+type Domain struct {
+	ID         primitive.ObjectID
+	UserIDs    []primitive.ObjectID
+	EntityType string
+	EntityID   primitive.ObjectID
+	OldStatus  string
+	NewStatus  string
+	Reason     string
+	CreatedAt  primitive.DateTime
+}
+
+// Hub fans the events out to the streams of their users, Publish never blocks the use case.
+type Hub interface {
+	Publish(domain Domain)
+	Subscribe(userID primitive.ObjectID) (<-chan Domain, func())
+}
+
+// FromAuditEvent reuses the transition recorded in the audit log, so a stream event can be looked up in the history by its id.
+func FromAuditEvent(auditEvent *auditEvents.Domain, userIDs ...primitive.ObjectID) Domain {
+	return Domain{
+		ID:         auditEvent.ID,
+		UserIDs:    userIDs,
+		EntityType: auditEvent.EntityType,
+		EntityID:   auditEvent.EntityID,
+		OldStatus:  auditEvent.OldStatus,
+		NewStatus:  auditEvent.NewStatus,
+		Reason:     auditEvent.Reason,
+		CreatedAt:  auditEvent.CreatedAt,
+	}
+}
@@ -21,6 +21,7 @@ type Repository interface {
 	Update(domain *Domain) (Domain, error)
 	// Delete
 	HardDelete(id primitive.ObjectID) error
+	HardDeleteExpired(now primitive.DateTime) (int, error)
 }
 
 type UseCase interface {
@@ -31,4 +32,5 @@ type UseCase interface {
 	// Update
 	ResetPassword(token string, password string) (int, error)
 	// Delete
+	PurgeExpired() (int, int, error)
 }
@@ -1,9 +1,11 @@
 package forgot_password
 
 import (
+	"context"
+	"crop_connect/business/jobs"
+	"crop_connect/business/sessions"
 	"crop_connect/business/users"
 	"crop_connect/constant"
-	"crop_connect/helper/mailgun"
 	"crop_connect/util"
 	"errors"
 	"net/http"
@@ -16,14 +18,16 @@ import (
 type ForgotPasswordUseCase struct {
 	forgotPasswordRepository Repository
 	userRepository           users.Repository
-	mailgun                  mailgun.Function
+	jobUseCase               jobs.UseCase
+	sessionUseCase           sessions.UseCase
 }
 
-func NewUseCase(fpr Repository, ur users.Repository, mg mailgun.Function) UseCase {
+func NewUseCase(fpr Repository, ur users.Repository, ju jobs.UseCase, su sessions.UseCase) UseCase {
 	return &ForgotPasswordUseCase{
 		forgotPasswordRepository: fpr,
 		userRepository:           ur,
-		mailgun:                  mg,
+		jobUseCase:               ju,
+		sessionUseCase:           su,
 	}
 }
 
@@ -52,9 +56,14 @@ func (fpu *ForgotPasswordUseCase) Generate(appDomain string, email string) (int,
 		return http.StatusCreated, errors.New("gagal membuat token")
 	}
 
-	_, _, err = fpu.mailgun.SendOneMailUsingTemplate("Lupa password Crop Connect?", constant.MailgunForgotPasswordTemplate, domain.Email, "", map[string]string{
-		"domain": appDomain,
-		"token":  domain.Token,
+	err = fpu.jobUseCase.Enqueue(context.Background(), constant.JobTypeSendEmail, jobs.SendEmailPayload{
+		Subject:        "Lupa password Crop Connect?",
+		Template:       constant.MailgunForgotPasswordTemplate,
+		RecipientEmail: domain.Email,
+		Variable: map[string]string{
+			"domain": appDomain,
+			"token":  domain.Token,
+		},
 	})
 	if err != nil {
 		if err := fpu.forgotPasswordRepository.HardDelete(domain.ID); err != nil {
@@ -120,9 +129,23 @@ func (fpu *ForgotPasswordUseCase) ResetPassword(token string, password string) (
 		return http.StatusForbidden, errorResponse
 	}
 
+	statusCode, err := fpu.sessionUseCase.RevokeAllByUserID(user.ID)
+	if err != nil {
+		return statusCode, err
+	}
+
 	return http.StatusOK, nil
 }
 
 /*
 Delete
 */
+
+func (fpu *ForgotPasswordUseCase) PurgeExpired() (int, int, error) {
+	totalDeleted, err := fpu.forgotPasswordRepository.HardDeleteExpired(primitive.NewDateTimeFromTime(time.Now()))
+	if err != nil {
+		return 0, http.StatusInternalServerError, errors.New("gagal menghapus token")
+	}
+
+	return totalDeleted, http.StatusOK, nil
+}
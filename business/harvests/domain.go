@@ -1,6 +1,7 @@
 package harvests
 
 import (
+	"context"
 	"crop_connect/dto"
 	"crop_connect/helper"
 	"mime/multipart"
@@ -44,7 +45,7 @@ type Repository interface {
 	GetByQuery(query Query) ([]Domain, int, error)
 	CountByYear(year int) (float64, error)
 	// Update
-	Update(domain *Domain) (Domain, error)
+	Update(ctx context.Context, domain *Domain) (Domain, error)
 	// Delete
 }
 
@@ -1,19 +1,33 @@
 package harvests
 
 import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
 	"crop_connect/business/batchs"
 	"crop_connect/business/commodities"
+	"crop_connect/business/emails"
+	"crop_connect/business/events"
+	"crop_connect/business/jobs"
+	"crop_connect/business/notifications"
+	"crop_connect/business/policies"
 	"crop_connect/business/proposals"
+	"crop_connect/business/shipments"
 	"crop_connect/business/transactions"
 	treatmentRecords "crop_connect/business/treatment_records"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/business/users"
+	"crop_connect/business/webhooks"
 	"crop_connect/constant"
 	"crop_connect/dto"
 	"crop_connect/helper"
 	"crop_connect/helper/cloudinary"
 	"crop_connect/util"
 	"errors"
+	"fmt"
+	"math"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -27,10 +41,20 @@ type HarvestUseCase struct {
 	transactionRepository     transactions.Repository
 	proposalRepository        proposals.Repository
 	commodityRepository       commodities.Repository
+	shipmentRepository        shipments.Repository
+	userRepository            users.Repository
+	notificationRepository    notifications.Repository
+	auditEventRepository      auditEvents.Repository
+	eventHub                  events.Hub
+	emailUseCase              emails.UseCase
+	webhookUseCase            webhooks.UseCase
+	jobUseCase                jobs.UseCase
+	policyUseCase             policies.UseCase
 	cloudinary                cloudinary.Function
+	unitOfWork                unitOfWork.UnitOfWork
 }
 
-func NewUseCase(hr Repository, br batchs.Repository, trr treatmentRecords.Repository, tr transactions.Repository, pr proposals.Repository, cr commodities.Repository, cldry cloudinary.Function) UseCase {
+func NewUseCase(hr Repository, br batchs.Repository, trr treatmentRecords.Repository, tr transactions.Repository, pr proposals.Repository, cr commodities.Repository, sr shipments.Repository, ur users.Repository, nr notifications.Repository, aer auditEvents.Repository, eh events.Hub, eu emails.UseCase, wu webhooks.UseCase, ju jobs.UseCase, pu policies.UseCase, cldry cloudinary.Function, uow unitOfWork.UnitOfWork) UseCase {
 	return &HarvestUseCase{
 		harvestRepository:         hr,
 		treatmentRecordRepository: trr,
@@ -38,30 +62,99 @@ func NewUseCase(hr Repository, br batchs.Repository, trr treatmentRecords.Reposi
 		transactionRepository:     tr,
 		proposalRepository:        pr,
 		commodityRepository:       cr,
+		shipmentRepository:        sr,
+		userRepository:            ur,
+		notificationRepository:    nr,
+		auditEventRepository:      aer,
+		eventHub:                  eh,
+		emailUseCase:              eu,
+		webhookUseCase:            wu,
+		jobUseCase:                ju,
+		policyUseCase:             pu,
 		cloudinary:                cldry,
+		unitOfWork:                uow,
 	}
 }
 
-func (hu *HarvestUseCase) CheckFarmerIDByProposalID(proposalID primitive.ObjectID, farmerID primitive.ObjectID) (proposals.Domain, commodities.Domain, int, error) {
-	proposal, err := hu.proposalRepository.GetByIDWithoutDeleted(proposalID)
-	if err == mongo.ErrNoDocuments {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
-	} else if err != nil {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("proposal tidak ditemukan")
+// settleTransaction reconciles the price of an accepted transaction with the weight that was actually harvested.
+// The buyer receives the same share of the real harvest as they ordered from the estimate.
+func settleTransaction(transaction *transactions.Domain, estimatedTotalHarvest float64, totalHarvest float64) {
+	quantity := transaction.Quantity
+	if quantity == 0 {
+		quantity = estimatedTotalHarvest
 	}
 
-	commodity, err := hu.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
-	if err == mongo.ErrNoDocuments {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
-	} else if err != nil {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("komoditas tidak ditemukan")
+	actualQuantity := quantity
+	if estimatedTotalHarvest > 0 {
+		actualQuantity = quantity * totalHarvest / estimatedTotalHarvest
 	}
 
-	if commodity.FarmerID != farmerID {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusForbidden, errors.New("anda tidak memiliki akses")
+	pricePerKg := 0.0
+	if quantity > 0 {
+		pricePerKg = transaction.TotalPrice / quantity
 	}
 
-	return proposal, commodity, http.StatusOK, nil
+	transaction.Quantity = quantity
+	transaction.ActualQuantity = math.Round(actualQuantity*100) / 100
+	transaction.FinalPrice = math.Round(pricePerKg * actualQuantity)
+	transaction.PriceDifference = transaction.FinalPrice - transaction.TotalPrice
+	transaction.SettledAt = primitive.NewDateTimeFromTime(time.Now())
+	transaction.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+}
+
+// settlePayment gives back the overpaid part of a paid transaction, or asks its buyer to pay the shortfall as a top up.
+// A transaction that is not paid yet is simply charged its final price when the buyer pays.
+func (hu *HarvestUseCase) settlePayment(ctx context.Context, transaction *transactions.Domain, batch *batchs.Domain) error {
+	if transaction.Status != constant.TransactionStatusPaid || transaction.PriceDifference == 0 {
+		return nil
+	}
+
+	if transaction.PriceDifference < 0 {
+		return hu.jobUseCase.Enqueue(ctx, constant.JobTypeRefundPayment, jobs.RefundPaymentPayload{
+			TransactionID: transaction.ID.Hex(),
+			Amount:        -transaction.PriceDifference,
+			Reason:        "selisih harga hasil panen",
+		})
+	}
+
+	return hu.notificationRepository.CreateMany(ctx, []notifications.Domain{{
+		ID:          primitive.NewObjectID(),
+		UserID:      transaction.BuyerID,
+		Type:        constant.NotificationTypePriceTopUp,
+		Title:       "Kekurangan pembayaran hasil panen",
+		Message:     fmt.Sprintf("Hasil panen batch %s melebihi perkiraan, silakan bayar kekurangan sebesar Rp%.0f", batch.Name, transaction.PriceDifference),
+		ReferenceID: transaction.ID,
+		CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+	}})
+}
+
+// notifyValidators tells the validator assigned to the proposal, or every validator when there is none, that a harvest of the batch awaits review.
+func (hu *HarvestUseCase) notifyValidators(harvest *Domain, batch *batchs.Domain, proposal *proposals.Domain) {
+	var validators []users.Domain
+	if proposal.ValidatorID != primitive.NilObjectID {
+		validators = append(validators, users.Domain{ID: proposal.ValidatorID})
+	} else {
+		var err error
+		validators, err = hu.userRepository.GetByNameAndRole("", constant.RoleValidator)
+		if err != nil {
+			return
+		}
+	}
+
+	var notificationList []notifications.Domain
+	for _, validator := range validators {
+		notificationList = append(notificationList, notifications.Domain{
+			ID:          primitive.NewObjectID(),
+			UserID:      validator.ID,
+			Type:        constant.NotificationTypeHarvestReview,
+			Title:       "Hasil panen menunggu verifikasi",
+			Message:     fmt.Sprintf("Hasil panen batch %s menunggu untuk diverifikasi", batch.Name),
+			ReferenceID: harvest.ID,
+			CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+		})
+	}
+
+	_ = hu.notificationRepository.CreateMany(context.Background(), notificationList)
 }
 
 /*
@@ -69,17 +162,14 @@ Create
 */
 
 func (hu *HarvestUseCase) SubmitHarvest(domain *Domain, farmerID primitive.ObjectID, images []*multipart.FileHeader, notes []string) (Domain, int, error) {
-	checkBatch, err := hu.batchRepository.GetByID(domain.BatchID)
-	if err == mongo.ErrNoDocuments {
-		return Domain{}, http.StatusNotFound, errors.New("batch tidak ditemukan")
-	} else if err != nil {
-		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
-	}
-
-	_, _, statusCode, err := hu.CheckFarmerIDByProposalID(checkBatch.ProposalID, farmerID)
+	checkBatch, checkProposal, _, statusCode, err := hu.policyUseCase.GetBatchOfFarmer(domain.BatchID, farmerID)
 	if err != nil {
 		return Domain{}, statusCode, err
 	}
+
+	if checkBatch.Status != constant.BatchStatusPlanting {
+		return Domain{}, http.StatusBadRequest, errors.New("batch tidak sedang dalam tahap tanam")
+	}
 	newestTreatmentRecord, err := hu.treatmentRecordRepository.GetNewestByBatchIDAndStatus(domain.BatchID, constant.TreatmentRecordStatusApproved)
 	if err == mongo.ErrNoDocuments {
 		return Domain{}, http.StatusNotFound, errors.New("batch belum memiliki riwayat perawatan")
@@ -123,6 +213,12 @@ func (hu *HarvestUseCase) SubmitHarvest(domain *Domain, farmerID primitive.Objec
 			return Domain{}, http.StatusInternalServerError, errors.New("gagal mengajukan hasi panen")
 		}
 
+		auditEvent := auditEvents.NewEvent(constant.AuditEntityHarvest, domain.ID, farmerID, constant.RoleFarmer, "", domain.Status, "")
+		_, _ = hu.auditEventRepository.Create(context.Background(), &auditEvent)
+		hu.eventHub.Publish(events.FromAuditEvent(&auditEvent, farmerID, checkProposal.ValidatorID))
+
+		hu.notifyValidators(domain, &checkBatch, &checkProposal)
+
 		return *domain, http.StatusCreated, nil
 	} else if err != nil {
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan hasil panen")
@@ -202,36 +298,80 @@ func (hu *HarvestUseCase) Validate(domain *Domain, validatorID primitive.ObjectI
 		return Domain{}, http.StatusBadRequest, errors.New("hasil panen tidak sedang dalam proses verifikasi")
 	}
 
+	_, proposalOfValidator, statusCode, err := hu.policyUseCase.GetBatchOfValidator(harvest.BatchID, validatorID)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	var (
+		batch           batchs.Domain
+		proposal        proposals.Domain
+		transactionList []transactions.Domain
+		shipmentList    []shipments.Domain
+		auditEventList  []auditEvents.Domain
+	)
+
 	if domain.Status == constant.HarvestStatusApproved {
 		harvest.AccepterID = validatorID
 		harvest.RevisionNote = ""
 
-		batch, err := hu.batchRepository.GetByID(harvest.BatchID)
+		batch, err = hu.batchRepository.GetByID(harvest.BatchID)
 		if err == mongo.ErrNoDocuments {
 			return Domain{}, http.StatusNotFound, errors.New("batch tidak ditemukan")
 		} else if err != nil {
 			return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
 		}
 
+		if !batchs.CanTransition(batch.Status, constant.BatchStatusHarvest) {
+			return Domain{}, http.StatusBadRequest, errors.New("batch tidak sedang dalam tahap tanam")
+		}
+
+		auditEventList = append(auditEventList, auditEvents.NewEvent(constant.AuditEntityBatch, batch.ID, validatorID, constant.RoleValidator, batch.Status, constant.BatchStatusHarvest, ""))
+
 		batch.Status = constant.BatchStatusHarvest
 		batch.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-		_, err = hu.batchRepository.Update(&batch)
-		if err != nil {
-			return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui batch")
-		}
-
-		proposal, err := hu.proposalRepository.GetByID(batch.ProposalID)
+		proposal, err = hu.proposalRepository.GetByID(batch.ProposalID)
 		if err == nil {
 			proposal.IsAvailable = true
+			proposal.RemainingQuantity = proposal.EstimatedTotalHarvest
+			proposal.IsQuantityTracked = true
 			proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+		} else if err != mongo.ErrNoDocuments {
+			return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+		}
+
+		// the produce of a batch is shipped to every buyer that bought part of it
+		transactionList, err = hu.transactionRepository.GetAcceptedByBatchID(batch.ID)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+		}
 
-			_, err = hu.proposalRepository.Update(&proposal)
+		if len(transactionList) > 0 {
+			proposalOfBatch, err := hu.proposalRepository.GetByIDWithoutDeleted(batch.ProposalID)
 			if err != nil {
-				return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui proposal")
+				return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+			}
+
+			commodity, err := hu.commodityRepository.GetByIDWithoutDeleted(proposalOfBatch.CommodityID)
+			if err != nil {
+				return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan komoditas")
+			}
+
+			for i, transaction := range transactionList {
+				settleTransaction(&transactionList[i], proposalOfBatch.EstimatedTotalHarvest, harvest.TotalHarvest)
+
+				shipmentList = append(shipmentList, shipments.Domain{
+					ID:            primitive.NewObjectID(),
+					TransactionID: transaction.ID,
+					BatchID:       batch.ID,
+					HarvestID:     harvest.ID,
+					FarmerID:      commodity.FarmerID,
+					BuyerID:       transaction.BuyerID,
+					Status:        constant.ShipmentStatusWaitingPickup,
+					CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
+				})
 			}
-		} else if err != nil && err != mongo.ErrNoDocuments {
-			return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
 		}
 	}
 
@@ -243,14 +383,113 @@ func (hu *HarvestUseCase) Validate(domain *Domain, validatorID primitive.ObjectI
 		harvest.RevisionNote = domain.RevisionNote
 	}
 
+	harvestEvent := auditEvents.NewEvent(constant.AuditEntityHarvest, harvest.ID, validatorID, constant.RoleValidator, harvest.Status, domain.Status, harvest.RevisionNote)
+	auditEventList = append(auditEventList, harvestEvent)
+
 	harvest.Status = domain.Status
 	harvest.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-	_, err = hu.harvestRepository.Update(&harvest)
-	if err != nil {
-		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui hasil panen")
+	// the farmer is only emailed when the approval commits, a farmer that cannot be found simply gets no email
+	farmerID := primitive.NilObjectID
+	if domain.Status == constant.HarvestStatusApproved {
+		proposalOfBatch, err := hu.proposalRepository.GetByIDWithoutDeleted(batch.ProposalID)
+		if err == nil {
+			commodity, err := hu.commodityRepository.GetByIDWithoutDeleted(proposalOfBatch.CommodityID)
+			if err == nil {
+				farmerID = commodity.FarmerID
+			}
+		}
 	}
 
+	err = hu.unitOfWork.Execute(func(ctx context.Context) error {
+		batch, proposal, harvest := batch, proposal, harvest
+		transactionList := append([]transactions.Domain{}, transactionList...)
+
+		if batch.ID != primitive.NilObjectID {
+			_, err := hu.batchRepository.Update(ctx, &batch)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal memperbarui batch: %w", err)
+			}
+		}
+
+		if proposal.ID != primitive.NilObjectID {
+			_, err := hu.proposalRepository.Update(ctx, &proposal)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal memperbarui proposal: %w", err)
+			}
+		}
+
+		for i := range transactionList {
+			_, err := hu.transactionRepository.Update(ctx, &transactionList[i])
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal memperbarui transaksi: %w", err)
+			}
+
+			err = hu.settlePayment(ctx, &transactionList[i], &batch)
+			if err != nil {
+				return fmt.Errorf("gagal menyelesaikan selisih harga: %w", err)
+			}
+		}
+
+		for i := range shipmentList {
+			_, err := hu.shipmentRepository.Create(ctx, &shipmentList[i])
+			if err != nil {
+				return fmt.Errorf("gagal membuat pengiriman: %w", err)
+			}
+		}
+
+		_, err := hu.harvestRepository.Update(ctx, &harvest)
+		if err != nil {
+			return fmt.Errorf("gagal memperbarui hasil panen: %w", err)
+		}
+
+		err = hu.auditEventRepository.CreateMany(ctx, auditEventList)
+		if err != nil {
+			return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+		}
+
+		if farmerID != primitive.NilObjectID {
+			err := hu.emailUseCase.SendToUser(ctx, farmerID, constant.MailgunHarvestApprovalTemplate, map[string]string{
+				"batch":        batch.Name,
+				"totalHarvest": strconv.FormatFloat(harvest.TotalHarvest, 'f', -1, 64),
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		if harvest.Status == constant.HarvestStatusApproved {
+			err := hu.webhookUseCase.Dispatch(ctx, constant.WebhookEventHarvestApproved, webhooks.HarvestPayload{
+				ID:           harvest.ID,
+				BatchID:      harvest.BatchID,
+				ProposalID:   proposalOfValidator.ID,
+				Status:       harvest.Status,
+				Date:         harvest.Date,
+				TotalHarvest: harvest.TotalHarvest,
+				Condition:    harvest.Condition,
+			})
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if helper.IsConflictError(err) {
+		return Domain{}, http.StatusConflict, errors.New("data panen telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	commodity, _ := hu.commodityRepository.GetByID(proposalOfValidator.CommodityID)
+	hu.eventHub.Publish(events.FromAuditEvent(&harvestEvent, commodity.FarmerID, validatorID))
+
 	return *domain, http.StatusOK, nil
 }
 
@@ -266,14 +505,7 @@ func (hu *HarvestUseCase) UpdateHarvest(domain *Domain, farmerID primitive.Objec
 		return Domain{}, http.StatusConflict, errors.New("panen sudah diterima")
 	}
 
-	batch, err := hu.batchRepository.GetByID(harvest.BatchID)
-	if err == mongo.ErrNoDocuments {
-		return Domain{}, http.StatusNotFound, errors.New("batch tidak ditemukan")
-	} else if err != nil {
-		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
-	}
-
-	_, _, statusCode, err := hu.CheckFarmerIDByProposalID(batch.ProposalID, farmerID)
+	batch, proposal, _, statusCode, err := hu.policyUseCase.GetBatchOfFarmer(harvest.BatchID, farmerID)
 	if err != nil {
 		return Domain{}, statusCode, err
 	}
@@ -302,14 +534,23 @@ func (hu *HarvestUseCase) UpdateHarvest(domain *Domain, farmerID primitive.Objec
 		return Domain{}, http.StatusBadRequest, errors.New("gambar dan catatan tidak boleh kosong")
 	}
 
+	oldStatus := harvest.Status
 	harvest.Status = constant.HarvestStatusPending
 	harvest.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-	harvest, err = hu.harvestRepository.Update(&harvest)
+	harvest, err = hu.harvestRepository.Update(context.Background(), &harvest)
 	if err != nil {
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui panen")
 	}
 
+	if oldStatus != harvest.Status {
+		auditEvent := auditEvents.NewEvent(constant.AuditEntityHarvest, harvest.ID, farmerID, constant.RoleFarmer, oldStatus, harvest.Status, "")
+		_, _ = hu.auditEventRepository.Create(context.Background(), &auditEvent)
+		hu.eventHub.Publish(events.FromAuditEvent(&auditEvent, farmerID, proposal.ValidatorID))
+	}
+
+	hu.notifyValidators(&harvest, &batch, &proposal)
+
 	return harvest, http.StatusOK, nil
 }
 
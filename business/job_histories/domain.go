@@ -0,0 +1,43 @@
+package job_histories
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Domain struct {
+	ID         primitive.ObjectID
+	Name       string
+	Status     string
+	Affected   int
+	Error      string
+	CreatedAt  primitive.DateTime
+	FinishedAt primitive.DateTime
+}
+
+type Query struct {
+	Skip   int64
+	Limit  int64
+	Sort   string
+	Order  int
+	Name   string
+	Status string
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	// Read
+	GetByQuery(query Query) ([]Domain, int, error)
+	// Update
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	// Read
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	// Update
+	// Delete
+}
@@ -0,0 +1,41 @@
+package job_histories
+
+import (
+	"errors"
+	"net/http"
+)
+
+type JobHistoryUseCase struct {
+	jobHistoryRepository Repository
+}
+
+func NewUseCase(jhr Repository) UseCase {
+	return &JobHistoryUseCase{
+		jobHistoryRepository: jhr,
+	}
+}
+
+/*
+Create
+*/
+
+/*
+Read
+*/
+
+func (jhu *JobHistoryUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	jobHistories, totalData, err := jhu.jobHistoryRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan riwayat job")
+	}
+
+	return jobHistories, totalData, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
@@ -0,0 +1,67 @@
+package jobs
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Domain struct {
+	ID          primitive.ObjectID
+	Type        string
+	Payload     string
+	Status      string
+	Attempts    int
+	MaxAttempts int
+	LastError   string
+	RunAt       primitive.DateTime
+	LockedUntil primitive.DateTime
+	CreatedAt   primitive.DateTime
+	UpdatedAt   primitive.DateTime
+	FinishedAt  primitive.DateTime
+}
+
+type SendEmailPayload struct {
+	Subject        string            `json:"subject"`
+	Template       string            `json:"template"`
+	RecipientEmail string            `json:"recipientEmail"`
+	Variable       map[string]string `json:"variable"`
+}
+
+type DeleteImagesPayload struct {
+	Folder string   `json:"folder"`
+	URLs   []string `json:"urls"`
+}
+
+// RefundPaymentPayload refunds the paid payment of the transaction, or only the payment of PaymentID when it is set.
+type RefundPaymentPayload struct {
+	TransactionID string  `json:"transactionID"`
+	PaymentID     string  `json:"paymentID,omitempty"`
+	Amount        float64 `json:"amount,omitempty"`
+	Reason        string  `json:"reason"`
+}
+
+type DeliverWebhookPayload struct {
+	DeliveryID string `json:"deliveryID"`
+}
+
+// Handler runs one job with the payload stored at enqueue time, a returned error schedules a retry.
+type Handler func(payload string) error
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	// Read
+	// ClaimNext marks the oldest due job as processing and returns it, a processing job whose lock has expired is claimed again.
+	ClaimNext(now primitive.DateTime, lockedUntil primitive.DateTime) (Domain, error)
+	// Update
+	Update(domain *Domain) (Domain, error)
+	// ExtendLock moves the lock of a job that is still processing, so a long running job is not claimed a second time.
+	ExtendLock(id primitive.ObjectID, lockedUntil primitive.DateTime) error
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	Enqueue(ctx context.Context, jobType string, payload interface{}) error
+}
@@ -0,0 +1,77 @@
+package jobs
+
+import (
+	"crop_connect/helper/cloudinary"
+	"crop_connect/helper/mailgun"
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func SendEmailHandler(mg mailgun.Function) Handler {
+	return func(payload string) error {
+		var email SendEmailPayload
+		if err := json.Unmarshal([]byte(payload), &email); err != nil {
+			return err
+		}
+
+		_, _, err := mg.SendOneMailUsingTemplate(email.Subject, email.Template, email.RecipientEmail, "", email.Variable)
+		return err
+	}
+}
+
+func DeleteImagesHandler(cldry cloudinary.Function) Handler {
+	return func(payload string) error {
+		var images DeleteImagesPayload
+		if err := json.Unmarshal([]byte(payload), &images); err != nil {
+			return err
+		}
+
+		return cldry.DeleteManyByURL(images.Folder, images.URLs)
+	}
+}
+
+// RefundPaymentHandler takes the refunds as functions since the payment use case depends on packages that enqueue jobs.
+func RefundPaymentHandler(refundTransaction func(transactionID primitive.ObjectID, amount float64, reason string) (int, error), refundPayment func(id primitive.ObjectID, reason string) (int, error)) Handler {
+	return func(payload string) error {
+		var refund RefundPaymentPayload
+		if err := json.Unmarshal([]byte(payload), &refund); err != nil {
+			return err
+		}
+
+		if refund.PaymentID != "" {
+			paymentID, err := primitive.ObjectIDFromHex(refund.PaymentID)
+			if err != nil {
+				return err
+			}
+
+			_, err = refundPayment(paymentID, refund.Reason)
+			return err
+		}
+
+		transactionID, err := primitive.ObjectIDFromHex(refund.TransactionID)
+		if err != nil {
+			return err
+		}
+
+		_, err = refundTransaction(transactionID, refund.Amount, refund.Reason)
+		return err
+	}
+}
+
+// DeliverWebhookHandler takes the delivery as a function for the same reason as RefundPaymentHandler.
+func DeliverWebhookHandler(deliver func(deliveryID primitive.ObjectID) error) Handler {
+	return func(payload string) error {
+		var deliverWebhook DeliverWebhookPayload
+		if err := json.Unmarshal([]byte(payload), &deliverWebhook); err != nil {
+			return err
+		}
+
+		deliveryID, err := primitive.ObjectIDFromHex(deliverWebhook.DeliveryID)
+		if err != nil {
+			return err
+		}
+
+		return deliver(deliveryID)
+	}
+}
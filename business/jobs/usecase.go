@@ -0,0 +1,49 @@
+package jobs
+
+import (
+	"context"
+	"crop_connect/constant"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type JobUseCase struct {
+	jobRepository Repository
+}
+
+func NewUseCase(jr Repository) UseCase {
+	return &JobUseCase{
+		jobRepository: jr,
+	}
+}
+
+/*
+Create
+*/
+
+// Enqueue stores the job with ctx, so a job enqueued inside a unit of work is only run when the unit of work commits.
+func (ju *JobUseCase) Enqueue(ctx context.Context, jobType string, payload interface{}) error {
+	encodedPayload, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("gagal membuat job: %w", err)
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	_, err = ju.jobRepository.Create(ctx, &Domain{
+		ID:          primitive.NewObjectID(),
+		Type:        jobType,
+		Payload:     string(encodedPayload),
+		Status:      constant.JobStatusPending,
+		MaxAttempts: constant.JobMaxAttempts,
+		RunAt:       now,
+		CreatedAt:   now,
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat job: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,52 @@
+package notifications
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Domain struct {
+	ID          primitive.ObjectID
+	UserID      primitive.ObjectID
+	Type        string
+	Title       string
+	Message     string
+	ReferenceID primitive.ObjectID
+	IsRead      bool
+	ReadAt      primitive.DateTime
+	CreatedAt   primitive.DateTime
+}
+
+type Query struct {
+	Skip   int64
+	Limit  int64
+	Sort   string
+	Order  int
+	UserID primitive.ObjectID
+	IsRead *bool
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	CreateMany(ctx context.Context, domains []Domain) error
+	// Read
+	GetByQuery(query Query) ([]Domain, int, error)
+	CountUnreadByUserID(userID primitive.ObjectID) (int, error)
+	// Update
+	MarkAsRead(id primitive.ObjectID, userID primitive.ObjectID) error
+	MarkAllAsRead(userID primitive.ObjectID) error
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	// Read
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	CountUnread(userID primitive.ObjectID) (int, int, error)
+	// Update
+	MarkAsRead(id primitive.ObjectID, userID primitive.ObjectID) (int, error)
+	MarkAllAsRead(userID primitive.ObjectID) (int, error)
+	// Delete
+}
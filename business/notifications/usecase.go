@@ -0,0 +1,73 @@
+package notifications
+
+import (
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type NotificationUseCase struct {
+	notificationRepository Repository
+}
+
+func NewUseCase(nr Repository) UseCase {
+	return &NotificationUseCase{
+		notificationRepository: nr,
+	}
+}
+
+/*
+Create
+*/
+
+/*
+Read
+*/
+
+func (nu *NotificationUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	notifications, totalData, err := nu.notificationRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan notifikasi")
+	}
+
+	return notifications, totalData, http.StatusOK, nil
+}
+
+func (nu *NotificationUseCase) CountUnread(userID primitive.ObjectID) (int, int, error) {
+	total, err := nu.notificationRepository.CountUnreadByUserID(userID)
+	if err != nil {
+		return 0, http.StatusInternalServerError, errors.New("gagal menghitung notifikasi yang belum dibaca")
+	}
+
+	return total, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+func (nu *NotificationUseCase) MarkAsRead(id primitive.ObjectID, userID primitive.ObjectID) (int, error) {
+	err := nu.notificationRepository.MarkAsRead(id, userID)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("notifikasi tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal menandai notifikasi")
+	}
+
+	return http.StatusOK, nil
+}
+
+func (nu *NotificationUseCase) MarkAllAsRead(userID primitive.ObjectID) (int, error) {
+	err := nu.notificationRepository.MarkAllAsRead(userID)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal menandai notifikasi")
+	}
+
+	return http.StatusOK, nil
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,72 @@
+package payments
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Domain struct {
+	ID             primitive.ObjectID
+	TransactionID  primitive.ObjectID
+	BuyerID        primitive.ObjectID
+	Gateway        string
+	ExternalID     string
+	PaymentURL     string
+	Amount         float64
+	RefundedAmount float64
+	IsTopUp        bool
+	Status         string
+	ExpiredAt      primitive.DateTime
+	PaidAt         primitive.DateTime
+	RefundedAt     primitive.DateTime
+	CreatedAt      primitive.DateTime
+	UpdatedAt      primitive.DateTime
+}
+
+type Invoice struct {
+	ExternalID string
+	PaymentURL string
+	ExpiredAt  primitive.DateTime
+}
+
+type Callback struct {
+	ExternalID string
+	Status     string
+}
+
+// Gateway is implemented by every payment provider, the use case only moves a payment to paid when the gateway confirms it through a callback.
+type Gateway interface {
+	Name() string
+	CreateInvoice(domain *Domain) (Invoice, error)
+	// Refund returns RefundedAmount of the payment, which is less than Amount for a partial refund.
+	// The payment id is the idempotency key, calling it again for the same payment must not return the money twice.
+	Refund(domain *Domain) error
+	ParseCallback(token string, body []byte) (Callback, error)
+}
+
+type Repository interface {
+	// Create
+	Create(domain *Domain) (Domain, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, error)
+	GetByExternalID(externalID string) (Domain, error)
+	GetByTransactionID(transactionID primitive.ObjectID) ([]Domain, error)
+	// Update
+	Update(ctx context.Context, domain *Domain) (Domain, error)
+	UpdateByStatus(ctx context.Context, domain *Domain, status string) (Domain, error)
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	Create(transactionID primitive.ObjectID, buyerID primitive.ObjectID) (Domain, int, error)
+	// Read
+	GetByTransactionID(transactionID primitive.ObjectID) ([]Domain, int, error)
+	// Update
+	HandleCallback(token string, body []byte) (int, error)
+	Refund(id primitive.ObjectID, adminID primitive.ObjectID) (Domain, int, error)
+	RefundTransaction(transactionID primitive.ObjectID, amount float64, reason string) (int, error)
+	RefundPayment(id primitive.ObjectID, reason string) (int, error)
+	// Delete
+}
@@ -0,0 +1,462 @@
+package payments
+
+import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
+	"crop_connect/business/commodities"
+	"crop_connect/business/events"
+	"crop_connect/business/jobs"
+	"crop_connect/business/proposals"
+	"crop_connect/business/transactions"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/constant"
+	"crop_connect/helper"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PaymentUseCase struct {
+	paymentRepository     Repository
+	transactionRepository transactions.Repository
+	proposalRepository    proposals.Repository
+	commodityRepository   commodities.Repository
+	auditEventRepository  auditEvents.Repository
+	eventHub              events.Hub
+	gateway               Gateway
+	jobUseCase            jobs.UseCase
+	unitOfWork            unitOfWork.UnitOfWork
+}
+
+func NewUseCase(pr Repository, tr transactions.Repository, ppr proposals.Repository, cr commodities.Repository, aer auditEvents.Repository, eh events.Hub, gateway Gateway, ju jobs.UseCase, uow unitOfWork.UnitOfWork) UseCase {
+	return &PaymentUseCase{
+		paymentRepository:     pr,
+		transactionRepository: tr,
+		proposalRepository:    ppr,
+		commodityRepository:   cr,
+		auditEventRepository:  aer,
+		eventHub:              eh,
+		gateway:               gateway,
+		jobUseCase:            ju,
+		unitOfWork:            uow,
+	}
+}
+
+var errorRefunding = errors.New("pembayaran sedang atau sudah dikembalikan")
+
+/*
+Util
+*/
+
+// publishTransition pushes a committed transition of the transaction to its buyer and, when the commodity can still be found, its farmer.
+func (pu *PaymentUseCase) publishTransition(auditEvent *auditEvents.Domain, transaction *transactions.Domain) {
+	farmerID := primitive.NilObjectID
+
+	proposal, err := pu.proposalRepository.GetByID(transaction.ProposalID)
+	if err == nil {
+		commodity, err := pu.commodityRepository.GetByID(proposal.CommodityID)
+		if err == nil {
+			farmerID = commodity.FarmerID
+		}
+	}
+
+	pu.eventHub.Publish(events.FromAuditEvent(auditEvent, transaction.BuyerID, farmerID))
+}
+
+// refund returns the money of a paid payment through its gateway, an amount below the paid amount leaves the payment and, when settlesTransaction, its transaction partially refunded.
+// The payment is claimed as refunding before the gateway is called, so a retried job or a second request cannot refund it twice.
+// A payment left refunding was refunded by the gateway but could not be saved afterwards, it has to be reconciled with the gateway.
+// The transaction is read again and saved once more when another request changed it in between.
+func (pu *PaymentUseCase) refund(payment Domain, amount float64, actorID primitive.ObjectID, actorRole string, reason string, settlesTransaction bool) (Domain, int, error) {
+	var transaction transactions.Domain
+	if settlesTransaction {
+		var err error
+		transaction, err = pu.transactionRepository.GetByID(payment.TransactionID)
+		if err == mongo.ErrNoDocuments {
+			return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+		} else if err != nil {
+			return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+		}
+	}
+
+	refundedStatus := constant.PaymentStatusRefunded
+	transactionStatus := constant.TransactionStatusRefunded
+	if amount <= 0 || amount >= payment.Amount {
+		amount = payment.Amount
+	} else {
+		refundedStatus = constant.PaymentStatusPartiallyRefunded
+		transactionStatus = constant.TransactionStatusPartiallyRefunded
+	}
+
+	payment.Status = constant.PaymentStatusRefunding
+	payment.RefundedAmount = amount
+	payment.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err := pu.paymentRepository.UpdateByStatus(context.Background(), &payment, constant.PaymentStatusPaid)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusConflict, errorRefunding
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui pembayaran")
+	}
+
+	err = pu.gateway.Refund(&payment)
+	if err != nil {
+		// the gateway refused the refund, the payment is paid again so a retry can ask once more with the same payment id
+		payment.Status = constant.PaymentStatusPaid
+		payment.RefundedAmount = 0
+		_, _ = pu.paymentRepository.UpdateByStatus(context.Background(), &payment, constant.PaymentStatusRefunding)
+
+		return Domain{}, http.StatusBadGateway, errors.New("gagal mengembalikan dana")
+	}
+
+	payment.Status = refundedStatus
+	payment.RefundedAt = primitive.NewDateTimeFromTime(time.Now())
+	payment.UpdatedAt = payment.RefundedAt
+
+	// the money is already returned, so the payment is saved on its own and never rolled back with the transaction
+	_, err = pu.paymentRepository.UpdateByStatus(context.Background(), &payment, constant.PaymentStatusRefunding)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui pembayaran")
+	}
+
+	if !settlesTransaction {
+		return payment, http.StatusOK, nil
+	}
+
+	var auditEvent auditEvents.Domain
+	for {
+		auditEvent = auditEvents.NewEvent(constant.AuditEntityTransaction, transaction.ID, actorID, actorRole, transaction.Status, transactionStatus, reason)
+
+		transaction.Status = transactionStatus
+		transaction.UpdatedAt = payment.RefundedAt
+
+		err = pu.unitOfWork.Execute(func(ctx context.Context) error {
+			transaction := transaction
+
+			_, err := pu.transactionRepository.Update(ctx, &transaction)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal memperbarui transaksi: %w", err)
+			}
+
+			_, err = pu.auditEventRepository.Create(ctx, &auditEvent)
+			if err != nil {
+				return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+			}
+
+			return nil
+		})
+		if !helper.IsConflictError(err) {
+			break
+		}
+
+		transaction, err = pu.transactionRepository.GetByID(payment.TransactionID)
+		if err != nil {
+			return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+		}
+	}
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	pu.publishTransition(&auditEvent, &transaction)
+
+	return payment, http.StatusOK, nil
+}
+
+/*
+Create
+*/
+
+func (pu *PaymentUseCase) Create(transactionID primitive.ObjectID, buyerID primitive.ObjectID) (Domain, int, error) {
+	transaction, err := pu.transactionRepository.GetByIDAndBuyerID(transactionID, buyerID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	payments, err := pu.paymentRepository.GetByTransactionID(transactionID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pembayaran")
+	}
+
+	// a settled transaction is charged its final price, a paid one whose harvest turned out larger is asked for the difference as a top up
+	amount := transaction.TotalPrice + transaction.PriceDifference
+	isTopUp := false
+	if transaction.Status == constant.TransactionStatusPaid && transaction.PriceDifference > 0 {
+		for _, payment := range payments {
+			if payment.IsTopUp && payment.Status != constant.PaymentStatusPending && payment.Status != constant.PaymentStatusExpired {
+				return Domain{}, http.StatusConflict, errors.New("transaksi sudah dibayar")
+			}
+		}
+
+		amount = transaction.PriceDifference
+		isTopUp = true
+	} else if transaction.Status == constant.TransactionStatusPaid {
+		return Domain{}, http.StatusConflict, errors.New("transaksi sudah dibayar")
+	} else if transaction.Status != constant.TransactionStatusAccepted {
+		return Domain{}, http.StatusBadRequest, errors.New("transaksi belum diterima oleh petani")
+	}
+
+	for _, payment := range payments {
+		if payment.Status != constant.PaymentStatusPending || payment.IsTopUp != isTopUp {
+			continue
+		}
+
+		if payment.ExpiredAt.Time().After(time.Now()) && payment.Amount == amount {
+			return payment, http.StatusOK, nil
+		}
+
+		payment.Status = constant.PaymentStatusExpired
+		payment.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+		_, err = pu.paymentRepository.UpdateByStatus(context.Background(), &payment, constant.PaymentStatusPending)
+		if err == mongo.ErrNoDocuments {
+			return Domain{}, http.StatusConflict, errors.New("pembayaran sedang diproses")
+		} else if err != nil {
+			return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui pembayaran")
+		}
+	}
+
+	domain := Domain{
+		ID:            primitive.NewObjectID(),
+		TransactionID: transaction.ID,
+		BuyerID:       transaction.BuyerID,
+		Gateway:       pu.gateway.Name(),
+		Amount:        amount,
+		IsTopUp:       isTopUp,
+		Status:        constant.PaymentStatusPending,
+		CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	invoice, err := pu.gateway.CreateInvoice(&domain)
+	if err != nil {
+		return Domain{}, http.StatusBadGateway, errors.New("gagal membuat tagihan pembayaran")
+	}
+
+	domain.ExternalID = invoice.ExternalID
+	domain.PaymentURL = invoice.PaymentURL
+	domain.ExpiredAt = invoice.ExpiredAt
+
+	payment, err := pu.paymentRepository.Create(&domain)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal membuat pembayaran")
+	}
+
+	return payment, http.StatusCreated, nil
+}
+
+/*
+Read
+*/
+
+func (pu *PaymentUseCase) GetByTransactionID(transactionID primitive.ObjectID) ([]Domain, int, error) {
+	payments, err := pu.paymentRepository.GetByTransactionID(transactionID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return nil, http.StatusInternalServerError, errors.New("gagal mendapatkan pembayaran")
+	}
+
+	return payments, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+func (pu *PaymentUseCase) HandleCallback(token string, body []byte) (int, error) {
+	callback, err := pu.gateway.ParseCallback(token, body)
+	if err != nil {
+		return http.StatusUnauthorized, errors.New("callback tidak valid")
+	}
+
+	payment, err := pu.paymentRepository.GetByExternalID(callback.ExternalID)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("pembayaran tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan pembayaran")
+	}
+
+	// the gateway may deliver the same callback more than once
+	if payment.Status != constant.PaymentStatusPending {
+		return http.StatusOK, nil
+	}
+
+	payment.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	switch callback.Status {
+	case constant.PaymentStatusExpired:
+		payment.Status = constant.PaymentStatusExpired
+		_, err = pu.paymentRepository.UpdateByStatus(context.Background(), &payment, constant.PaymentStatusPending)
+		if err != nil && err != mongo.ErrNoDocuments {
+			return http.StatusInternalServerError, errors.New("gagal memperbarui pembayaran")
+		}
+
+		return http.StatusOK, nil
+	case constant.PaymentStatusPaid:
+		transaction, err := pu.transactionRepository.GetByID(payment.TransactionID)
+		if err == mongo.ErrNoDocuments {
+			return http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+		} else if err != nil {
+			return http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+		}
+
+		payment.Status = constant.PaymentStatusPaid
+		payment.PaidAt = payment.UpdatedAt
+
+		// a top up only completes the price of a transaction that is still paid, it does not move the transaction
+		if payment.IsTopUp && transaction.Status == constant.TransactionStatusPaid {
+			_, err = pu.paymentRepository.UpdateByStatus(context.Background(), &payment, constant.PaymentStatusPending)
+			if err != nil && err != mongo.ErrNoDocuments {
+				return http.StatusInternalServerError, errors.New("gagal memperbarui pembayaran")
+			}
+
+			return http.StatusOK, nil
+		}
+
+		// the money is already captured when the transaction expired or was paid through another invoice meanwhile, it is kept as paid and given back
+		if payment.IsTopUp || transaction.Status != constant.TransactionStatusAccepted {
+			err = pu.unitOfWork.Execute(func(ctx context.Context) error {
+				payment := payment
+
+				_, err := pu.paymentRepository.UpdateByStatus(ctx, &payment, constant.PaymentStatusPending)
+				if err != nil {
+					return fmt.Errorf("gagal memperbarui pembayaran: %w", err)
+				}
+
+				return pu.jobUseCase.Enqueue(ctx, constant.JobTypeRefundPayment, jobs.RefundPaymentPayload{
+					TransactionID: transaction.ID.Hex(),
+					PaymentID:     payment.ID.Hex(),
+					Reason:        "pembayaran diterima setelah transaksi tidak dapat dibayar",
+				})
+			})
+			if errors.Is(err, mongo.ErrNoDocuments) {
+				return http.StatusOK, nil
+			} else if err != nil {
+				return http.StatusInternalServerError, err
+			}
+
+			return http.StatusOK, nil
+		}
+
+		auditEvent := auditEvents.NewEvent(constant.AuditEntityTransaction, transaction.ID, primitive.NilObjectID, constant.AuditActorSystem, transaction.Status, constant.TransactionStatusPaid, "pembayaran diterima dari "+payment.Gateway)
+
+		transaction.Status = constant.TransactionStatusPaid
+		transaction.UpdatedAt = payment.UpdatedAt
+
+		err = pu.unitOfWork.Execute(func(ctx context.Context) error {
+			payment, transaction := payment, transaction
+
+			_, err := pu.paymentRepository.UpdateByStatus(ctx, &payment, constant.PaymentStatusPending)
+			if err != nil {
+				return fmt.Errorf("gagal memperbarui pembayaran: %w", err)
+			}
+
+			_, err = pu.transactionRepository.Update(ctx, &transaction)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal memperbarui transaksi: %w", err)
+			}
+
+			_, err = pu.auditEventRepository.Create(ctx, &auditEvent)
+			if err != nil {
+				return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+			}
+
+			return nil
+		})
+		if errors.Is(err, mongo.ErrNoDocuments) {
+			return http.StatusOK, nil
+		} else if helper.IsConflictError(err) {
+			return http.StatusConflict, errors.New("transaksi telah diubah oleh pengguna lain, silakan coba lagi")
+		} else if err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		pu.publishTransition(&auditEvent, &transaction)
+
+		return http.StatusOK, nil
+	default:
+		return http.StatusBadRequest, errors.New("status pembayaran tidak valid")
+	}
+}
+
+func (pu *PaymentUseCase) Refund(id primitive.ObjectID, adminID primitive.ObjectID) (Domain, int, error) {
+	payment, err := pu.paymentRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("pembayaran tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pembayaran")
+	}
+
+	if payment.Status != constant.PaymentStatusPaid {
+		return Domain{}, http.StatusBadRequest, errors.New("hanya pembayaran yang sudah dibayar yang dapat dikembalikan")
+	}
+
+	return pu.refund(payment, payment.Amount, adminID, constant.RoleAdmin, "", !payment.IsTopUp)
+}
+
+// RefundTransaction refunds the paid payment of a transaction in full when amount is zero, a full refund also gives back a paid top up.
+// A transaction whose payment is already refunding or refunded is left as it is, so a retried job does nothing.
+func (pu *PaymentUseCase) RefundTransaction(transactionID primitive.ObjectID, amount float64, reason string) (int, error) {
+	paymentList, err := pu.paymentRepository.GetByTransactionID(transactionID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan pembayaran")
+	}
+
+	for _, payment := range paymentList {
+		if payment.Status == constant.PaymentStatusPaid && !payment.IsTopUp {
+			_, statusCode, err := pu.refund(payment, amount, primitive.NilObjectID, constant.AuditActorSystem, reason, true)
+			if err != nil && err != errorRefunding {
+				return statusCode, err
+			}
+
+			break
+		}
+	}
+
+	if amount > 0 {
+		return http.StatusOK, nil
+	}
+
+	for _, payment := range paymentList {
+		if payment.Status == constant.PaymentStatusPaid && payment.IsTopUp {
+			_, statusCode, err := pu.refund(payment, 0, primitive.NilObjectID, constant.AuditActorSystem, reason, false)
+			if err != nil && err != errorRefunding {
+				return statusCode, err
+			}
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// RefundPayment gives back a payment that arrived after its transaction could no longer be paid, the transaction keeps its status.
+func (pu *PaymentUseCase) RefundPayment(id primitive.ObjectID, reason string) (int, error) {
+	payment, err := pu.paymentRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("pembayaran tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan pembayaran")
+	}
+
+	if payment.Status != constant.PaymentStatusPaid {
+		return http.StatusOK, nil
+	}
+
+	_, statusCode, err := pu.refund(payment, payment.Amount, primitive.NilObjectID, constant.AuditActorSystem, reason, false)
+	if err == errorRefunding {
+		return http.StatusOK, nil
+	}
+
+	return statusCode, err
+}
+
+/*
+Delete
+*/
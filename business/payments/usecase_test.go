@@ -0,0 +1,291 @@
+package payments_test
+
+import (
+	"context"
+	"crop_connect/app/realtime"
+	"crop_connect/business/jobs"
+	"crop_connect/business/payments"
+	"crop_connect/business/transactions"
+	"crop_connect/constant"
+	"crop_connect/driver"
+	memoryDriver "crop_connect/driver/memory"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// gateway counts the refunds it accepted, the first failures it is given are returned before any refund goes through.
+type gateway struct {
+	refunds  int
+	failures int
+	callback payments.Callback
+}
+
+func (g *gateway) Name() string {
+	return constant.PaymentGatewayLocal
+}
+
+func (g *gateway) CreateInvoice(domain *payments.Domain) (payments.Invoice, error) {
+	return payments.Invoice{ExternalID: domain.ID.Hex(), ExpiredAt: primitive.NewDateTimeFromTime(time.Now().Add(time.Hour))}, nil
+}
+
+func (g *gateway) Refund(domain *payments.Domain) error {
+	if g.failures > 0 {
+		g.failures--
+		return errors.New("gateway unavailable")
+	}
+
+	g.refunds++
+	return nil
+}
+
+func (g *gateway) ParseCallback(token string, body []byte) (payments.Callback, error) {
+	return g.callback, nil
+}
+
+// racingTransactionRepository saves the transaction again right before the first updates, the same as another request committing first.
+type racingTransactionRepository struct {
+	transactions.Repository
+	races int
+}
+
+func (r *racingTransactionRepository) Update(ctx context.Context, domain *transactions.Domain) (transactions.Domain, error) {
+	if r.races > 0 {
+		r.races--
+
+		winner, err := r.Repository.GetByID(domain.ID)
+		if err != nil {
+			return transactions.Domain{}, err
+		}
+
+		if _, err := r.Repository.Update(ctx, &winner); err != nil {
+			return transactions.Domain{}, err
+		}
+	}
+
+	return r.Repository.Update(ctx, domain)
+}
+
+func newUseCase(db *memoryDriver.Database, paymentGateway payments.Gateway, transactionRepository transactions.Repository) payments.UseCase {
+	return payments.NewUseCase(
+		driver.NewPaymentMemoryRepository(db),
+		transactionRepository,
+		driver.NewProposalMemoryRepository(db),
+		driver.NewCommodityMemoryRepository(db),
+		driver.NewAuditEventMemoryRepository(db),
+		realtime.NewHub(),
+		paymentGateway,
+		jobs.NewUseCase(driver.NewJobMemoryRepository(db)),
+		driver.NewUnitOfWorkMemory(db),
+	)
+}
+
+// seed saves a transaction of 100000 and the payment of its whole price.
+func seed(db *memoryDriver.Database, transactionStatus string, paymentStatus string) (transactions.Domain, payments.Domain) {
+	transaction := transactions.Domain{
+		ID:              primitive.NewObjectID(),
+		TransactionType: constant.TransactionTypeAnnuals,
+		ProposalID:      primitive.NewObjectID(),
+		BuyerID:         primitive.NewObjectID(),
+		Status:          transactionStatus,
+		Quantity:        10,
+		PricePerKg:      10000,
+		TotalPrice:      100000,
+		CreatedAt:       primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	payment := payments.Domain{
+		ID:            primitive.NewObjectID(),
+		TransactionID: transaction.ID,
+		BuyerID:       transaction.BuyerID,
+		Gateway:       constant.PaymentGatewayLocal,
+		ExternalID:    primitive.NewObjectID().Hex(),
+		Amount:        transaction.TotalPrice,
+		Status:        paymentStatus,
+		ExpiredAt:     primitive.NewDateTimeFromTime(time.Now().Add(time.Hour)),
+		CreatedAt:     primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	db.Transactions = []transactions.Domain{transaction}
+	db.Payments = []payments.Domain{payment}
+
+	return transaction, payment
+}
+
+func TestRefundTransaction(t *testing.T) {
+	tests := []struct {
+		name                  string
+		paymentStatus         string
+		gatewayFailures       int
+		races                 int
+		amount                float64
+		runs                  int
+		wantRefunds           int
+		wantPaymentStatus     string
+		wantRefundedAmount    float64
+		wantTransactionStatus string
+	}{
+		{
+			name:                  "a retried job refunds the payment once",
+			paymentStatus:         constant.PaymentStatusPaid,
+			runs:                  3,
+			wantRefunds:           1,
+			wantPaymentStatus:     constant.PaymentStatusRefunded,
+			wantRefundedAmount:    100000,
+			wantTransactionStatus: constant.TransactionStatusRefunded,
+		},
+		{
+			name:                  "a partial refund is not repeated either",
+			paymentStatus:         constant.PaymentStatusPaid,
+			amount:                25000,
+			runs:                  2,
+			wantRefunds:           1,
+			wantPaymentStatus:     constant.PaymentStatusPartiallyRefunded,
+			wantRefundedAmount:    25000,
+			wantTransactionStatus: constant.TransactionStatusPartiallyRefunded,
+		},
+		{
+			name:                  "a payment another worker is refunding is left alone",
+			paymentStatus:         constant.PaymentStatusRefunding,
+			runs:                  1,
+			wantRefunds:           0,
+			wantPaymentStatus:     constant.PaymentStatusRefunding,
+			wantTransactionStatus: constant.TransactionStatusPaid,
+		},
+		{
+			name:                  "a refused refund is paid again so the retry can refund it",
+			paymentStatus:         constant.PaymentStatusPaid,
+			gatewayFailures:       1,
+			runs:                  2,
+			wantRefunds:           1,
+			wantPaymentStatus:     constant.PaymentStatusRefunded,
+			wantRefundedAmount:    100000,
+			wantTransactionStatus: constant.TransactionStatusRefunded,
+		},
+		{
+			name:                  "a transaction changed during the refund is read again instead of losing the refund",
+			paymentStatus:         constant.PaymentStatusPaid,
+			races:                 2,
+			runs:                  1,
+			wantRefunds:           1,
+			wantPaymentStatus:     constant.PaymentStatusRefunded,
+			wantRefundedAmount:    100000,
+			wantTransactionStatus: constant.TransactionStatusRefunded,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memoryDriver.Init()
+			transaction, _ := seed(db, constant.TransactionStatusPaid, tt.paymentStatus)
+
+			paymentGateway := &gateway{failures: tt.gatewayFailures}
+			transactionRepository := &racingTransactionRepository{Repository: driver.NewTransactionMemoryRepository(db), races: tt.races}
+			useCase := newUseCase(db, paymentGateway, transactionRepository)
+
+			for i := 0; i < tt.runs; i++ {
+				_, _ = useCase.RefundTransaction(transaction.ID, tt.amount, "sengketa")
+			}
+
+			if paymentGateway.refunds != tt.wantRefunds {
+				t.Errorf("refunds = %d, want %d", paymentGateway.refunds, tt.wantRefunds)
+			}
+
+			payment := db.Payments[0]
+			if payment.Status != tt.wantPaymentStatus || payment.RefundedAmount != tt.wantRefundedAmount {
+				t.Errorf("payment = %s %v, want %s %v", payment.Status, payment.RefundedAmount, tt.wantPaymentStatus, tt.wantRefundedAmount)
+			}
+
+			if status := db.Transactions[0].Status; status != tt.wantTransactionStatus {
+				t.Errorf("transaction status = %s, want %s", status, tt.wantTransactionStatus)
+			}
+		})
+	}
+}
+
+func TestHandleCallbackPaid(t *testing.T) {
+	tests := []struct {
+		name                  string
+		transactionStatus     string
+		wantTransactionStatus string
+		wantRefundJob         bool
+	}{
+		{
+			name:                  "an accepted transaction becomes paid",
+			transactionStatus:     constant.TransactionStatusAccepted,
+			wantTransactionStatus: constant.TransactionStatusPaid,
+		},
+		{
+			name:                  "a payment for an expired transaction is kept and refunded",
+			transactionStatus:     constant.TransactionStatusExpired,
+			wantTransactionStatus: constant.TransactionStatusExpired,
+			wantRefundJob:         true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memoryDriver.Init()
+			_, payment := seed(db, tt.transactionStatus, constant.PaymentStatusPending)
+
+			paymentGateway := &gateway{callback: payments.Callback{ExternalID: payment.ExternalID, Status: constant.PaymentStatusPaid}}
+			useCase := newUseCase(db, paymentGateway, driver.NewTransactionMemoryRepository(db))
+
+			// the gateway may deliver the callback more than once
+			for i := 0; i < 2; i++ {
+				statusCode, err := useCase.HandleCallback("token", nil)
+				if statusCode != http.StatusOK {
+					t.Fatalf("status code = %d, want %d (err: %v)", statusCode, http.StatusOK, err)
+				}
+			}
+
+			if status := db.Payments[0].Status; status != constant.PaymentStatusPaid {
+				t.Errorf("payment status = %s, want %s", status, constant.PaymentStatusPaid)
+			}
+
+			if status := db.Transactions[0].Status; status != tt.wantTransactionStatus {
+				t.Errorf("transaction status = %s, want %s", status, tt.wantTransactionStatus)
+			}
+
+			var refundJobs []jobs.RefundPaymentPayload
+			for _, job := range db.Jobs {
+				if job.Type != constant.JobTypeRefundPayment {
+					continue
+				}
+
+				var payload jobs.RefundPaymentPayload
+				if err := json.Unmarshal([]byte(job.Payload), &payload); err != nil {
+					t.Fatalf("refund job payload: %v", err)
+				}
+
+				refundJobs = append(refundJobs, payload)
+			}
+
+			if !tt.wantRefundJob {
+				if len(refundJobs) != 0 {
+					t.Errorf("refund jobs = %d, want 0", len(refundJobs))
+				}
+				return
+			}
+
+			if len(refundJobs) != 1 || refundJobs[0].PaymentID != payment.ID.Hex() {
+				t.Fatalf("refund jobs = %+v, want one for payment %s", refundJobs, payment.ID.Hex())
+			}
+
+			// the refund job runs twice, the late payment is still only refunded once
+			for i := 0; i < 2; i++ {
+				if statusCode, err := useCase.RefundPayment(payment.ID, refundJobs[0].Reason); err != nil {
+					t.Fatalf("RefundPayment() = %d, %v", statusCode, err)
+				}
+			}
+
+			if paymentGateway.refunds != 1 || db.Payments[0].Status != constant.PaymentStatusRefunded {
+				t.Errorf("refunds = %d payment status = %s, want 1 and %s", paymentGateway.refunds, db.Payments[0].Status, constant.PaymentStatusRefunded)
+			}
+		})
+	}
+}
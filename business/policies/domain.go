@@ -0,0 +1,39 @@
+package policies
+
+import (
+	"crop_connect/business/batchs"
+	"crop_connect/business/commodities"
+	"crop_connect/business/proposals"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is one row of the role to permission matrix.
+type Domain struct {
+	Role        string
+	Permissions []string
+}
+
+type Actor struct {
+	ID   primitive.ObjectID
+	Role string
+}
+
+// Resource is what the actor acts on, an empty Type means the permission alone decides.
+type Resource struct {
+	Type string
+	ID   primitive.ObjectID
+}
+
+type UseCase interface {
+	// Read
+	Can(role string, permission string) bool
+	CanScope(scopeList []string, permission string) bool
+	GetScopes() []string
+	Authorize(actor Actor, permission string, resource Resource) (int, error)
+	GetMatrix() []Domain
+	GetCommodityOfFarmer(commodityID primitive.ObjectID, farmerID primitive.ObjectID) (commodities.Domain, int, error)
+	GetProposalOfFarmer(proposalID primitive.ObjectID, farmerID primitive.ObjectID) (proposals.Domain, commodities.Domain, int, error)
+	GetBatchOfFarmer(batchID primitive.ObjectID, farmerID primitive.ObjectID) (batchs.Domain, proposals.Domain, commodities.Domain, int, error)
+	GetBatchOfValidator(batchID primitive.ObjectID, validatorID primitive.ObjectID) (batchs.Domain, proposals.Domain, int, error)
+}
@@ -0,0 +1,335 @@
+package policies
+
+import (
+	"crop_connect/business/batchs"
+	"crop_connect/business/commodities"
+	"crop_connect/business/proposals"
+	"crop_connect/constant"
+	"crop_connect/util"
+	"errors"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PolicyUseCase struct {
+	commodityRepository commodities.Repository
+	proposalRepository  proposals.Repository
+	batchRepository     batchs.Repository
+}
+
+func NewUseCase(cr commodities.Repository, pr proposals.Repository, br batchs.Repository) UseCase {
+	return &PolicyUseCase{
+		commodityRepository: cr,
+		proposalRepository:  pr,
+		batchRepository:     br,
+	}
+}
+
+var roles = []string{constant.RoleAdmin, constant.RoleValidator, constant.RoleFarmer, constant.RoleBuyer}
+
+var rolePermissions = map[string][]string{
+	constant.RoleAdmin: {
+		constant.PermissionUserCreateValidator,
+		constant.PermissionUserRead,
+		constant.PermissionUserSuspend,
+		constant.PermissionUserAssignArea,
+		constant.PermissionUserStatistic,
+		constant.PermissionValidatorStatistic,
+		constant.PermissionCommodityStatistic,
+		constant.PermissionProposalRead,
+		constant.PermissionProposalAssign,
+		constant.PermissionProposalStatistic,
+		constant.PermissionTransactionStatistic,
+		constant.PermissionTransactionStatisticProvince,
+		constant.PermissionPaymentRead,
+		constant.PermissionPaymentRefund,
+		constant.PermissionBatchStatistic,
+		constant.PermissionTreatmentRecordStatistic,
+		constant.PermissionHarvestStatistic,
+		constant.PermissionJobHistoryRead,
+		constant.PermissionPolicyRead,
+		constant.PermissionAuditEventRead,
+		constant.PermissionAuditEventHistory,
+		constant.PermissionDisputeRead,
+		constant.PermissionDisputeMessage,
+		constant.PermissionDisputeResolve,
+		constant.PermissionConversationRead,
+		constant.PermissionWebhookManage,
+		constant.PermissionAPIKeyManage,
+	},
+	constant.RoleValidator: {
+		constant.PermissionValidatorStatistic,
+		constant.PermissionCommodityStatistic,
+		constant.PermissionProposalRead,
+		constant.PermissionProposalList,
+		constant.PermissionProposalValidate,
+		constant.PermissionProposalQueue,
+		constant.PermissionBatchRead,
+		constant.PermissionBatchStatistic,
+		constant.PermissionTreatmentRecordRead,
+		constant.PermissionTreatmentRecordRequest,
+		constant.PermissionTreatmentRecordValidate,
+		constant.PermissionTreatmentRecordStatistic,
+		constant.PermissionTreatmentRecordCount,
+		constant.PermissionHarvestRead,
+		constant.PermissionHarvestValidate,
+		constant.PermissionAuditEventHistory,
+		constant.PermissionDisputeRead,
+		constant.PermissionDisputeMessage,
+		constant.PermissionDisputeResolve,
+	},
+	constant.RoleFarmer: {
+		constant.PermissionCommodityManage,
+		constant.PermissionProposalManage,
+		constant.PermissionProposalRead,
+		constant.PermissionProposalList,
+		constant.PermissionTransactionRead,
+		constant.PermissionTransactionDecide,
+		constant.PermissionTransactionNegotiate,
+		constant.PermissionTransactionStatistic,
+		constant.PermissionPaymentRead,
+		constant.PermissionBatchRead,
+		constant.PermissionBatchManage,
+		constant.PermissionTreatmentRecordRead,
+		constant.PermissionTreatmentRecordFill,
+		constant.PermissionHarvestRead,
+		constant.PermissionHarvestSubmit,
+		constant.PermissionShipmentRead,
+		constant.PermissionShipmentDispatch,
+		constant.PermissionDisputeRead,
+		constant.PermissionDisputeMessage,
+		constant.PermissionConversationRead,
+		constant.PermissionConversationMessage,
+	},
+	constant.RoleBuyer: {
+		constant.PermissionTransactionRead,
+		constant.PermissionTransactionCreate,
+		constant.PermissionTransactionCancel,
+		constant.PermissionTransactionNegotiate,
+		constant.PermissionPaymentCreate,
+		constant.PermissionPaymentRead,
+		constant.PermissionShipmentRead,
+		constant.PermissionShipmentConfirm,
+		constant.PermissionDisputeOpen,
+		constant.PermissionDisputeRead,
+		constant.PermissionDisputeMessage,
+		constant.PermissionRatingCreate,
+		constant.PermissionConversationStart,
+		constant.PermissionConversationRead,
+		constant.PermissionConversationMessage,
+	},
+}
+
+var scopes = []string{
+	constant.ScopeCommoditiesRead,
+	constant.ScopeCommoditiesWrite,
+	constant.ScopeProposalsRead,
+	constant.ScopeProposalsWrite,
+	constant.ScopeTransactionsRead,
+	constant.ScopeTransactionsWrite,
+	constant.ScopeBatchsRead,
+	constant.ScopeBatchsWrite,
+	constant.ScopeShipmentsRead,
+	constant.ScopeShipmentsWrite,
+}
+
+// scopePermissions lists what an api key may do with each scope, permissions that are in no scope such as managing users or webhooks are never available to api keys.
+var scopePermissions = map[string][]string{
+	constant.ScopeCommoditiesRead: {
+		constant.PermissionCommodityStatistic,
+	},
+	constant.ScopeCommoditiesWrite: {
+		constant.PermissionCommodityManage,
+	},
+	constant.ScopeProposalsRead: {
+		constant.PermissionProposalRead,
+		constant.PermissionProposalList,
+		constant.PermissionProposalQueue,
+		constant.PermissionProposalStatistic,
+	},
+	constant.ScopeProposalsWrite: {
+		constant.PermissionProposalManage,
+		constant.PermissionProposalValidate,
+	},
+	constant.ScopeTransactionsRead: {
+		constant.PermissionTransactionRead,
+		constant.PermissionTransactionStatistic,
+		constant.PermissionTransactionStatisticProvince,
+		constant.PermissionPaymentRead,
+	},
+	constant.ScopeTransactionsWrite: {
+		constant.PermissionTransactionCreate,
+		constant.PermissionTransactionCancel,
+		constant.PermissionTransactionDecide,
+		constant.PermissionTransactionNegotiate,
+		constant.PermissionPaymentCreate,
+	},
+	constant.ScopeBatchsRead: {
+		constant.PermissionBatchRead,
+		constant.PermissionBatchStatistic,
+		constant.PermissionTreatmentRecordRead,
+		constant.PermissionTreatmentRecordStatistic,
+		constant.PermissionTreatmentRecordCount,
+		constant.PermissionHarvestRead,
+		constant.PermissionHarvestStatistic,
+	},
+	constant.ScopeBatchsWrite: {
+		constant.PermissionBatchManage,
+		constant.PermissionTreatmentRecordRequest,
+		constant.PermissionTreatmentRecordFill,
+		constant.PermissionTreatmentRecordValidate,
+		constant.PermissionHarvestSubmit,
+		constant.PermissionHarvestValidate,
+	},
+	constant.ScopeShipmentsRead: {
+		constant.PermissionShipmentRead,
+	},
+	constant.ScopeShipmentsWrite: {
+		constant.PermissionShipmentDispatch,
+		constant.PermissionShipmentConfirm,
+	},
+}
+
+var errorForbidden = errors.New("anda tidak memiliki akses")
+
+/*
+Read
+*/
+
+func (pu *PolicyUseCase) Can(role string, permission string) bool {
+	return util.CheckStringOnArray(rolePermissions[role], permission)
+}
+
+// CanScope reports whether one of the scopes of an api key covers the permission, the role of the key owner is checked separately by Can.
+func (pu *PolicyUseCase) CanScope(scopeList []string, permission string) bool {
+	for _, scope := range scopeList {
+		if util.CheckStringOnArray(scopePermissions[scope], permission) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (pu *PolicyUseCase) GetScopes() []string {
+	return scopes
+}
+
+// Authorize checks the permission of the role, then for farmers that the resource belongs to them through commodity, proposal and batch.
+// Validators are limited to batches of proposals assigned to them, admins act on every resource their permission covers.
+func (pu *PolicyUseCase) Authorize(actor Actor, permission string, resource Resource) (int, error) {
+	if !pu.Can(actor.Role, permission) {
+		return http.StatusForbidden, errorForbidden
+	}
+
+	if resource.Type == "" || actor.Role == constant.RoleAdmin || actor.Role == constant.RoleBuyer {
+		return http.StatusOK, nil
+	}
+
+	if actor.Role == constant.RoleValidator {
+		if resource.Type != constant.ResourceBatch {
+			return http.StatusOK, nil
+		}
+
+		_, _, statusCode, err := pu.GetBatchOfValidator(resource.ID, actor.ID)
+		return statusCode, err
+	}
+
+	switch resource.Type {
+	case constant.ResourceCommodity:
+		_, statusCode, err := pu.GetCommodityOfFarmer(resource.ID, actor.ID)
+		return statusCode, err
+	case constant.ResourceProposal:
+		_, _, statusCode, err := pu.GetProposalOfFarmer(resource.ID, actor.ID)
+		return statusCode, err
+	case constant.ResourceBatch:
+		_, _, _, statusCode, err := pu.GetBatchOfFarmer(resource.ID, actor.ID)
+		return statusCode, err
+	}
+
+	return http.StatusForbidden, errorForbidden
+}
+
+func (pu *PolicyUseCase) GetMatrix() []Domain {
+	matrix := []Domain{}
+	for _, role := range roles {
+		matrix = append(matrix, Domain{
+			Role:        role,
+			Permissions: rolePermissions[role],
+		})
+	}
+
+	return matrix
+}
+
+func (pu *PolicyUseCase) GetCommodityOfFarmer(commodityID primitive.ObjectID, farmerID primitive.ObjectID) (commodities.Domain, int, error) {
+	commodity, err := pu.commodityRepository.GetByIDWithoutDeleted(commodityID)
+	if err == mongo.ErrNoDocuments {
+		return commodities.Domain{}, http.StatusNotFound, errors.New("komoditas tidak ditemukan")
+	} else if err != nil {
+		return commodities.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan komoditas")
+	}
+
+	if commodity.FarmerID != farmerID {
+		return commodities.Domain{}, http.StatusForbidden, errorForbidden
+	}
+
+	return commodity, http.StatusOK, nil
+}
+
+func (pu *PolicyUseCase) GetProposalOfFarmer(proposalID primitive.ObjectID, farmerID primitive.ObjectID) (proposals.Domain, commodities.Domain, int, error) {
+	proposal, err := pu.proposalRepository.GetByIDWithoutDeleted(proposalID)
+	if err == mongo.ErrNoDocuments {
+		return proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
+	} else if err != nil {
+		return proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+	}
+
+	commodity, statusCode, err := pu.GetCommodityOfFarmer(proposal.CommodityID, farmerID)
+	if err != nil {
+		return proposals.Domain{}, commodities.Domain{}, statusCode, err
+	}
+
+	return proposal, commodity, http.StatusOK, nil
+}
+
+func (pu *PolicyUseCase) GetBatchOfFarmer(batchID primitive.ObjectID, farmerID primitive.ObjectID) (batchs.Domain, proposals.Domain, commodities.Domain, int, error) {
+	batch, err := pu.batchRepository.GetByID(batchID)
+	if err == mongo.ErrNoDocuments {
+		return batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("batch tidak ditemukan")
+	} else if err != nil {
+		return batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
+	}
+
+	proposal, commodity, statusCode, err := pu.GetProposalOfFarmer(batch.ProposalID, farmerID)
+	if err != nil {
+		return batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, statusCode, err
+	}
+
+	return batch, proposal, commodity, http.StatusOK, nil
+}
+
+// GetBatchOfValidator allows the validator assigned to the proposal of the batch, a proposal without one is open to every validator.
+func (pu *PolicyUseCase) GetBatchOfValidator(batchID primitive.ObjectID, validatorID primitive.ObjectID) (batchs.Domain, proposals.Domain, int, error) {
+	batch, err := pu.batchRepository.GetByID(batchID)
+	if err == mongo.ErrNoDocuments {
+		return batchs.Domain{}, proposals.Domain{}, http.StatusNotFound, errors.New("batch tidak ditemukan")
+	} else if err != nil {
+		return batchs.Domain{}, proposals.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
+	}
+
+	proposal, err := pu.proposalRepository.GetByID(batch.ProposalID)
+	if err == mongo.ErrNoDocuments {
+		return batchs.Domain{}, proposals.Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
+	} else if err != nil {
+		return batchs.Domain{}, proposals.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+	}
+
+	if proposal.ValidatorID != primitive.NilObjectID && proposal.ValidatorID != validatorID {
+		return batchs.Domain{}, proposals.Domain{}, http.StatusForbidden, errorForbidden
+	}
+
+	return batch, proposal, http.StatusOK, nil
+}
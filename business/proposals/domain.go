@@ -1,6 +1,7 @@
 package proposals
 
 import (
+	"context"
 	"crop_connect/dto"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -19,10 +20,13 @@ type Domain struct {
 	EstimatedTotalHarvest float64
 	PlantingArea          float64
 	Address               string
+	RemainingQuantity     float64
+	IsQuantityTracked     bool
 	IsAvailable           bool
 	CreatedAt             primitive.DateTime
 	UpdatedAt             primitive.DateTime
 	DeletedAt             primitive.DateTime
+	Version               int
 }
 
 type Query struct {
@@ -33,6 +37,7 @@ type Query struct {
 	CommodityID primitive.ObjectID
 	Commodity   string
 	FarmerID    primitive.ObjectID
+	ValidatorID primitive.ObjectID
 	Name        string
 	Status      string
 }
@@ -49,11 +54,12 @@ type Repository interface {
 	GetByIDAccepted(id primitive.ObjectID) (Domain, error)
 	StatisticByYear(year int) ([]dto.StatisticByYear, error)
 	CountTotalProposalByFarmer(farmerID primitive.ObjectID) (int, error)
+	CountPendingByValidatorID(validatorID primitive.ObjectID) (int, error)
 	GetByQuery(query Query) ([]Domain, int, error)
 	GetForPerennials(commodityID primitive.ObjectID, farmerID primitive.ObjectID) ([]Domain, error)
 	// Update
-	Update(domain *Domain) (Domain, error)
-	UnsetRejectReason(id primitive.ObjectID) (Domain, error)
+	Update(ctx context.Context, domain *Domain) (Domain, error)
+	UnsetRejectReason(ctx context.Context, domain *Domain) (Domain, error)
 	// Delete
 	Delete(id primitive.ObjectID) error
 }
@@ -75,6 +81,7 @@ type UseCase interface {
 	Update(domain *Domain, farmerID primitive.ObjectID) (int, error)
 	UpdateCommodityID(OldCommodityID primitive.ObjectID, NewCommodityID primitive.ObjectID) (int, error)
 	ValidateProposal(domain *Domain, adminID primitive.ObjectID) (int, error)
+	Assign(id primitive.ObjectID, validatorID primitive.ObjectID) (int, error)
 	// Delete
 	Delete(id primitive.ObjectID, farmerID primitive.ObjectID) (int, error)
 	DeleteByCommodityID(commodityID primitive.ObjectID) (int, error)
@@ -1,12 +1,22 @@
 package proposals
 
 import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
 	"crop_connect/business/commodities"
+	"crop_connect/business/emails"
+	"crop_connect/business/events"
+	"crop_connect/business/notifications"
 	"crop_connect/business/regions"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/business/users"
+	"crop_connect/business/webhooks"
 	"crop_connect/constant"
 	"crop_connect/dto"
+	"crop_connect/helper"
 	"crop_connect/util"
 	"errors"
+	"fmt"
 	"net/http"
 	"time"
 
@@ -15,19 +25,96 @@ import (
 )
 
 type ProposalUseCase struct {
-	proposalRepository  Repository
-	commodityRepository commodities.Repository
-	regionRepository    regions.Repository
+	proposalRepository     Repository
+	commodityRepository    commodities.Repository
+	regionRepository       regions.Repository
+	userRepository         users.Repository
+	notificationRepository notifications.Repository
+	auditEventRepository   auditEvents.Repository
+	eventHub               events.Hub
+	emailUseCase           emails.UseCase
+	webhookUseCase         webhooks.UseCase
+	unitOfWork             unitOfWork.UnitOfWork
 }
 
-func NewUseCase(pr Repository, cr commodities.Repository, rr regions.Repository) UseCase {
+func NewUseCase(pr Repository, cr commodities.Repository, rr regions.Repository, ur users.Repository, nr notifications.Repository, aer auditEvents.Repository, eh events.Hub, eu emails.UseCase, wu webhooks.UseCase, uow unitOfWork.UnitOfWork) UseCase {
 	return &ProposalUseCase{
-		proposalRepository:  pr,
-		commodityRepository: cr,
-		regionRepository:    rr,
+		proposalRepository:     pr,
+		commodityRepository:    cr,
+		regionRepository:       rr,
+		userRepository:         ur,
+		notificationRepository: nr,
+		auditEventRepository:   aer,
+		eventHub:               eh,
+		emailUseCase:           eu,
+		webhookUseCase:         wu,
+		unitOfWork:             uow,
 	}
 }
 
+/*
+Util
+*/
+
+// assignValidator picks the active validator covering the region of the proposal with the fewest pending proposals.
+// A proposal outside every validator area stays unassigned, so any validator can pick it up.
+func (pu *ProposalUseCase) assignValidator(regionID primitive.ObjectID) primitive.ObjectID {
+	region, err := pu.regionRepository.GetByID(regionID)
+	if err != nil {
+		return primitive.NilObjectID
+	}
+
+	validators, err := pu.userRepository.GetActiveValidatorsByArea(region.Province, region.Regency)
+	if err != nil {
+		return primitive.NilObjectID
+	}
+
+	validatorID := primitive.NilObjectID
+	lightestWorkload := -1
+	for _, validator := range validators {
+		workload, err := pu.proposalRepository.CountPendingByValidatorID(validator.ID)
+		if err != nil {
+			continue
+		}
+
+		if lightestWorkload == -1 || workload < lightestWorkload {
+			validatorID = validator.ID
+			lightestWorkload = workload
+		}
+	}
+
+	return validatorID
+}
+
+// notifyValidators tells the assigned validator, or every validator when there is none, that a proposal awaits review.
+func (pu *ProposalUseCase) notifyValidators(proposal *Domain) {
+	var validators []users.Domain
+	if proposal.ValidatorID != primitive.NilObjectID {
+		validators = append(validators, users.Domain{ID: proposal.ValidatorID})
+	} else {
+		var err error
+		validators, err = pu.userRepository.GetByNameAndRole("", constant.RoleValidator)
+		if err != nil {
+			return
+		}
+	}
+
+	var notificationList []notifications.Domain
+	for _, validator := range validators {
+		notificationList = append(notificationList, notifications.Domain{
+			ID:          primitive.NewObjectID(),
+			UserID:      validator.ID,
+			Type:        constant.NotificationTypeProposalReview,
+			Title:       "Proposal menunggu verifikasi",
+			Message:     fmt.Sprintf("Proposal %s menunggu untuk diverifikasi", proposal.Name),
+			ReferenceID: proposal.ID,
+			CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+		})
+	}
+
+	_ = pu.notificationRepository.CreateMany(context.Background(), notificationList)
+}
+
 /*
 Create
 */
@@ -55,7 +142,10 @@ func (pu *ProposalUseCase) Create(domain *Domain, farmerID primitive.ObjectID) (
 
 		domain.ID = primitive.NewObjectID()
 		domain.Code = primitive.NewObjectID()
+		domain.ValidatorID = pu.assignValidator(domain.RegionID)
 		domain.Status = constant.ProposalStatusPending
+		domain.RemainingQuantity = domain.EstimatedTotalHarvest
+		domain.IsQuantityTracked = true
 		domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 
 		_, err = pu.proposalRepository.Create(domain)
@@ -63,6 +153,12 @@ func (pu *ProposalUseCase) Create(domain *Domain, farmerID primitive.ObjectID) (
 			return http.StatusInternalServerError, errors.New("gagal membuat proposal")
 		}
 
+		auditEvent := auditEvents.NewEvent(constant.AuditEntityProposal, domain.ID, farmerID, constant.RoleFarmer, "", domain.Status, "")
+		_, _ = pu.auditEventRepository.Create(context.Background(), &auditEvent)
+		pu.eventHub.Publish(events.FromAuditEvent(&auditEvent, farmerID, domain.ValidatorID))
+
+		pu.notifyValidators(domain)
+
 		return http.StatusCreated, nil
 	} else {
 		return http.StatusConflict, errors.New("nama proposal sudah digunakan")
@@ -227,7 +323,10 @@ func (pu *ProposalUseCase) Update(domain *Domain, farmerID primitive.ObjectID) (
 		domain.ID = primitive.NewObjectID()
 		domain.Code = proposal.Code
 		domain.CommodityID = proposal.CommodityID
+		domain.ValidatorID = pu.assignValidator(domain.RegionID)
 		domain.Status = constant.ProposalStatusPending
+		domain.RemainingQuantity = domain.EstimatedTotalHarvest
+		domain.IsQuantityTracked = true
 		domain.CreatedAt = proposal.CreatedAt
 		domain.UpdatedAt = proposal.UpdatedAt
 
@@ -235,19 +334,57 @@ func (pu *ProposalUseCase) Update(domain *Domain, farmerID primitive.ObjectID) (
 		if err != nil {
 			return http.StatusInternalServerError, errors.New("gagal membuat proposal")
 		}
+
+		// the revision is a new proposal that keeps the code, so its history starts from the approved one it replaces
+		auditEvent := auditEvents.NewEvent(constant.AuditEntityProposal, domain.ID, farmerID, constant.RoleFarmer, proposal.Status, domain.Status, "revisi proposal "+proposal.ID.Hex())
+		_, _ = pu.auditEventRepository.Create(context.Background(), &auditEvent)
+		pu.eventHub.Publish(events.FromAuditEvent(&auditEvent, farmerID, domain.ValidatorID))
+
+		pu.notifyValidators(domain)
 	} else if proposal.Status == constant.ProposalStatusPending || proposal.Status == constant.ProposalStatusRejected {
+		auditEvent := auditEvents.NewEvent(constant.AuditEntityProposal, proposal.ID, farmerID, constant.RoleFarmer, proposal.Status, constant.ProposalStatusPending, "")
+
 		proposal.Name = domain.Name
 		proposal.Description = domain.Description
 		proposal.Status = constant.ProposalStatusPending
 		proposal.EstimatedTotalHarvest = domain.EstimatedTotalHarvest
+		proposal.RemainingQuantity = domain.EstimatedTotalHarvest
+		proposal.IsQuantityTracked = true
 		proposal.PlantingArea = domain.PlantingArea
 		proposal.Address = domain.Address
 		proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-		_, err = pu.proposalRepository.Update(&proposal)
-		if err != nil {
-			return http.StatusInternalServerError, errors.New("gagal memperbarui proposal")
+		err = pu.unitOfWork.Execute(func(ctx context.Context) error {
+			proposal := proposal
+
+			_, err := pu.proposalRepository.Update(ctx, &proposal)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal memperbarui proposal: %w", err)
+			}
+
+			// editing a pending proposal is not a transition
+			if auditEvent.OldStatus != auditEvent.NewStatus {
+				_, err = pu.auditEventRepository.Create(ctx, &auditEvent)
+				if err != nil {
+					return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+				}
+			}
+
+			return nil
+		})
+		if helper.IsConflictError(err) {
+			return http.StatusConflict, errors.New("proposal telah diubah oleh pengguna lain, silakan coba lagi")
+		} else if err != nil {
+			return http.StatusInternalServerError, err
+		}
+
+		if auditEvent.OldStatus != auditEvent.NewStatus {
+			pu.eventHub.Publish(events.FromAuditEvent(&auditEvent, farmerID, proposal.ValidatorID))
 		}
+
+		pu.notifyValidators(&proposal)
 	} else {
 		return http.StatusBadRequest, errors.New("status proposal tidak valid")
 	}
@@ -281,8 +418,10 @@ func (pu *ProposalUseCase) UpdateCommodityID(oldCommodityID primitive.ObjectID,
 				return http.StatusInternalServerError, err
 			}
 		} else if proposal.Status == constant.ProposalStatusPending || proposal.Status == constant.ProposalStatusRejected {
-			_, err = pu.proposalRepository.Update(&proposal)
-			if err != nil {
+			_, err = pu.proposalRepository.Update(context.Background(), &proposal)
+			if helper.IsConflictError(err) {
+				return http.StatusConflict, errors.New("proposal telah diubah oleh pengguna lain, silakan coba lagi")
+			} else if err != nil {
 				return http.StatusInternalServerError, errors.New("gagal memperbarui proposal")
 			}
 		} else {
@@ -305,11 +444,20 @@ func (pu *ProposalUseCase) ValidateProposal(domain *Domain, validatorID primitiv
 		return http.StatusBadRequest, errors.New("proposal sudah divalidasi")
 	}
 
+	if proposal.ValidatorID != primitive.NilObjectID && proposal.ValidatorID != validatorID {
+		return http.StatusForbidden, errors.New("proposal ditugaskan kepada validator lain")
+	}
+
 	isStatusAvailable := util.CheckStringOnArray([]string{constant.ProposalStatusRejected, constant.ProposalStatusApproved}, domain.Status)
 	if !isStatusAvailable {
 		return http.StatusBadRequest, errors.New("status proposal hanya tersedia approved dan rejected")
 	}
 
+	auditEvent := auditEvents.NewEvent(constant.AuditEntityProposal, proposal.ID, validatorID, constant.RoleValidator, proposal.Status, domain.Status, "")
+	if domain.Status == constant.ProposalStatusRejected {
+		auditEvent.Reason = domain.RejectReason
+	}
+
 	proposal.ValidatorID = validatorID
 	proposal.Status = domain.Status
 	proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
@@ -318,17 +466,105 @@ func (pu *ProposalUseCase) ValidateProposal(domain *Domain, validatorID primitiv
 		proposal.RejectReason = domain.RejectReason
 	} else {
 		proposal.IsAvailable = true
-		_, err = pu.proposalRepository.UnsetRejectReason(proposal.ID)
+		proposal.RemainingQuantity = proposal.EstimatedTotalHarvest
+		proposal.IsQuantityTracked = true
+	}
+
+	err = pu.unitOfWork.Execute(func(ctx context.Context) error {
+		proposal := proposal
+
+		// the reason of an earlier rejection is cleared under the same version check as the rest of the update
+		if proposal.Status == constant.ProposalStatusApproved {
+			_, err := pu.proposalRepository.UnsetRejectReason(ctx, &proposal)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal memperbarui proposal: %w", err)
+			}
+		}
+
+		_, err := pu.proposalRepository.Update(ctx, &proposal)
+		if helper.IsConflictError(err) {
+			return err
+		} else if err != nil {
+			return fmt.Errorf("gagal memperbarui proposal: %w", err)
+		}
+
+		_, err = pu.auditEventRepository.Create(ctx, &auditEvent)
 		if err != nil {
-			return http.StatusInternalServerError, errors.New("gagal memperbarui proposal")
+			return fmt.Errorf("gagal mencatat riwayat status: %w", err)
 		}
+
+		return pu.webhookUseCase.Dispatch(ctx, constant.WebhookEventProposalValidated, webhooks.ProposalPayload{
+			ID:           proposal.ID,
+			CommodityID:  proposal.CommodityID,
+			Status:       proposal.Status,
+			RejectReason: proposal.RejectReason,
+		})
+	})
+	if helper.IsConflictError(err) {
+		return http.StatusConflict, errors.New("proposal telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
+		return http.StatusInternalServerError, err
 	}
 
-	_, err = pu.proposalRepository.Update(&proposal)
-	if err != nil {
+	commodity, err := pu.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
+	pu.eventHub.Publish(events.FromAuditEvent(&auditEvent, commodity.FarmerID, validatorID))
+
+	if err == nil {
+		decision := "disetujui"
+		rejectReason := ""
+		if proposal.Status == constant.ProposalStatusRejected {
+			decision = "ditolak"
+			rejectReason = fmt.Sprintf("Alasan: %s", proposal.RejectReason)
+		}
+
+		// a failed email is ignored, the farmer still sees the validation in the app
+		_ = pu.emailUseCase.SendToUser(context.Background(), commodity.FarmerID, constant.MailgunProposalValidationTemplate, map[string]string{
+			"proposal":     proposal.Name,
+			"decision":     decision,
+			"rejectReason": rejectReason,
+		})
+	}
+
+	return http.StatusOK, nil
+}
+
+func (pu *ProposalUseCase) Assign(id primitive.ObjectID, validatorID primitive.ObjectID) (int, error) {
+	proposal, err := pu.proposalRepository.GetByIDWithoutDeleted(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("proposal tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengambil data proposal")
+	}
+
+	if proposal.Status != constant.ProposalStatusPending {
+		return http.StatusBadRequest, errors.New("proposal sudah divalidasi")
+	}
+
+	validator, err := pu.userRepository.GetByID(validatorID)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("validator tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengambil data validator")
+	}
+
+	if validator.Role != constant.RoleValidator || validator.Status != constant.UserStatusActive {
+		return http.StatusBadRequest, errors.New("proposal hanya dapat ditugaskan kepada validator aktif")
+	}
+
+	proposal.ValidatorID = validator.ID
+	proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = pu.proposalRepository.Update(context.Background(), &proposal)
+	if helper.IsConflictError(err) {
+		return http.StatusConflict, errors.New("proposal telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
 		return http.StatusInternalServerError, errors.New("gagal memperbarui proposal")
 	}
 
+	pu.notifyValidators(&proposal)
+
 	return http.StatusOK, nil
 }
 
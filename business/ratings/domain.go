@@ -0,0 +1,50 @@
+package ratings
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is the review a buyer leaves once per completed transaction, scoring both the farmer and the commodity from 1 to 5.
+type Domain struct {
+	ID              primitive.ObjectID
+	TransactionID   primitive.ObjectID
+	BuyerID         primitive.ObjectID
+	FarmerID        primitive.ObjectID
+	CommodityID     primitive.ObjectID
+	CommodityCode   primitive.ObjectID
+	FarmerRating    int
+	CommodityRating int
+	Review          string
+	CreatedAt       primitive.DateTime
+}
+
+type Query struct {
+	Skip          int64
+	Limit         int64
+	Sort          string
+	Order         int
+	FarmerID      primitive.ObjectID
+	CommodityID   primitive.ObjectID
+	CommodityCode primitive.ObjectID
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	// Read
+	GetByTransactionID(transactionID primitive.ObjectID) (Domain, error)
+	GetByQuery(query Query) ([]Domain, int, error)
+	// Update
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	Create(domain *Domain, buyerID primitive.ObjectID) (Domain, int, error)
+	// Read
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	// Update
+	// Delete
+}
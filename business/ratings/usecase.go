@@ -0,0 +1,171 @@
+package ratings
+
+import (
+	"context"
+	"crop_connect/business/commodities"
+	"crop_connect/business/notifications"
+	"crop_connect/business/proposals"
+	"crop_connect/business/shipments"
+	"crop_connect/business/transactions"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/business/users"
+	"crop_connect/constant"
+	"crop_connect/util"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RatingUseCase struct {
+	ratingRepository       Repository
+	transactionRepository  transactions.Repository
+	shipmentRepository     shipments.Repository
+	proposalRepository     proposals.Repository
+	commodityRepository    commodities.Repository
+	userRepository         users.Repository
+	notificationRepository notifications.Repository
+	unitOfWork             unitOfWork.UnitOfWork
+}
+
+func NewUseCase(rr Repository, tr transactions.Repository, sr shipments.Repository, pr proposals.Repository, cr commodities.Repository, ur users.Repository, nr notifications.Repository, uow unitOfWork.UnitOfWork) UseCase {
+	return &RatingUseCase{
+		ratingRepository:       rr,
+		transactionRepository:  tr,
+		shipmentRepository:     sr,
+		proposalRepository:     pr,
+		commodityRepository:    cr,
+		userRepository:         ur,
+		notificationRepository: nr,
+		unitOfWork:             uow,
+	}
+}
+
+/*
+Create
+*/
+
+func (ru *RatingUseCase) Create(domain *Domain, buyerID primitive.ObjectID) (Domain, int, error) {
+	if domain.FarmerRating < 1 || domain.FarmerRating > 5 || domain.CommodityRating < 1 || domain.CommodityRating > 5 {
+		return Domain{}, http.StatusBadRequest, errors.New("penilaian harus bernilai 1 sampai 5")
+	}
+
+	transaction, err := ru.transactionRepository.GetByID(domain.TransactionID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	if transaction.BuyerID != buyerID {
+		return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if !util.CheckStringOnArray([]string{constant.TransactionStatusPaid, constant.TransactionStatusPartiallyRefunded}, transaction.Status) {
+		return Domain{}, http.StatusBadRequest, errors.New("penilaian hanya dapat diberikan untuk transaksi yang sudah selesai")
+	}
+
+	shipment, err := ru.shipmentRepository.GetByTransactionID(transaction.ID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman")
+	}
+
+	if err == mongo.ErrNoDocuments || shipment.Status != constant.ShipmentStatusDelivered {
+		return Domain{}, http.StatusBadRequest, errors.New("penilaian hanya dapat diberikan setelah hasil panen diterima")
+	}
+
+	_, err = ru.ratingRepository.GetByTransactionID(transaction.ID)
+	if err == nil {
+		return Domain{}, http.StatusConflict, errors.New("transaksi sudah diberi penilaian")
+	} else if err != mongo.ErrNoDocuments {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan penilaian")
+	}
+
+	proposal, err := ru.proposalRepository.GetByIDWithoutDeleted(transaction.ProposalID)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+	}
+
+	commodity, err := ru.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan komoditas")
+	}
+
+	domain.ID = primitive.NewObjectID()
+	domain.BuyerID = buyerID
+	domain.FarmerID = commodity.FarmerID
+	domain.CommodityID = commodity.ID
+	domain.CommodityCode = commodity.Code
+	domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	err = ru.unitOfWork.Execute(func(ctx context.Context) error {
+		_, err := ru.ratingRepository.Create(ctx, domain)
+		if err != nil {
+			return fmt.Errorf("gagal membuat penilaian: %w", err)
+		}
+
+		err = ru.commodityRepository.AddRating(ctx, domain.CommodityCode, domain.CommodityRating)
+		if err != nil {
+			return fmt.Errorf("gagal memperbarui penilaian komoditas: %w", err)
+		}
+
+		err = ru.userRepository.AddRating(ctx, domain.FarmerID, domain.FarmerRating)
+		if err != nil {
+			return fmt.Errorf("gagal memperbarui penilaian petani: %w", err)
+		}
+
+		_, err = ru.notificationRepository.Create(ctx, &notifications.Domain{
+			ID:          primitive.NewObjectID(),
+			UserID:      domain.FarmerID,
+			Type:        constant.NotificationTypeRatingReceived,
+			Title:       "Penilaian baru",
+			Message:     "Pembeli memberikan penilaian untuk komoditas " + commodity.Name,
+			ReferenceID: domain.ID,
+			CreatedAt:   domain.CreatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("gagal membuat notifikasi: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, err
+	}
+
+	return *domain, http.StatusCreated, nil
+}
+
+/*
+Read
+*/
+
+func (ru *RatingUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	// reviews follow the commodity code so they stay visible after the commodity is edited
+	if query.CommodityID != primitive.NilObjectID {
+		commodity, err := ru.commodityRepository.GetByIDWithoutDeleted(query.CommodityID)
+		if err == mongo.ErrNoDocuments {
+			return []Domain{}, 0, http.StatusNotFound, errors.New("komoditas tidak ditemukan")
+		} else if err != nil {
+			return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan komoditas")
+		}
+
+		query.CommodityCode = commodity.Code
+	}
+
+	ratings, totalData, err := ru.ratingRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan penilaian")
+	}
+
+	return ratings, totalData, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
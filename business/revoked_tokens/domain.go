@@ -0,0 +1,26 @@
+package revoked_tokens
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is the jti of an access token revoked before it expired, it can be removed once ExpiredAt has passed.
+type Domain struct {
+	ID        string
+	UserID    primitive.ObjectID
+	ExpiredAt primitive.DateTime
+	CreatedAt primitive.DateTime
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) error
+	CreateMany(ctx context.Context, domains []Domain) error
+	// Read
+	IsRevoked(id string) (bool, error)
+	// Update
+	// Delete
+	DeleteExpired(now primitive.DateTime) (int, error)
+}
@@ -0,0 +1,57 @@
+package sessions
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is one login of a user, it keeps the jti of the latest token pair so the pair can be rotated and revoked.
+type Domain struct {
+	ID                   primitive.ObjectID
+	UserID               primitive.ObjectID
+	RefreshTokenID       string
+	AccessTokenID        string
+	AccessTokenExpiredAt primitive.DateTime
+	ExpiredAt            primitive.DateTime
+	RevokedAt            primitive.DateTime
+	CreatedAt            primitive.DateTime
+	UpdatedAt            primitive.DateTime
+}
+
+type TokenPair struct {
+	AccessToken           string
+	RefreshToken          string
+	AccessTokenExpiredAt  primitive.DateTime
+	RefreshTokenExpiredAt primitive.DateTime
+}
+
+// RoleGetter returns the current role of an active user, users depends on sessions so the lookup is passed in.
+type RoleGetter func(userID primitive.ObjectID) (string, int, error)
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, error)
+	GetActiveByUserID(userID primitive.ObjectID) ([]Domain, error)
+	// Update
+	Update(ctx context.Context, domain *Domain) (Domain, error)
+	UpdateByRefreshTokenID(ctx context.Context, domain *Domain, refreshTokenID string) (Domain, error)
+	// Delete
+	DeleteExpired(now primitive.DateTime) (int, error)
+}
+
+type UseCase interface {
+	// Create
+	Create(userID primitive.ObjectID, role string) (TokenPair, int, error)
+	// Read
+	IsRevoked(tokenID string) (bool, error)
+	// Update
+	Consume(tokenID string, userID primitive.ObjectID, expiredAt primitive.DateTime) (int, error)
+	Refresh(refreshToken string, getRole RoleGetter) (TokenPair, int, error)
+	Revoke(id primitive.ObjectID, userID primitive.ObjectID) (int, error)
+	RevokeAllByUserID(userID primitive.ObjectID) (int, error)
+	// Delete
+	PurgeExpired() (int, int, error)
+}
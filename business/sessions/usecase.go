@@ -0,0 +1,267 @@
+package sessions
+
+import (
+	"context"
+	revokedTokens "crop_connect/business/revoked_tokens"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/constant"
+	"crop_connect/helper"
+	"crop_connect/util"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+const (
+	accessTokenDuration  = 15 * time.Minute
+	refreshTokenDuration = 7 * 24 * time.Hour
+)
+
+type SessionUseCase struct {
+	sessionRepository      Repository
+	revokedTokenRepository revokedTokens.Repository
+	unitOfWork             unitOfWork.UnitOfWork
+}
+
+func NewUseCase(sr Repository, rtr revokedTokens.Repository, uow unitOfWork.UnitOfWork) UseCase {
+	return &SessionUseCase{
+		sessionRepository:      sr,
+		revokedTokenRepository: rtr,
+		unitOfWork:             uow,
+	}
+}
+
+var errorRefreshToken = errors.New("refresh token tidak valid")
+
+/*
+Util
+*/
+
+func (su *SessionUseCase) issueTokenPair(session *Domain, role string) TokenPair {
+	accessTokenExpiredAt := time.Now().Add(accessTokenDuration)
+
+	session.AccessTokenID = util.GenerateUUID()
+	session.AccessTokenExpiredAt = primitive.NewDateTimeFromTime(accessTokenExpiredAt)
+	session.RefreshTokenID = util.GenerateUUID()
+
+	return TokenPair{
+		AccessToken:           "Bearer " + helper.GenerateToken(session.UserID.Hex(), role, session.ID.Hex(), session.AccessTokenID, constant.TokenTypeAccess, accessTokenExpiredAt),
+		RefreshToken:          helper.GenerateToken(session.UserID.Hex(), role, session.ID.Hex(), session.RefreshTokenID, constant.TokenTypeRefresh, session.ExpiredAt.Time()),
+		AccessTokenExpiredAt:  session.AccessTokenExpiredAt,
+		RefreshTokenExpiredAt: session.ExpiredAt,
+	}
+}
+
+// revoke ends the sessions and revokes their current access token in one unit of work.
+func (su *SessionUseCase) revoke(sessions []Domain) (int, error) {
+	if len(sessions) == 0 {
+		return http.StatusOK, nil
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	revokedTokenList := []revokedTokens.Domain{}
+	for i := range sessions {
+		sessions[i].RevokedAt = now
+		sessions[i].UpdatedAt = now
+
+		revokedTokenList = append(revokedTokenList, revokedTokens.Domain{
+			ID:        sessions[i].AccessTokenID,
+			UserID:    sessions[i].UserID,
+			ExpiredAt: sessions[i].AccessTokenExpiredAt,
+			CreatedAt: now,
+		})
+	}
+
+	err := su.unitOfWork.Execute(func(ctx context.Context) error {
+		for i := range sessions {
+			_, err := su.sessionRepository.Update(ctx, &sessions[i])
+			if err != nil {
+				return fmt.Errorf("gagal mengakhiri sesi: %w", err)
+			}
+		}
+
+		err := su.revokedTokenRepository.CreateMany(ctx, revokedTokenList)
+		if err != nil {
+			return fmt.Errorf("gagal mencabut token: %w", err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	return http.StatusOK, nil
+}
+
+/*
+Create
+*/
+
+func (su *SessionUseCase) Create(userID primitive.ObjectID, role string) (TokenPair, int, error) {
+	session := Domain{
+		ID:        primitive.NewObjectID(),
+		UserID:    userID,
+		ExpiredAt: primitive.NewDateTimeFromTime(time.Now().Add(refreshTokenDuration)),
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	tokenPair := su.issueTokenPair(&session, role)
+
+	_, err := su.sessionRepository.Create(context.Background(), &session)
+	if err != nil {
+		return TokenPair{}, http.StatusInternalServerError, errors.New("gagal membuat sesi")
+	}
+
+	return tokenPair, http.StatusCreated, nil
+}
+
+/*
+Read
+*/
+
+func (su *SessionUseCase) IsRevoked(tokenID string) (bool, error) {
+	return su.revokedTokenRepository.IsRevoked(tokenID)
+}
+
+/*
+Update
+*/
+
+// Consume revokes a single use token, a token that was already consumed or revoked is rejected.
+func (su *SessionUseCase) Consume(tokenID string, userID primitive.ObjectID, expiredAt primitive.DateTime) (int, error) {
+	err := su.revokedTokenRepository.Create(context.Background(), &revokedTokens.Domain{
+		ID:        tokenID,
+		UserID:    userID,
+		ExpiredAt: expiredAt,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	})
+	if mongo.IsDuplicateKeyError(err) {
+		return http.StatusUnauthorized, errors.New("token sudah digunakan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mencabut token")
+	}
+
+	return http.StatusOK, nil
+}
+
+// Refresh rotates the token pair of the session, the access token it replaces is revoked right away.
+// A refresh token that was already rotated means it leaked, so the whole session is revoked.
+// The role is read again from the user so a role change or a suspension applies from the next refresh.
+func (su *SessionUseCase) Refresh(refreshToken string, getRole RoleGetter) (TokenPair, int, error) {
+	claims, err := helper.GetPayloadToken(refreshToken)
+	if err != nil || claims.Type != constant.TokenTypeRefresh {
+		return TokenPair{}, http.StatusUnauthorized, errorRefreshToken
+	}
+
+	sessionID, err := primitive.ObjectIDFromHex(claims.SessionID)
+	if err != nil {
+		return TokenPair{}, http.StatusUnauthorized, errorRefreshToken
+	}
+
+	session, err := su.sessionRepository.GetByID(sessionID)
+	if err == mongo.ErrNoDocuments {
+		return TokenPair{}, http.StatusUnauthorized, errorRefreshToken
+	} else if err != nil {
+		return TokenPair{}, http.StatusInternalServerError, errors.New("gagal mendapatkan sesi")
+	}
+
+	if session.RevokedAt != 0 || session.ExpiredAt < primitive.NewDateTimeFromTime(time.Now()) {
+		return TokenPair{}, http.StatusUnauthorized, errorRefreshToken
+	}
+
+	if session.RefreshTokenID != claims.ID {
+		if _, err := su.revoke([]Domain{session}); err != nil {
+			return TokenPair{}, http.StatusInternalServerError, err
+		}
+
+		return TokenPair{}, http.StatusUnauthorized, errorRefreshToken
+	}
+
+	role, statusCode, err := getRole(session.UserID)
+	if err != nil {
+		return TokenPair{}, statusCode, err
+	}
+
+	replacedToken := revokedTokens.Domain{
+		ID:        session.AccessTokenID,
+		UserID:    session.UserID,
+		ExpiredAt: session.AccessTokenExpiredAt,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	tokenPair := su.issueTokenPair(&session, role)
+	session.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	// a concurrent refresh with the same token loses here, the same as a reused one
+	err = su.unitOfWork.Execute(func(ctx context.Context) error {
+		session := session
+
+		_, err := su.sessionRepository.UpdateByRefreshTokenID(ctx, &session, claims.ID)
+		if err != nil {
+			return fmt.Errorf("gagal memperbarui sesi: %w", err)
+		}
+
+		err = su.revokedTokenRepository.CreateMany(ctx, []revokedTokens.Domain{replacedToken})
+		if err != nil {
+			return fmt.Errorf("gagal mencabut token: %w", err)
+		}
+
+		return nil
+	})
+	if errors.Is(err, mongo.ErrNoDocuments) {
+		return TokenPair{}, http.StatusUnauthorized, errorRefreshToken
+	} else if err != nil {
+		return TokenPair{}, http.StatusInternalServerError, err
+	}
+
+	return tokenPair, http.StatusOK, nil
+}
+
+func (su *SessionUseCase) Revoke(id primitive.ObjectID, userID primitive.ObjectID) (int, error) {
+	session, err := su.sessionRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments || (err == nil && session.UserID != userID) {
+		return http.StatusNotFound, errors.New("sesi tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan sesi")
+	}
+
+	if session.RevokedAt != 0 {
+		return http.StatusOK, nil
+	}
+
+	return su.revoke([]Domain{session})
+}
+
+func (su *SessionUseCase) RevokeAllByUserID(userID primitive.ObjectID) (int, error) {
+	sessions, err := su.sessionRepository.GetActiveByUserID(userID)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan sesi")
+	}
+
+	return su.revoke(sessions)
+}
+
+/*
+Delete
+*/
+
+func (su *SessionUseCase) PurgeExpired() (int, int, error) {
+	now := primitive.NewDateTimeFromTime(time.Now())
+
+	totalSession, err := su.sessionRepository.DeleteExpired(now)
+	if err != nil {
+		return 0, http.StatusInternalServerError, errors.New("gagal menghapus sesi")
+	}
+
+	totalRevokedToken, err := su.revokedTokenRepository.DeleteExpired(now)
+	if err != nil {
+		return totalSession, http.StatusInternalServerError, errors.New("gagal menghapus token")
+	}
+
+	return totalSession + totalRevokedToken, http.StatusOK, nil
+}
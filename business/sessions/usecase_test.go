@@ -0,0 +1,131 @@
+package sessions_test
+
+import (
+	"crop_connect/business/sessions"
+	"crop_connect/constant"
+	"crop_connect/driver"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/helper"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newUseCase() (sessions.UseCase, *memoryDriver.Database) {
+	helper.JWTSecretKey = "secret"
+
+	db := memoryDriver.Init()
+	return sessions.NewUseCase(driver.NewSessionMemoryRepository(db), driver.NewRevokedTokenMemoryRepository(db), driver.NewUnitOfWorkMemory(db)), db
+}
+
+func roleOf(role string) sessions.RoleGetter {
+	return func(userID primitive.ObjectID) (string, int, error) {
+		return role, http.StatusOK, nil
+	}
+}
+
+func TestRefresh(t *testing.T) {
+	suspended := func(userID primitive.ObjectID) (string, int, error) {
+		return "", http.StatusForbidden, errors.New("akun ditangguhkan")
+	}
+
+	tests := []struct {
+		name           string
+		reuseOldToken  bool
+		getRole        sessions.RoleGetter
+		wantStatusCode int
+		wantRole       string
+		wantRevoked    bool
+	}{
+		{
+			name:           "the role is read again from the user",
+			getRole:        roleOf(constant.RoleValidator),
+			wantStatusCode: http.StatusOK,
+			wantRole:       constant.RoleValidator,
+		},
+		{
+			name:           "a suspended user cannot refresh",
+			getRole:        suspended,
+			wantStatusCode: http.StatusForbidden,
+		},
+		{
+			name:           "a rotated refresh token revokes the whole session",
+			reuseOldToken:  true,
+			getRole:        roleOf(constant.RoleBuyer),
+			wantStatusCode: http.StatusUnauthorized,
+			wantRevoked:    true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			useCase, db := newUseCase()
+
+			tokenPair, _, err := useCase.Create(primitive.NewObjectID(), constant.RoleBuyer)
+			if err != nil {
+				t.Fatalf("Create() error = %v", err)
+			}
+
+			refreshToken := tokenPair.RefreshToken
+			if tt.reuseOldToken {
+				if _, _, err := useCase.Refresh(refreshToken, roleOf(constant.RoleBuyer)); err != nil {
+					t.Fatalf("first Refresh() error = %v", err)
+				}
+			}
+
+			newTokenPair, statusCode, err := useCase.Refresh(refreshToken, tt.getRole)
+			if statusCode != tt.wantStatusCode {
+				t.Fatalf("status code = %d, want %d (err: %v)", statusCode, tt.wantStatusCode, err)
+			}
+
+			if tt.wantRole != "" {
+				claims, err := helper.GetPayloadToken(newTokenPair.RefreshToken)
+				if err != nil || claims.Role != tt.wantRole {
+					t.Errorf("role = %q (err: %v), want %q", claims.Role, err, tt.wantRole)
+				}
+			}
+
+			if isRevoked := db.Sessions[0].RevokedAt != 0; isRevoked != tt.wantRevoked {
+				t.Errorf("revoked = %v, want %v", isRevoked, tt.wantRevoked)
+			}
+		})
+	}
+}
+
+func TestRefreshConcurrently(t *testing.T) {
+	useCase, _ := newUseCase()
+
+	tokenPair, _, err := useCase.Create(primitive.NewObjectID(), constant.RoleBuyer)
+	if err != nil {
+		t.Fatalf("Create() error = %v", err)
+	}
+
+	const attempts = 8
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		succeeded int
+	)
+
+	for i := 0; i < attempts; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+
+			if _, _, err := useCase.Refresh(tokenPair.RefreshToken, roleOf(constant.RoleBuyer)); err == nil {
+				mu.Lock()
+				succeeded++
+				mu.Unlock()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if succeeded != 1 {
+		t.Errorf("successful refreshes = %d, want 1", succeeded)
+	}
+}
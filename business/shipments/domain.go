@@ -0,0 +1,61 @@
+package shipments
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Domain struct {
+	ID              primitive.ObjectID
+	TransactionID   primitive.ObjectID
+	BatchID         primitive.ObjectID
+	HarvestID       primitive.ObjectID
+	FarmerID        primitive.ObjectID
+	BuyerID         primitive.ObjectID
+	Status          string
+	PickupDate      primitive.DateTime
+	Courier         string
+	TrackingNumber  string
+	DeliveredWeight float64
+	DispatchedAt    primitive.DateTime
+	ReceivedAt      primitive.DateTime
+	ReceiptNote     string
+	CreatedAt       primitive.DateTime
+	UpdatedAt       primitive.DateTime
+}
+
+type Query struct {
+	Skip     int64
+	Limit    int64
+	Sort     string
+	Order    int
+	FarmerID primitive.ObjectID
+	BuyerID  primitive.ObjectID
+	Status   string
+}
+
+type Repository interface {
+	// Create
+	Create(ctx context.Context, domain *Domain) (Domain, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, error)
+	GetByTransactionID(transactionID primitive.ObjectID) (Domain, error)
+	GetByQuery(query Query) ([]Domain, int, error)
+	// Update
+	Update(domain *Domain) (Domain, error)
+	UpdateByStatus(domain *Domain, status string) (Domain, error)
+	// Delete
+}
+
+type UseCase interface {
+	// Create
+	// Read
+	GetByID(id primitive.ObjectID, userID primitive.ObjectID) (Domain, int, error)
+	GetByTransactionID(transactionID primitive.ObjectID, userID primitive.ObjectID) (Domain, int, error)
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	// Update
+	Dispatch(domain *Domain, farmerID primitive.ObjectID) (Domain, int, error)
+	ConfirmReceipt(domain *Domain, buyerID primitive.ObjectID) (Domain, int, error)
+	// Delete
+}
@@ -0,0 +1,157 @@
+package shipments
+
+import (
+	"crop_connect/business/transactions"
+	"crop_connect/constant"
+	"errors"
+	"net/http"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ShipmentUseCase struct {
+	shipmentRepository    Repository
+	transactionRepository transactions.Repository
+}
+
+func NewUseCase(sr Repository, tr transactions.Repository) UseCase {
+	return &ShipmentUseCase{
+		shipmentRepository:    sr,
+		transactionRepository: tr,
+	}
+}
+
+/*
+Create
+*/
+
+/*
+Read
+*/
+
+func (su *ShipmentUseCase) GetByID(id primitive.ObjectID, userID primitive.ObjectID) (Domain, int, error) {
+	shipment, err := su.shipmentRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("pengiriman tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman")
+	}
+
+	if shipment.FarmerID != userID && shipment.BuyerID != userID {
+		return Domain{}, http.StatusNotFound, errors.New("pengiriman tidak ditemukan")
+	}
+
+	return shipment, http.StatusOK, nil
+}
+
+func (su *ShipmentUseCase) GetByTransactionID(transactionID primitive.ObjectID, userID primitive.ObjectID) (Domain, int, error) {
+	shipment, err := su.shipmentRepository.GetByTransactionID(transactionID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("pengiriman tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman")
+	}
+
+	if shipment.FarmerID != userID && shipment.BuyerID != userID {
+		return Domain{}, http.StatusNotFound, errors.New("pengiriman tidak ditemukan")
+	}
+
+	return shipment, http.StatusOK, nil
+}
+
+func (su *ShipmentUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	shipments, totalData, err := su.shipmentRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman")
+	}
+
+	return shipments, totalData, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+func (su *ShipmentUseCase) Dispatch(domain *Domain, farmerID primitive.ObjectID) (Domain, int, error) {
+	shipment, err := su.shipmentRepository.GetByID(domain.ID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("pengiriman tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman")
+	}
+
+	if shipment.FarmerID != farmerID {
+		return Domain{}, http.StatusForbidden, errors.New("anda tidak memiliki akses")
+	}
+
+	if shipment.Status != constant.ShipmentStatusWaitingPickup {
+		return Domain{}, http.StatusConflict, errors.New("pengiriman sudah dikirim")
+	}
+
+	transaction, err := su.transactionRepository.GetByID(shipment.TransactionID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	if domain.DeliveredWeight <= 0 || domain.DeliveredWeight > transaction.ActualQuantity {
+		return Domain{}, http.StatusBadRequest, errors.New("berat yang dikirim harus lebih dari 0 dan tidak melebihi jumlah hasil panen transaksi")
+	}
+
+	shipment.Status = constant.ShipmentStatusDispatched
+	shipment.PickupDate = domain.PickupDate
+	shipment.Courier = domain.Courier
+	shipment.TrackingNumber = domain.TrackingNumber
+	shipment.DeliveredWeight = domain.DeliveredWeight
+	shipment.DispatchedAt = primitive.NewDateTimeFromTime(time.Now())
+	shipment.UpdatedAt = shipment.DispatchedAt
+
+	_, err = su.shipmentRepository.UpdateByStatus(&shipment, constant.ShipmentStatusWaitingPickup)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusConflict, errors.New("pengiriman sudah dikirim")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui pengiriman")
+	}
+
+	return shipment, http.StatusOK, nil
+}
+
+func (su *ShipmentUseCase) ConfirmReceipt(domain *Domain, buyerID primitive.ObjectID) (Domain, int, error) {
+	shipment, err := su.shipmentRepository.GetByID(domain.ID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("pengiriman tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman")
+	}
+
+	if shipment.BuyerID != buyerID {
+		return Domain{}, http.StatusForbidden, errors.New("anda tidak memiliki akses")
+	}
+
+	if shipment.Status == constant.ShipmentStatusWaitingPickup {
+		return Domain{}, http.StatusBadRequest, errors.New("pengiriman belum dikirim oleh petani")
+	} else if shipment.Status == constant.ShipmentStatusDelivered {
+		return Domain{}, http.StatusConflict, errors.New("pengiriman sudah dikonfirmasi")
+	}
+
+	shipment.Status = constant.ShipmentStatusDelivered
+	shipment.ReceiptNote = domain.ReceiptNote
+	shipment.ReceivedAt = primitive.NewDateTimeFromTime(time.Now())
+	shipment.UpdatedAt = shipment.ReceivedAt
+
+	_, err = su.shipmentRepository.UpdateByStatus(&shipment, constant.ShipmentStatusDispatched)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusConflict, errors.New("pengiriman sudah dikonfirmasi")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui pengiriman")
+	}
+
+	return shipment, http.StatusOK, nil
+}
+
+/*
+Delete
+*/
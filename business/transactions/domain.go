@@ -1,6 +1,8 @@
 package transactions
 
 import (
+	"context"
+	"crop_connect/business/batchs"
 	"crop_connect/business/commodities"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,9 +17,24 @@ type Domain struct {
 	BatchID         primitive.ObjectID
 	Address         string
 	Status          string
+	Quantity        float64
+	PricePerKg      float64
 	TotalPrice      float64
+	ActualQuantity  float64
+	FinalPrice      float64
+	PriceDifference float64
+	SettledAt       primitive.DateTime
+	Offers          []Offer
 	CreatedAt       primitive.DateTime
 	UpdatedAt       primitive.DateTime
+	Version         int
+}
+
+type Offer struct {
+	AuthorID   primitive.ObjectID
+	Role       string
+	PricePerKg float64
+	CreatedAt  primitive.DateTime
 }
 
 type Statistic struct {
@@ -62,7 +79,7 @@ type Query struct {
 
 type Repository interface {
 	// Create
-	Create(domain *Domain) (Domain, error)
+	Create(ctx context.Context, domain *Domain) (Domain, error)
 	// Read
 	GetByID(id primitive.ObjectID) (Domain, error)
 	GetByBuyerIDProposalIDAndStatus(buyerID primitive.ObjectID, proposalID primitive.ObjectID, status string) (Domain, error)
@@ -73,10 +90,12 @@ type Repository interface {
 	StatisticTopCommodity(farmerID primitive.ObjectID, year int, limit int) ([]ModelStatisticTopCommodity, error)
 	CountByCommodityCode(Code primitive.ObjectID) (int, float64, error)
 	GetByBuyerIDBatchIDAndStatus(buyerID primitive.ObjectID, batchID primitive.ObjectID, status string) (Domain, error)
+	GetAcceptedByBatchID(batchID primitive.ObjectID) ([]Domain, error)
+	GetPendingCreatedBefore(date primitive.DateTime) ([]Domain, error)
 	// Update
-	Update(domain *Domain) (Domain, error)
-	RejectPendingByProposalID(proposalID primitive.ObjectID) error
-	RejectPendingByBatchID(batchID primitive.ObjectID) error
+	Update(ctx context.Context, domain *Domain) (Domain, error)
+	RejectPendingByProposalID(ctx context.Context, proposalID primitive.ObjectID, remainingQuantity float64) ([]Domain, error)
+	RejectPendingByBatchID(ctx context.Context, batchID primitive.ObjectID, remainingQuantity float64) ([]Domain, error)
 	// Delete
 }
 
@@ -94,5 +113,9 @@ type UseCase interface {
 	// Update
 	MakeDecision(domain *Domain, farmerID primitive.ObjectID) (int, error)
 	CancelOnPending(id primitive.ObjectID, buyerID primitive.ObjectID) (int, error)
+	MakeOffer(id primitive.ObjectID, offer *Offer) (int, error)
+	AcceptOffer(id primitive.ObjectID, userID primitive.ObjectID, role string) (int, error)
+	ExpirePending(createdBefore primitive.DateTime) (int, int, error)
+	CancelBatch(domain *batchs.Domain, farmerID primitive.ObjectID) (int, error)
 	// Delete
 }
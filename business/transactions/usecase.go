@@ -1,10 +1,21 @@
 package transactions
 
 import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
 	"crop_connect/business/batchs"
 	"crop_connect/business/commodities"
+	"crop_connect/business/emails"
+	"crop_connect/business/events"
+	"crop_connect/business/jobs"
+	"crop_connect/business/notifications"
+	"crop_connect/business/policies"
 	"crop_connect/business/proposals"
+	treatmentRecords "crop_connect/business/treatment_records"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/business/webhooks"
 	"crop_connect/constant"
+	"crop_connect/helper"
 	"errors"
 	"fmt"
 	"net/http"
@@ -15,18 +26,36 @@ import (
 )
 
 type TransactionUseCase struct {
-	transactionRepository Repository
-	batchRepository       batchs.Repository
-	commodityRepository   commodities.Repository
-	proposalRepository    proposals.Repository
+	transactionRepository     Repository
+	batchRepository           batchs.Repository
+	commodityRepository       commodities.Repository
+	proposalRepository        proposals.Repository
+	treatmentRecordRepository treatmentRecords.Repository
+	notificationRepository    notifications.Repository
+	auditEventRepository      auditEvents.Repository
+	eventHub                  events.Hub
+	emailUseCase              emails.UseCase
+	jobUseCase                jobs.UseCase
+	webhookUseCase            webhooks.UseCase
+	policyUseCase             policies.UseCase
+	unitOfWork                unitOfWork.UnitOfWork
 }
 
-func NewUseCase(tr Repository, br batchs.Repository, cr commodities.Repository, pr proposals.Repository) UseCase {
+func NewUseCase(tr Repository, br batchs.Repository, cr commodities.Repository, pr proposals.Repository, trr treatmentRecords.Repository, nr notifications.Repository, aer auditEvents.Repository, eh events.Hub, eu emails.UseCase, ju jobs.UseCase, wu webhooks.UseCase, pu policies.UseCase, uow unitOfWork.UnitOfWork) UseCase {
 	return &TransactionUseCase{
-		transactionRepository: tr,
-		batchRepository:       br,
-		commodityRepository:   cr,
-		proposalRepository:    pr,
+		transactionRepository:     tr,
+		batchRepository:           br,
+		commodityRepository:       cr,
+		proposalRepository:        pr,
+		treatmentRecordRepository: trr,
+		notificationRepository:    nr,
+		auditEventRepository:      aer,
+		eventHub:                  eh,
+		emailUseCase:              eu,
+		jobUseCase:                ju,
+		webhookUseCase:            wu,
+		policyUseCase:             pu,
+		unitOfWork:                uow,
 	}
 }
 
@@ -34,32 +63,101 @@ func NewUseCase(tr Repository, br batchs.Repository, cr commodities.Repository,
 Util
 */
 
-func (tu *TransactionUseCase) CheckFarmerIDByProposalID(proposalID primitive.ObjectID, farmerID primitive.ObjectID) (proposals.Domain, commodities.Domain, int, error) {
-	proposal, err := tu.proposalRepository.GetByIDWithoutDeleted(proposalID)
-	if err == mongo.ErrNoDocuments {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
-	} else if err != nil {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("proposal tidak ditemukan")
+// CheckNegotiator makes sure the user is the buyer or the farmer of the transaction and returns the farmer id.
+func (tu *TransactionUseCase) CheckNegotiator(transaction *Domain, userID primitive.ObjectID, role string) (primitive.ObjectID, int, error) {
+	if role == constant.RoleBuyer {
+		if transaction.BuyerID != userID {
+			return primitive.NilObjectID, http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+		}
+
+		proposal, err := tu.proposalRepository.GetByIDWithoutDeleted(transaction.ProposalID)
+		if err != nil {
+			return primitive.NilObjectID, http.StatusInternalServerError, errors.New("proposal tidak ditemukan")
+		}
+
+		commodity, err := tu.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
+		if err != nil {
+			return primitive.NilObjectID, http.StatusInternalServerError, errors.New("komoditas tidak ditemukan")
+		}
+
+		return commodity.FarmerID, http.StatusOK, nil
 	}
 
-	commodity, err := tu.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
-	if err == mongo.ErrNoDocuments {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
-	} else if err != nil {
-		return proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("komoditas tidak ditemukan")
+	_, _, statusCode, err := tu.policyUseCase.GetProposalOfFarmer(transaction.ProposalID, userID)
+	if err != nil {
+		return primitive.NilObjectID, statusCode, err
 	}
 
-	if commodity.FarmerID != farmerID {
-		return proposal, commodity, http.StatusForbidden, errors.New("anda tidak memiliki akses")
+	return userID, http.StatusOK, nil
+}
+
+// remainingQuantity falls back to the estimated harvest for proposals and batches made available before quantities were tracked, a tracked remaining of zero means sold out.
+func remainingQuantity(remaining float64, isQuantityTracked bool, estimatedTotalHarvest float64) float64 {
+	if !isQuantityTracked {
+		return estimatedTotalHarvest
+	}
+
+	return remaining
+}
+
+// recordCreated appends the first event of a new transaction and dispatches its webhook.
+func (tu *TransactionUseCase) recordCreated(transaction *Domain, farmerID primitive.ObjectID) {
+	auditEvent := auditEvents.NewEvent(constant.AuditEntityTransaction, transaction.ID, transaction.BuyerID, constant.RoleBuyer, "", transaction.Status, "")
+	_, _ = tu.auditEventRepository.Create(context.Background(), &auditEvent)
+
+	tu.publish([]auditEvents.Domain{auditEvent}, farmerID)
+	_ = tu.webhookUseCase.Dispatch(context.Background(), constant.WebhookEventTransactionCreated, webhookPayload(transaction))
+}
+
+func webhookPayload(transaction *Domain) webhooks.TransactionPayload {
+	return webhooks.TransactionPayload{
+		ID:              transaction.ID,
+		TransactionType: transaction.TransactionType,
+		ProposalID:      transaction.ProposalID,
+		BatchID:         transaction.BatchID,
+		BuyerID:         transaction.BuyerID,
+		Status:          transaction.Status,
+		Quantity:        transaction.Quantity,
+		PricePerKg:      transaction.PricePerKg,
+		TotalPrice:      transaction.TotalPrice,
 	}
+}
+
+// publish pushes the committed transitions to the farmer and to the buyer of each transaction or the validator of each treatment record.
+// Transactions rejected in bulk are only known by their id, so every entity is read again to find its user.
+func (tu *TransactionUseCase) publish(auditEventList []auditEvents.Domain, farmerID primitive.ObjectID) {
+	for i := range auditEventList {
+		userID := primitive.NilObjectID
+
+		switch auditEventList[i].EntityType {
+		case constant.AuditEntityTransaction:
+			transaction, err := tu.transactionRepository.GetByID(auditEventList[i].EntityID)
+			if err != nil {
+				continue
+			}
 
-	return proposal, commodity, http.StatusOK, nil
+			userID = transaction.BuyerID
+		case constant.AuditEntityTreatmentRecord:
+			treatmentRecord, err := tu.treatmentRecordRepository.GetByID(auditEventList[i].EntityID)
+			if err != nil {
+				continue
+			}
+
+			userID = treatmentRecord.RequesterID
+		default:
+			continue
+		}
+
+		tu.eventHub.Publish(events.FromAuditEvent(&auditEventList[i], userID, farmerID))
+	}
 }
 
 /*
 Create
 */
 
+// Create checks the availability and remaining quantity against the proposal or batch it saves again in the same unit of work,
+// so a concurrent purchase or decision that changed it in between makes this one conflict instead of both passing.
 func (tu *TransactionUseCase) Create(domain *Domain) (int, error) {
 	if domain.TransactionType == constant.TransactionTypeAnnuals {
 		proposal, err := tu.proposalRepository.GetByID(domain.ProposalID)
@@ -84,6 +182,10 @@ func (tu *TransactionUseCase) Create(domain *Domain) (int, error) {
 			return http.StatusConflict, errors.New("komoditas hanya bisa ditransaksikan melalui batch")
 		}
 
+		if domain.Quantity > remainingQuantity(proposal.RemainingQuantity, proposal.IsQuantityTracked, proposal.EstimatedTotalHarvest) {
+			return http.StatusConflict, errors.New("jumlah melebihi sisa proposal")
+		}
+
 		_, err = tu.transactionRepository.GetByBuyerIDProposalIDAndStatus(domain.BuyerID, domain.ProposalID, constant.TransactionStatusPending)
 		if err == mongo.ErrNoDocuments {
 
@@ -96,14 +198,36 @@ func (tu *TransactionUseCase) Create(domain *Domain) (int, error) {
 
 			domain.ID = primitive.NewObjectID()
 			domain.Status = constant.TransactionStatusPending
-			domain.TotalPrice = float64(commodity.PricePerKg) * proposal.EstimatedTotalHarvest
+			domain.PricePerKg = float64(commodity.PricePerKg)
+			domain.TotalPrice = domain.PricePerKg * domain.Quantity
 			domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-			_, err = tu.transactionRepository.Create(domain)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal membuat transaksi")
+			err = tu.unitOfWork.Execute(func(ctx context.Context) error {
+				proposal := proposal
+
+				// saving the proposal at the version it was checked against makes a concurrent transaction or decision conflict
+				_, err := tu.proposalRepository.Update(ctx, &proposal)
+				if helper.IsConflictError(err) {
+					return err
+				} else if err != nil {
+					return fmt.Errorf("gagal memperbarui proposal: %w", err)
+				}
+
+				_, err = tu.transactionRepository.Create(ctx, domain)
+				if err != nil {
+					return fmt.Errorf("gagal membuat transaksi: %w", err)
+				}
+
+				return nil
+			})
+			if helper.IsConflictError(err) {
+				return http.StatusConflict, errors.New("proposal telah diubah oleh pengguna lain, silakan coba lagi")
+			} else if err != nil {
+				return http.StatusInternalServerError, err
 			}
 
+			tu.recordCreated(domain, commodity.FarmerID)
+
 			return http.StatusCreated, nil
 		} else {
 			return http.StatusConflict, errors.New("transaksi sedang diproses")
@@ -138,19 +262,44 @@ func (tu *TransactionUseCase) Create(domain *Domain) (int, error) {
 			return http.StatusConflict, errors.New("komoditas hanya bisa ditransaksikan melalui proposal")
 		}
 
+		if domain.Quantity > remainingQuantity(batch.RemainingQuantity, batch.IsQuantityTracked, proposal.EstimatedTotalHarvest) {
+			return http.StatusConflict, errors.New("jumlah melebihi sisa batch")
+		}
+
 		_, err = tu.transactionRepository.GetByBuyerIDBatchIDAndStatus(domain.BuyerID, domain.BatchID, constant.TransactionStatusPending)
 		if err == mongo.ErrNoDocuments {
 			domain.ID = primitive.NewObjectID()
 			domain.ProposalID = batch.ProposalID
 			domain.Status = constant.TransactionStatusPending
-			domain.TotalPrice = float64(commodity.PricePerKg) * proposal.EstimatedTotalHarvest
+			domain.PricePerKg = float64(commodity.PricePerKg)
+			domain.TotalPrice = domain.PricePerKg * domain.Quantity
 			domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-			_, err = tu.transactionRepository.Create(domain)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal membuat transaksi")
+			err = tu.unitOfWork.Execute(func(ctx context.Context) error {
+				batch := batch
+
+				_, err := tu.batchRepository.Update(ctx, &batch)
+				if helper.IsConflictError(err) {
+					return err
+				} else if err != nil {
+					return fmt.Errorf("gagal memperbarui batch: %w", err)
+				}
+
+				_, err = tu.transactionRepository.Create(ctx, domain)
+				if err != nil {
+					return fmt.Errorf("gagal membuat transaksi: %w", err)
+				}
+
+				return nil
+			})
+			if helper.IsConflictError(err) {
+				return http.StatusConflict, errors.New("batch telah diubah oleh pengguna lain, silakan coba lagi")
+			} else if err != nil {
+				return http.StatusInternalServerError, err
 			}
 
+			tu.recordCreated(domain, commodity.FarmerID)
+
 			return http.StatusCreated, nil
 		} else {
 			return http.StatusConflict, errors.New("transaksi sedang diproses")
@@ -283,6 +432,32 @@ Update
 */
 
 func (tu *TransactionUseCase) MakeDecision(domain *Domain, farmerID primitive.ObjectID) (int, error) {
+	return tu.decide(domain, farmerID, farmerID, constant.RoleFarmer)
+}
+
+// notifyDecision tells the buyer of transaction that it was decided, decision completes the sentence "Transaksi anda ...".
+func (tu *TransactionUseCase) notifyDecision(ctx context.Context, transaction *Domain, decision string) error {
+	_, err := tu.notificationRepository.Create(ctx, &notifications.Domain{
+		ID:          primitive.NewObjectID(),
+		UserID:      transaction.BuyerID,
+		Type:        constant.NotificationTypeTransactionDecision,
+		Title:       "Keputusan transaksi",
+		Message:     "Transaksi anda " + decision,
+		ReferenceID: transaction.ID,
+		CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat notifikasi: %w", err)
+	}
+
+	return tu.emailUseCase.SendToUser(ctx, transaction.BuyerID, constant.MailgunTransactionDecisionTemplate, map[string]string{
+		"transactionID": transaction.ID.Hex(),
+		"decision":      decision,
+	})
+}
+
+// decide settles a pending transaction of the farmer, the actor is the farmer or, when an offer is accepted, the buyer.
+func (tu *TransactionUseCase) decide(domain *Domain, farmerID primitive.ObjectID, actorID primitive.ObjectID, actorRole string) (int, error) {
 	transaction, err := tu.transactionRepository.GetByID(domain.ID)
 	if err != nil {
 		return http.StatusNotFound, errors.New("transaksi tidak ditemukan")
@@ -292,84 +467,203 @@ func (tu *TransactionUseCase) MakeDecision(domain *Domain, farmerID primitive.Ob
 		return http.StatusConflict, errors.New("transaksi sudah dibuat keputusan")
 	}
 
+	var (
+		proposal proposals.Domain
+		batch    batchs.Domain
+		newBatch batchs.Domain
+	)
+
 	if transaction.TransactionType == constant.TransactionTypeAnnuals {
-		proposal, commodity, statusCode, err := tu.CheckFarmerIDByProposalID(transaction.ProposalID, farmerID)
+		var (
+			commodity  commodities.Domain
+			statusCode int
+		)
+
+		proposal, commodity, statusCode, err = tu.policyUseCase.GetProposalOfFarmer(transaction.ProposalID, farmerID)
 		if err != nil {
 			return statusCode, err
 		}
 
 		if domain.Status == constant.TransactionStatusAccepted {
-			err = tu.transactionRepository.RejectPendingByProposalID(transaction.ProposalID)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal mengupdate transaksi")
+			if transaction.Quantity == 0 {
+				transaction.Quantity = proposal.EstimatedTotalHarvest
 			}
 
-			proposal.IsAvailable = false
-			proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
-
-			_, err = tu.proposalRepository.Update(&proposal)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal mengupdate proposal")
+			remaining := remainingQuantity(proposal.RemainingQuantity, proposal.IsQuantityTracked, proposal.EstimatedTotalHarvest)
+			if !proposal.IsAvailable || transaction.Quantity > remaining {
+				return http.StatusConflict, errors.New("jumlah melebihi sisa proposal")
 			}
 
-			lastBatch, err := tu.batchRepository.CountByProposalCode(proposal.Code)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal menghitung jumlah batch")
-			}
-
-			domain := &batchs.Domain{
-				ID:                   primitive.NewObjectID(),
-				ProposalID:           transaction.ProposalID,
-				Name:                 fmt.Sprintf("%s - %d", proposal.Name, lastBatch+1),
-				EstimatedHarvestDate: primitive.NewDateTimeFromTime(time.Now().AddDate(0, 0, commodity.PlantingPeriod)),
-				Status:               constant.BatchStatusPlanting,
-				CreatedAt:            primitive.NewDateTimeFromTime(time.Now()),
-			}
+			proposal.RemainingQuantity = remaining - transaction.Quantity
+			proposal.IsQuantityTracked = true
+			proposal.IsAvailable = proposal.RemainingQuantity > 0
+			proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-			_, err = tu.batchRepository.Create(domain)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal membuat batch")
+			// every buyer accepted in the same planting season shares one batch
+			plantingBatch, err := tu.batchRepository.GetPlantingByProposalID(proposal.ID)
+			if err == nil {
+				transaction.BatchID = plantingBatch.ID
+			} else if err == mongo.ErrNoDocuments {
+				lastBatch, err := tu.batchRepository.CountByProposalCode(proposal.Code)
+				if err != nil {
+					return http.StatusInternalServerError, errors.New("gagal menghitung jumlah batch")
+				}
+
+				newBatch = batchs.Domain{
+					ID:                   primitive.NewObjectID(),
+					ProposalID:           transaction.ProposalID,
+					Name:                 fmt.Sprintf("%s - %d", proposal.Name, lastBatch+1),
+					EstimatedHarvestDate: primitive.NewDateTimeFromTime(time.Now().AddDate(0, 0, commodity.PlantingPeriod)),
+					Status:               constant.BatchStatusPlanting,
+					CreatedAt:            primitive.NewDateTimeFromTime(time.Now()),
+				}
+
+				transaction.BatchID = newBatch.ID
+			} else {
+				return http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
 			}
-
-			transaction.BatchID = domain.ID
 		}
 	} else if transaction.TransactionType == constant.TransactionTypePerennials {
-		batch, err := tu.batchRepository.GetByID(transaction.BatchID)
+		batch, err = tu.batchRepository.GetByID(transaction.BatchID)
 		if err == mongo.ErrNoDocuments {
 			return http.StatusNotFound, errors.New("batch tidak ditemukan")
 		} else if err != nil {
 			return http.StatusInternalServerError, errors.New("batch tidak ditemukan")
 		}
 
-		_, _, statusCode, err := tu.CheckFarmerIDByProposalID(batch.ProposalID, farmerID)
+		proposalOfBatch, _, statusCode, err := tu.policyUseCase.GetProposalOfFarmer(batch.ProposalID, farmerID)
 		if err != nil {
 			return statusCode, err
 		}
 
 		if domain.Status == constant.TransactionStatusAccepted {
-			err = tu.transactionRepository.RejectPendingByBatchID(transaction.BatchID)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal mengupdate transaksi")
+			if transaction.Quantity == 0 {
+				transaction.Quantity = proposalOfBatch.EstimatedTotalHarvest
 			}
 
-			batch.IsAvailable = false
-			batch.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
-
-			_, err = tu.batchRepository.Update(&batch)
-			if err != nil {
-				return http.StatusInternalServerError, errors.New("gagal mengupdate batch")
+			remaining := remainingQuantity(batch.RemainingQuantity, batch.IsQuantityTracked, proposalOfBatch.EstimatedTotalHarvest)
+			if !batch.IsAvailable || transaction.Quantity > remaining {
+				return http.StatusConflict, errors.New("jumlah melebihi sisa batch")
 			}
+
+			batch.RemainingQuantity = remaining - transaction.Quantity
+			batch.IsQuantityTracked = true
+			batch.IsAvailable = batch.RemainingQuantity > 0
+			batch.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 		}
 	}
 
+	// a negotiated price replaces the listed price the transaction was created with
+	if domain.Status == constant.TransactionStatusAccepted && domain.PricePerKg > 0 {
+		transaction.PricePerKg = domain.PricePerKg
+		transaction.TotalPrice = transaction.PricePerKg * transaction.Quantity
+	}
+
+	auditEventList := []auditEvents.Domain{
+		auditEvents.NewEvent(constant.AuditEntityTransaction, transaction.ID, actorID, actorRole, transaction.Status, domain.Status, ""),
+	}
+
 	transaction.Status = domain.Status
 	transaction.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-	_, err = tu.transactionRepository.Update(&transaction)
-	if err != nil {
-		return http.StatusInternalServerError, errors.New("gagal mengupdate transaksi")
+	err = tu.unitOfWork.Execute(func(ctx context.Context) error {
+		transaction, proposal, batch := transaction, proposal, batch
+		auditEventList = auditEventList[:1]
+
+		// the decided transaction is updated first so rejecting the other pending transactions does not bump its version
+		_, err := tu.transactionRepository.Update(ctx, &transaction)
+		if helper.IsConflictError(err) {
+			return err
+		} else if err != nil {
+			return fmt.Errorf("gagal mengupdate transaksi: %w", err)
+		}
+
+		var rejectedList []Domain
+		if domain.Status == constant.TransactionStatusAccepted {
+
+			if transaction.TransactionType == constant.TransactionTypeAnnuals {
+				rejectedList, err = tu.transactionRepository.RejectPendingByProposalID(ctx, transaction.ProposalID, proposal.RemainingQuantity)
+				if err != nil {
+					return fmt.Errorf("gagal mengupdate transaksi: %w", err)
+				}
+
+				_, err = tu.proposalRepository.Update(ctx, &proposal)
+				if helper.IsConflictError(err) {
+					return err
+				} else if err != nil {
+					return fmt.Errorf("gagal mengupdate proposal: %w", err)
+				}
+
+				if newBatch.ID != primitive.NilObjectID {
+					_, err = tu.batchRepository.Create(ctx, &newBatch)
+					if err != nil {
+						return fmt.Errorf("gagal membuat batch: %w", err)
+					}
+
+					auditEventList = append(auditEventList, auditEvents.NewEvent(constant.AuditEntityBatch, newBatch.ID, actorID, actorRole, "", newBatch.Status, ""))
+				}
+			} else if transaction.TransactionType == constant.TransactionTypePerennials {
+				rejectedList, err = tu.transactionRepository.RejectPendingByBatchID(ctx, transaction.BatchID, batch.RemainingQuantity)
+				if err != nil {
+					return fmt.Errorf("gagal mengupdate transaksi: %w", err)
+				}
+
+				_, err = tu.batchRepository.Update(ctx, &batch)
+				if helper.IsConflictError(err) {
+					return err
+				} else if err != nil {
+					return fmt.Errorf("gagal mengupdate batch: %w", err)
+				}
+			}
+
+			for _, rejected := range rejectedList {
+				auditEventList = append(auditEventList, auditEvents.NewEvent(constant.AuditEntityTransaction, rejected.ID, actorID, actorRole, constant.TransactionStatusPending, constant.TransactionStatusRejected, "jumlah melebihi sisa setelah transaksi lain diterima"))
+			}
+		}
+
+		err = tu.auditEventRepository.CreateMany(ctx, auditEventList)
+		if err != nil {
+			return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+		}
+
+		if domain.Status == constant.TransactionStatusAccepted || domain.Status == constant.TransactionStatusRejected {
+			decision := "diterima oleh petani"
+			if domain.Status == constant.TransactionStatusRejected {
+				decision = "ditolak oleh petani"
+			} else if actorRole == constant.RoleBuyer {
+				decision = "diterima setelah anda menyetujui penawaran petani"
+			}
+
+			err := tu.notifyDecision(ctx, &transaction, decision)
+			if err != nil {
+				return err
+			}
+		}
+
+		for _, rejected := range rejectedList {
+			err := tu.notifyDecision(ctx, &rejected, "ditolak karena jumlahnya melebihi sisa setelah transaksi lain diterima")
+			if err != nil {
+				return err
+			}
+		}
+
+		if domain.Status == constant.TransactionStatusAccepted {
+			err = tu.webhookUseCase.Dispatch(ctx, constant.WebhookEventTransactionAccepted, webhookPayload(&transaction))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if helper.IsConflictError(err) {
+		return http.StatusConflict, errors.New("transaksi telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
+		return http.StatusInternalServerError, err
 	}
 
+	tu.publish(auditEventList, farmerID)
+
 	return http.StatusOK, nil
 }
 
@@ -383,17 +677,305 @@ func (tu *TransactionUseCase) CancelOnPending(id primitive.ObjectID, buyerID pri
 		return http.StatusConflict, errors.New("transaksi sudah dibuat keputusan")
 	}
 
+	auditEvent := auditEvents.NewEvent(constant.AuditEntityTransaction, transaction.ID, buyerID, constant.RoleBuyer, transaction.Status, constant.TransactionStatusCancel, "")
+
 	transaction.Status = constant.TransactionStatusCancel
 	transaction.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
-	_, err = tu.transactionRepository.Update(&transaction)
+	err = tu.unitOfWork.Execute(func(ctx context.Context) error {
+		transaction := transaction
+
+		_, err := tu.transactionRepository.Update(ctx, &transaction)
+		if helper.IsConflictError(err) {
+			return err
+		} else if err != nil {
+			return fmt.Errorf("gagal mengupdate transaksi: %w", err)
+		}
+
+		_, err = tu.auditEventRepository.Create(ctx, &auditEvent)
+		if err != nil {
+			return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+		}
+
+		return nil
+	})
+	if helper.IsConflictError(err) {
+		return http.StatusConflict, errors.New("transaksi telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	farmerID, _, err := tu.CheckNegotiator(&transaction, buyerID, constant.RoleBuyer)
+	if err == nil {
+		tu.publish([]auditEvents.Domain{auditEvent}, farmerID)
+	}
+
+	return http.StatusOK, nil
+}
+
+func (tu *TransactionUseCase) MakeOffer(id primitive.ObjectID, offer *Offer) (int, error) {
+	transaction, err := tu.transactionRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	_, statusCode, err := tu.CheckNegotiator(&transaction, offer.AuthorID, offer.Role)
 	if err != nil {
+		return statusCode, err
+	}
+
+	if transaction.Status != constant.TransactionStatusPending {
+		return http.StatusConflict, errors.New("transaksi sudah dibuat keputusan")
+	}
+
+	if len(transaction.Offers) > 0 && transaction.Offers[len(transaction.Offers)-1].AuthorID == offer.AuthorID {
+		return http.StatusConflict, errors.New("tunggu tanggapan atas penawaran sebelumnya")
+	}
+
+	offer.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+	transaction.Offers = append(transaction.Offers, *offer)
+	transaction.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = tu.transactionRepository.Update(context.Background(), &transaction)
+	if helper.IsConflictError(err) {
+		return http.StatusConflict, errors.New("transaksi telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
 		return http.StatusInternalServerError, errors.New("gagal mengupdate transaksi")
 	}
 
 	return http.StatusOK, nil
 }
 
+func (tu *TransactionUseCase) AcceptOffer(id primitive.ObjectID, userID primitive.ObjectID, role string) (int, error) {
+	transaction, err := tu.transactionRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("transaksi tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	farmerID, statusCode, err := tu.CheckNegotiator(&transaction, userID, role)
+	if err != nil {
+		return statusCode, err
+	}
+
+	if len(transaction.Offers) == 0 {
+		return http.StatusBadRequest, errors.New("belum ada penawaran")
+	}
+
+	lastOffer := transaction.Offers[len(transaction.Offers)-1]
+	if lastOffer.AuthorID == userID {
+		return http.StatusConflict, errors.New("penawaran sendiri tidak bisa diterima")
+	}
+
+	return tu.decide(&Domain{
+		ID:         transaction.ID,
+		Status:     constant.TransactionStatusAccepted,
+		PricePerKg: lastOffer.PricePerKg,
+	}, farmerID, userID, role)
+}
+
+// ExpirePending expires every pending transaction created before createdBefore and returns how many were expired.
+// A transaction decided by the farmer in the meantime fails the version check and is left as it is.
+func (tu *TransactionUseCase) ExpirePending(createdBefore primitive.DateTime) (int, int, error) {
+	transactions, err := tu.transactionRepository.GetPendingCreatedBefore(createdBefore)
+	if err != nil {
+		return 0, http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	totalExpired := 0
+	for _, transaction := range transactions {
+		auditEvent := auditEvents.NewEvent(constant.AuditEntityTransaction, transaction.ID, primitive.NilObjectID, constant.AuditActorSystem, transaction.Status, constant.TransactionStatusExpired, "tidak mendapat keputusan dari petani")
+
+		transaction.Status = constant.TransactionStatusExpired
+		transaction.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+		err := tu.unitOfWork.Execute(func(ctx context.Context) error {
+			transaction := transaction
+
+			_, err := tu.transactionRepository.Update(ctx, &transaction)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal mengupdate transaksi: %w", err)
+			}
+
+			_, err = tu.auditEventRepository.Create(ctx, &auditEvent)
+			if err != nil {
+				return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+			}
+
+			_, err = tu.notificationRepository.Create(ctx, &notifications.Domain{
+				ID:          primitive.NewObjectID(),
+				UserID:      transaction.BuyerID,
+				Type:        constant.NotificationTypeTransactionExpired,
+				Title:       "Transaksi kedaluwarsa",
+				Message:     "Transaksi anda kedaluwarsa karena tidak mendapat keputusan dari petani",
+				ReferenceID: transaction.ID,
+				CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+			})
+			if err != nil {
+				return fmt.Errorf("gagal membuat notifikasi: %w", err)
+			}
+
+			return nil
+		})
+		if helper.IsConflictError(err) {
+			continue
+		} else if err != nil {
+			return totalExpired, http.StatusInternalServerError, err
+		}
+
+		farmerID, _, err := tu.CheckNegotiator(&transaction, transaction.BuyerID, constant.RoleBuyer)
+		if err == nil {
+			tu.publish([]auditEvents.Domain{auditEvent}, farmerID)
+		}
+
+		totalExpired++
+	}
+
+	return totalExpired, http.StatusOK, nil
+}
+
+// CancelBatch lives here rather than in batchs because it settles every transaction of the batch.
+// Pending and accepted transactions are rejected, paid ones are refunded by a job and open treatment records are closed.
+func (tu *TransactionUseCase) CancelBatch(domain *batchs.Domain, farmerID primitive.ObjectID) (int, error) {
+	batch, proposal, _, statusCode, err := tu.policyUseCase.GetBatchOfFarmer(domain.ID, farmerID)
+	if err != nil {
+		return statusCode, err
+	}
+
+	if !batchs.CanTransition(batch.Status, constant.BatchStatusCancel) {
+		return http.StatusBadRequest, errors.New("batch tidak sedang dalam tahap tanam")
+	}
+
+	transactionList, err := tu.transactionRepository.GetAcceptedByBatchID(batch.ID)
+	if err != nil && err != mongo.ErrNoDocuments {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan transaksi")
+	}
+
+	reason := "batch dibatalkan: " + domain.CancelReason
+
+	auditEventList := []auditEvents.Domain{
+		auditEvents.NewEvent(constant.AuditEntityBatch, batch.ID, farmerID, constant.RoleFarmer, batch.Status, constant.BatchStatusCancel, domain.CancelReason),
+	}
+
+	batch.Status = constant.BatchStatusCancel
+	batch.CancelReason = domain.CancelReason
+	batch.IsAvailable = false
+	batch.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	// the season of the proposal starts over, the same as after a harvest
+	isProposalRestored := proposal.Status == constant.ProposalStatusApproved
+	if isProposalRestored {
+		proposal.IsAvailable = true
+		proposal.RemainingQuantity = proposal.EstimatedTotalHarvest
+		proposal.IsQuantityTracked = true
+		proposal.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+	}
+
+	err = tu.unitOfWork.Execute(func(ctx context.Context) error {
+		batch, proposal := batch, proposal
+		transactionList := append([]Domain{}, transactionList...)
+		auditEventList = auditEventList[:1]
+
+		_, err := tu.batchRepository.Update(ctx, &batch)
+		if helper.IsConflictError(err) {
+			return err
+		} else if err != nil {
+			return fmt.Errorf("gagal membatalkan batch: %w", err)
+		}
+
+		if isProposalRestored {
+			_, err = tu.proposalRepository.Update(ctx, &proposal)
+			if helper.IsConflictError(err) {
+				return err
+			} else if err != nil {
+				return fmt.Errorf("gagal mengupdate proposal: %w", err)
+			}
+		}
+
+		// a negative remaining quantity rejects every pending transaction of the batch
+		rejectedList, err := tu.transactionRepository.RejectPendingByBatchID(ctx, batch.ID, -1)
+		if err != nil {
+			return fmt.Errorf("gagal mengupdate transaksi: %w", err)
+		}
+
+		for _, rejected := range rejectedList {
+			auditEventList = append(auditEventList, auditEvents.NewEvent(constant.AuditEntityTransaction, rejected.ID, farmerID, constant.RoleFarmer, constant.TransactionStatusPending, constant.TransactionStatusRejected, reason))
+
+			err := tu.notifyDecision(ctx, &rejected, "ditolak karena batch dibatalkan oleh petani")
+			if err != nil {
+				return err
+			}
+		}
+
+		for i, transaction := range transactionList {
+			if transaction.Status == constant.TransactionStatusPaid {
+				err := tu.jobUseCase.Enqueue(ctx, constant.JobTypeRefundPayment, jobs.RefundPaymentPayload{
+					TransactionID: transaction.ID.Hex(),
+					Reason:        reason,
+				})
+				if err != nil {
+					return fmt.Errorf("gagal menjadwalkan pengembalian dana: %w", err)
+				}
+			} else {
+				auditEventList = append(auditEventList, auditEvents.NewEvent(constant.AuditEntityTransaction, transaction.ID, farmerID, constant.RoleFarmer, transaction.Status, constant.TransactionStatusRejected, reason))
+
+				transactionList[i].Status = constant.TransactionStatusRejected
+				transactionList[i].UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+				_, err := tu.transactionRepository.Update(ctx, &transactionList[i])
+				if helper.IsConflictError(err) {
+					return err
+				} else if err != nil {
+					return fmt.Errorf("gagal mengupdate transaksi: %w", err)
+				}
+			}
+
+			_, err := tu.notificationRepository.Create(ctx, &notifications.Domain{
+				ID:          primitive.NewObjectID(),
+				UserID:      transaction.BuyerID,
+				Type:        constant.NotificationTypeBatchCancelled,
+				Title:       "Batch dibatalkan",
+				Message:     fmt.Sprintf("Batch %s dibatalkan oleh petani, transaksi anda dibatalkan dan dana yang sudah dibayar akan dikembalikan", batch.Name),
+				ReferenceID: transaction.ID,
+				CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+			})
+			if err != nil {
+				return fmt.Errorf("gagal membuat notifikasi: %w", err)
+			}
+		}
+
+		closedTreatmentRecords, err := tu.treatmentRecordRepository.CloseOpenByBatchID(ctx, batch.ID)
+		if err != nil {
+			return fmt.Errorf("gagal menutup riwayat perawatan: %w", err)
+		}
+
+		for _, treatmentRecord := range closedTreatmentRecords {
+			auditEventList = append(auditEventList, auditEvents.NewEvent(constant.AuditEntityTreatmentRecord, treatmentRecord.ID, farmerID, constant.RoleFarmer, treatmentRecord.Status, constant.TreatmentRecordStatusClosed, reason))
+		}
+
+		err = tu.auditEventRepository.CreateMany(ctx, auditEventList)
+		if err != nil {
+			return fmt.Errorf("gagal mencatat riwayat status: %w", err)
+		}
+
+		return nil
+	})
+	if helper.IsConflictError(err) {
+		return http.StatusConflict, errors.New("batch telah diubah oleh pengguna lain, silakan coba lagi")
+	} else if err != nil {
+		return http.StatusInternalServerError, err
+	}
+
+	tu.publish(auditEventList, farmerID)
+
+	return http.StatusOK, nil
+}
+
 /*
 Delete
 */
@@ -0,0 +1,293 @@
+package transactions_test
+
+import (
+	"context"
+	"crop_connect/app/realtime"
+	"crop_connect/business/commodities"
+	"crop_connect/business/emails"
+	"crop_connect/business/jobs"
+	"crop_connect/business/policies"
+	"crop_connect/business/proposals"
+	"crop_connect/business/transactions"
+	"crop_connect/business/users"
+	"crop_connect/business/webhooks"
+	"crop_connect/constant"
+	"crop_connect/driver"
+	memoryDriver "crop_connect/driver/memory"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+func newUseCase(db *memoryDriver.Database, proposalRepository proposals.Repository) transactions.UseCase {
+	userRepository := driver.NewUserMemoryRepository(db)
+	commodityRepository := driver.NewCommodityMemoryRepository(db)
+	batchRepository := driver.NewBatchMemoryRepository(db)
+	unitOfWork := driver.NewUnitOfWorkMemory(db)
+	jobUseCase := jobs.NewUseCase(driver.NewJobMemoryRepository(db))
+
+	return transactions.NewUseCase(
+		driver.NewTransactionMemoryRepository(db),
+		batchRepository,
+		commodityRepository,
+		proposalRepository,
+		driver.NewTreatmentRecordMemoryRepository(db),
+		driver.NewNotificationMemoryRepository(db),
+		driver.NewAuditEventMemoryRepository(db),
+		realtime.NewHub(),
+		emails.NewUseCase(userRepository, jobUseCase),
+		jobUseCase,
+		webhooks.NewUseCase(driver.NewWebhookMemoryRepository(db), jobUseCase, nil, unitOfWork),
+		policies.NewUseCase(commodityRepository, proposalRepository, batchRepository),
+		unitOfWork,
+	)
+}
+
+// seedProposal saves a farmer, a buyer and an approved proposal of 100 kg on an annual commodity of the farmer.
+// The quantity fields of proposal are kept, the proposal with its id is returned along with the farmer and buyer ids.
+func seedProposal(db *memoryDriver.Database, proposal proposals.Domain) (proposals.Domain, primitive.ObjectID, primitive.ObjectID) {
+	farmerID := primitive.NewObjectID()
+	buyerID := primitive.NewObjectID()
+	commodityID := primitive.NewObjectID()
+
+	proposal.ID = primitive.NewObjectID()
+	proposal.Code = primitive.NewObjectID()
+	proposal.CommodityID = commodityID
+	proposal.Name = "proposal"
+	proposal.Status = constant.ProposalStatusApproved
+	proposal.EstimatedTotalHarvest = 100
+	proposal.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	db.Users = []users.Domain{
+		{ID: farmerID, Name: "petani", Email: "petani@example.com", Role: constant.RoleFarmer, Status: constant.UserStatusActive},
+		{ID: buyerID, Name: "pembeli", Email: "pembeli@example.com", Role: constant.RoleBuyer, Status: constant.UserStatusActive},
+	}
+	db.Commodities = []commodities.Domain{
+		{ID: commodityID, Code: primitive.NewObjectID(), FarmerID: farmerID, Name: "jagung", PlantingPeriod: 90, IsAvailable: true},
+	}
+	db.Proposals = []proposals.Domain{proposal}
+
+	return proposal, farmerID, buyerID
+}
+
+// seedPending saves one pending transaction per quantity, each bought at 1000 per kg.
+func seedPending(db *memoryDriver.Database, proposalID primitive.ObjectID, buyerID primitive.ObjectID, createdAt time.Time, quantities ...float64) []primitive.ObjectID {
+	var ids []primitive.ObjectID
+	for _, quantity := range quantities {
+		transaction := transactions.Domain{
+			ID:              primitive.NewObjectID(),
+			TransactionType: constant.TransactionTypeAnnuals,
+			ProposalID:      proposalID,
+			BuyerID:         buyerID,
+			Status:          constant.TransactionStatusPending,
+			Quantity:        quantity,
+			PricePerKg:      1000,
+			TotalPrice:      1000 * quantity,
+			CreatedAt:       primitive.NewDateTimeFromTime(createdAt),
+		}
+
+		db.Transactions = append(db.Transactions, transaction)
+		ids = append(ids, transaction.ID)
+	}
+
+	return ids
+}
+
+func statuses(db *memoryDriver.Database, ids []primitive.ObjectID) []string {
+	var statuses []string
+	for _, id := range ids {
+		for _, transaction := range db.Transactions {
+			if transaction.ID == id {
+				statuses = append(statuses, transaction.Status)
+			}
+		}
+	}
+
+	return statuses
+}
+
+func TestExpirePending(t *testing.T) {
+	now := time.Now()
+
+	tests := []struct {
+		name         string
+		createdAt    []time.Time
+		wantExpired  int
+		wantStatuses []string
+	}{
+		{
+			name:         "only pending transactions made before the cutoff expire",
+			createdAt:    []time.Time{now.AddDate(0, 0, -10), now.AddDate(0, 0, -1)},
+			wantExpired:  1,
+			wantStatuses: []string{constant.TransactionStatusExpired, constant.TransactionStatusPending},
+		},
+		{
+			name:         "nothing expires when every transaction is recent",
+			createdAt:    []time.Time{now, now.AddDate(0, 0, -2)},
+			wantExpired:  0,
+			wantStatuses: []string{constant.TransactionStatusPending, constant.TransactionStatusPending},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memoryDriver.Init()
+			useCase := newUseCase(db, driver.NewProposalMemoryRepository(db))
+			proposal, _, buyerID := seedProposal(db, proposals.Domain{RemainingQuantity: 100, IsQuantityTracked: true, IsAvailable: true})
+
+			var ids []primitive.ObjectID
+			for _, createdAt := range tt.createdAt {
+				ids = append(ids, seedPending(db, proposal.ID, buyerID, createdAt, 40)...)
+			}
+
+			totalExpired, _, err := useCase.ExpirePending(primitive.NewDateTimeFromTime(now.AddDate(0, 0, -7)))
+			if err != nil {
+				t.Fatalf("ExpirePending() error = %v", err)
+			}
+
+			if totalExpired != tt.wantExpired {
+				t.Errorf("expired = %d, want %d", totalExpired, tt.wantExpired)
+			}
+
+			if got := statuses(db, ids); !reflect.DeepEqual(got, tt.wantStatuses) {
+				t.Errorf("statuses = %v, want %v", got, tt.wantStatuses)
+			}
+
+			// an expired transaction never held any quantity
+			if saved, _ := db.FindProposal(proposal.ID); saved.RemainingQuantity != 100 {
+				t.Errorf("remaining = %v, want 100", saved.RemainingQuantity)
+			}
+		})
+	}
+}
+
+func TestMakeDecisionQuantity(t *testing.T) {
+	tests := []struct {
+		name           string
+		proposal       proposals.Domain
+		quantities     []float64
+		status         string
+		wantStatusCode int
+		wantRemaining  float64
+		wantAvailable  bool
+		wantStatuses   []string
+	}{
+		{
+			name:           "accepting part of the proposal rejects the pending transactions that no longer fit",
+			proposal:       proposals.Domain{RemainingQuantity: 100, IsQuantityTracked: true, IsAvailable: true},
+			quantities:     []float64{60, 50, 30},
+			status:         constant.TransactionStatusAccepted,
+			wantStatusCode: http.StatusOK,
+			wantRemaining:  40,
+			wantAvailable:  true,
+			wantStatuses:   []string{constant.TransactionStatusAccepted, constant.TransactionStatusRejected, constant.TransactionStatusPending},
+		},
+		{
+			name:           "accepting the whole remaining quantity sells the proposal out",
+			proposal:       proposals.Domain{RemainingQuantity: 100, IsQuantityTracked: true, IsAvailable: true},
+			quantities:     []float64{100, 10},
+			status:         constant.TransactionStatusAccepted,
+			wantStatusCode: http.StatusOK,
+			wantRemaining:  0,
+			wantAvailable:  false,
+			wantStatuses:   []string{constant.TransactionStatusAccepted, constant.TransactionStatusRejected},
+		},
+		{
+			name:           "a sold out proposal is not treated as untracked",
+			proposal:       proposals.Domain{RemainingQuantity: 0, IsQuantityTracked: true, IsAvailable: true},
+			quantities:     []float64{10},
+			status:         constant.TransactionStatusAccepted,
+			wantStatusCode: http.StatusConflict,
+			wantRemaining:  0,
+			wantAvailable:  true,
+			wantStatuses:   []string{constant.TransactionStatusPending},
+		},
+		{
+			name:           "an untracked proposal starts from the estimated harvest",
+			proposal:       proposals.Domain{IsAvailable: true},
+			quantities:     []float64{30},
+			status:         constant.TransactionStatusAccepted,
+			wantStatusCode: http.StatusOK,
+			wantRemaining:  70,
+			wantAvailable:  true,
+			wantStatuses:   []string{constant.TransactionStatusAccepted},
+		},
+		{
+			name:           "rejecting leaves the quantity as it is",
+			proposal:       proposals.Domain{RemainingQuantity: 100, IsQuantityTracked: true, IsAvailable: true},
+			quantities:     []float64{60},
+			status:         constant.TransactionStatusRejected,
+			wantStatusCode: http.StatusOK,
+			wantRemaining:  100,
+			wantAvailable:  true,
+			wantStatuses:   []string{constant.TransactionStatusRejected},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memoryDriver.Init()
+			useCase := newUseCase(db, driver.NewProposalMemoryRepository(db))
+			proposal, farmerID, buyerID := seedProposal(db, tt.proposal)
+			ids := seedPending(db, proposal.ID, buyerID, time.Now(), tt.quantities...)
+
+			statusCode, err := useCase.MakeDecision(&transactions.Domain{ID: ids[0], Status: tt.status}, farmerID)
+			if statusCode != tt.wantStatusCode {
+				t.Fatalf("status code = %d, want %d (err: %v)", statusCode, tt.wantStatusCode, err)
+			}
+
+			saved, _ := db.FindProposal(proposal.ID)
+			if saved.RemainingQuantity != tt.wantRemaining || saved.IsAvailable != tt.wantAvailable {
+				t.Errorf("remaining = %v available = %v, want %v and %v", saved.RemainingQuantity, saved.IsAvailable, tt.wantRemaining, tt.wantAvailable)
+			}
+
+			if got := statuses(db, ids); !reflect.DeepEqual(got, tt.wantStatuses) {
+				t.Errorf("statuses = %v, want %v", got, tt.wantStatuses)
+			}
+		})
+	}
+}
+
+// racingProposalRepository saves the proposal again right after it is read, the same as another request committing first.
+type racingProposalRepository struct {
+	proposals.Repository
+}
+
+func (r racingProposalRepository) GetByIDWithoutDeleted(id primitive.ObjectID) (proposals.Domain, error) {
+	proposal, err := r.Repository.GetByIDWithoutDeleted(id)
+	if err != nil {
+		return proposals.Domain{}, err
+	}
+
+	winner := proposal
+	if _, err := r.Repository.Update(context.Background(), &winner); err != nil {
+		return proposals.Domain{}, err
+	}
+
+	return proposal, nil
+}
+
+func TestMakeDecisionVersionConflict(t *testing.T) {
+	db := memoryDriver.Init()
+	useCase := newUseCase(db, racingProposalRepository{driver.NewProposalMemoryRepository(db)})
+	proposal, farmerID, buyerID := seedProposal(db, proposals.Domain{RemainingQuantity: 100, IsQuantityTracked: true, IsAvailable: true})
+	ids := seedPending(db, proposal.ID, buyerID, time.Now(), 60, 50)
+
+	statusCode, _ := useCase.MakeDecision(&transactions.Domain{ID: ids[0], Status: constant.TransactionStatusAccepted}, farmerID)
+	if statusCode != http.StatusConflict {
+		t.Fatalf("status code = %d, want %d", statusCode, http.StatusConflict)
+	}
+
+	// the unit of work is rolled back, so neither transaction nor the quantity changed
+	wantStatuses := []string{constant.TransactionStatusPending, constant.TransactionStatusPending}
+	if got := statuses(db, ids); !reflect.DeepEqual(got, wantStatuses) {
+		t.Errorf("statuses = %v, want %v", got, wantStatuses)
+	}
+
+	if saved, _ := db.FindProposal(proposal.ID); saved.RemainingQuantity != 100 || len(db.Batchs) != 0 {
+		t.Errorf("remaining = %v batchs = %d, want 100 and 0", saved.RemainingQuantity, len(db.Batchs))
+	}
+}
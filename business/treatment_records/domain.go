@@ -1,6 +1,7 @@
 package treatment_records
 
 import (
+	"context"
 	"crop_connect/dto"
 	"crop_connect/helper"
 	"mime/multipart"
@@ -51,6 +52,8 @@ type Repository interface {
 	StatisticByYear(year int) ([]dto.StatisticByYear, error)
 	// Update
 	Update(domain *Domain) (Domain, error)
+	// CloseOpenByBatchID closes every treatment record of a batch that is not approved yet and returns them as they were before closing.
+	CloseOpenByBatchID(ctx context.Context, batchID primitive.ObjectID) ([]Domain, error)
 	// Delete
 }
 
@@ -66,7 +69,7 @@ type UseCase interface {
 	FillTreatmentRecord(domain *Domain, farmerID primitive.ObjectID, images []*multipart.FileHeader, notes []string) (Domain, int, error)
 	UpdateTreatmentRecord(domain *Domain, farmerID primitive.ObjectID, updateImages []*helper.UpdateImage, notes []string) (Domain, int, error)
 	Validate(domain *Domain, validatorID primitive.ObjectID) (Domain, int, error)
-	UpdateNotes(domain *Domain) (Domain, int, error)
+	UpdateNotes(domain *Domain, validatorID primitive.ObjectID) (Domain, int, error)
 	CountByYear(year int) (int, int, error)
 	// Delete
 }
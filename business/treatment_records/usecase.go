@@ -1,8 +1,15 @@
 package treatment_records
 
 import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
 	"crop_connect/business/batchs"
 	"crop_connect/business/commodities"
+	"crop_connect/business/emails"
+	"crop_connect/business/events"
+	"crop_connect/business/jobs"
+	"crop_connect/business/notifications"
+	"crop_connect/business/policies"
 	"crop_connect/business/proposals"
 	"crop_connect/constant"
 	"crop_connect/dto"
@@ -10,8 +17,10 @@ import (
 	"crop_connect/helper/cloudinary"
 	"crop_connect/util"
 	"errors"
+	"fmt"
 	"mime/multipart"
 	"net/http"
+	"strconv"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -23,19 +32,43 @@ type TreatmentRecordUseCase struct {
 	batchRepository           batchs.Repository
 	proposalRepository        proposals.Repository
 	commodityRepository       commodities.Repository
+	notificationRepository    notifications.Repository
+	auditEventRepository      auditEvents.Repository
+	eventHub                  events.Hub
+	emailUseCase              emails.UseCase
+	jobUseCase                jobs.UseCase
+	policyUseCase             policies.UseCase
 	cloudinary                cloudinary.Function
 }
 
-func NewUseCase(trr Repository, br batchs.Repository, pr proposals.Repository, cr commodities.Repository, cldry cloudinary.Function) UseCase {
+func NewUseCase(trr Repository, br batchs.Repository, pr proposals.Repository, cr commodities.Repository, nr notifications.Repository, aer auditEvents.Repository, eh events.Hub, eu emails.UseCase, ju jobs.UseCase, pu policies.UseCase, cldry cloudinary.Function) UseCase {
 	return &TreatmentRecordUseCase{
 		treatmentRecordRepository: trr,
 		batchRepository:           br,
 		proposalRepository:        pr,
 		commodityRepository:       cr,
+		notificationRepository:    nr,
+		auditEventRepository:      aer,
+		eventHub:                  eh,
+		emailUseCase:              eu,
+		jobUseCase:                ju,
+		policyUseCase:             pu,
 		cloudinary:                cldry,
 	}
 }
 
+/*
+Util
+*/
+
+// recordTransition is best-effort since treatment records are not saved inside a unit of work, the transition is then pushed to the given users.
+func (tru *TreatmentRecordUseCase) recordTransition(treatmentRecordID primitive.ObjectID, actorID primitive.ObjectID, actorRole string, oldStatus string, newStatus string, reason string, userIDs ...primitive.ObjectID) {
+	auditEvent := auditEvents.NewEvent(constant.AuditEntityTreatmentRecord, treatmentRecordID, actorID, actorRole, oldStatus, newStatus, reason)
+	_, _ = tru.auditEventRepository.Create(context.Background(), &auditEvent)
+
+	tru.eventHub.Publish(events.FromAuditEvent(&auditEvent, userIDs...))
+}
+
 func (tru *TreatmentRecordUseCase) CheckFarmerID(id primitive.ObjectID, farmerID primitive.ObjectID) (Domain, batchs.Domain, proposals.Domain, commodities.Domain, int, error) {
 	treatmentRecord, err := tru.treatmentRecordRepository.GetByID(id)
 	if err == mongo.ErrNoDocuments {
@@ -44,29 +77,9 @@ func (tru *TreatmentRecordUseCase) CheckFarmerID(id primitive.ObjectID, farmerID
 		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan riwayat perawatan")
 	}
 
-	batch, err := tru.batchRepository.GetByID(treatmentRecord.BatchID)
-	if err == mongo.ErrNoDocuments {
-		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("batch tidak ditemukan")
-	} else if err != nil {
-		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
-	}
-
-	proposal, err := tru.proposalRepository.GetByIDWithoutDeleted(batch.ProposalID)
-	if err == mongo.ErrNoDocuments {
-		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
-	} else if err != nil {
-		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
-	}
-
-	commodity, err := tru.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
-	if err == mongo.ErrNoDocuments {
-		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusNotFound, errors.New("komoditas tidak ditemukan")
-	} else if err != nil {
-		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan komoditas")
-	}
-
-	if commodity.FarmerID != farmerID {
-		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, http.StatusForbidden, errors.New("riwayat perawatan tidak ditemukan")
+	batch, proposal, commodity, statusCode, err := tru.policyUseCase.GetBatchOfFarmer(treatmentRecord.BatchID, farmerID)
+	if err != nil {
+		return Domain{}, batchs.Domain{}, proposals.Domain{}, commodities.Domain{}, statusCode, err
 	}
 
 	return treatmentRecord, batch, proposal, commodity, http.StatusOK, nil
@@ -77,11 +90,9 @@ Create
 */
 
 func (tru *TreatmentRecordUseCase) RequestToFarmer(domain *Domain) (Domain, int, error) {
-	batch, err := tru.batchRepository.GetByID(domain.BatchID)
-	if err == mongo.ErrNoDocuments {
-		return Domain{}, http.StatusNotFound, errors.New("batch tidak ditemukan")
-	} else if err != nil {
-		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan batch")
+	batch, _, statusCode, err := tru.policyUseCase.GetBatchOfValidator(domain.BatchID, domain.RequesterID)
+	if err != nil {
+		return Domain{}, statusCode, err
 	}
 
 	if batch.Status != constant.BatchStatusPlanting {
@@ -90,6 +101,13 @@ func (tru *TreatmentRecordUseCase) RequestToFarmer(domain *Domain) (Domain, int,
 		return Domain{}, http.StatusBadRequest, errors.New("tanggal perawatan harus lebih besar dari tanggal tanam")
 	}
 
+	proposal, err := tru.proposalRepository.GetByIDWithoutDeleted(batch.ProposalID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("proposal tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan proposal")
+	}
+
 	count, err := tru.treatmentRecordRepository.CountByBatchID(domain.BatchID)
 	if err != nil {
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan jumlah riwayat perawatan")
@@ -120,6 +138,27 @@ func (tru *TreatmentRecordUseCase) RequestToFarmer(domain *Domain) (Domain, int,
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal membuat riwayat perawatan")
 	}
 
+	commodity, err := tru.commodityRepository.GetByIDWithoutDeleted(proposal.CommodityID)
+	tru.recordTransition(treatmentRecord.ID, domain.RequesterID, constant.RoleValidator, "", treatmentRecord.Status, "", domain.RequesterID, commodity.FarmerID)
+
+	if err == nil {
+		_, _ = tru.notificationRepository.Create(context.Background(), &notifications.Domain{
+			ID:          primitive.NewObjectID(),
+			UserID:      commodity.FarmerID,
+			Type:        constant.NotificationTypeTreatmentRecordRequest,
+			Title:       "Permintaan riwayat perawatan",
+			Message:     fmt.Sprintf("Validator meminta riwayat perawatan ke-%d untuk batch %s", treatmentRecord.Number, batch.Name),
+			ReferenceID: treatmentRecord.ID,
+			CreatedAt:   primitive.NewDateTimeFromTime(time.Now()),
+		})
+
+		_ = tru.emailUseCase.SendToUser(context.Background(), commodity.FarmerID, constant.MailgunTreatmentRecordRequestTemplate, map[string]string{
+			"number": strconv.Itoa(treatmentRecord.Number),
+			"batch":  batch.Name,
+			"date":   treatmentRecord.Date.Time().Format("02-01-2006"),
+		})
+	}
+
 	return treatmentRecord, http.StatusCreated, nil
 }
 
@@ -212,6 +251,8 @@ func (tru *TreatmentRecordUseCase) FillTreatmentRecord(domain *Domain, farmerID
 
 	if treatmentRecord.Status == constant.TreatmentRecordStatusApproved {
 		return Domain{}, http.StatusBadRequest, errors.New("riwayat perawatan sudah diterima")
+	} else if treatmentRecord.Status == constant.TreatmentRecordStatusClosed {
+		return Domain{}, http.StatusBadRequest, errors.New("riwayat perawatan sudah ditutup karena batch dibatalkan")
 	}
 
 	var imageURLs []string
@@ -232,18 +273,23 @@ func (tru *TreatmentRecordUseCase) FillTreatmentRecord(domain *Domain, farmerID
 		return Domain{}, http.StatusBadRequest, errors.New("gambar dan catatan tidak boleh kosong")
 	}
 
+	oldStatus := treatmentRecord.Status
 	treatmentRecord.Status = constant.TreatmentRecordStatusPending
 	treatmentRecord.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
 	treatmentRecord, err = tru.treatmentRecordRepository.Update(&treatmentRecord)
 	if err != nil {
-		err = tru.cloudinary.DeleteManyByURL(constant.CloudinaryFolderTreatmentRecords, imageURLs)
-		if err != nil {
-			return Domain{}, 0, err
-		}
+		_ = tru.jobUseCase.Enqueue(context.Background(), constant.JobTypeDeleteImages, jobs.DeleteImagesPayload{
+			Folder: constant.CloudinaryFolderTreatmentRecords,
+			URLs:   imageURLs,
+		})
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui riwayat perawatan")
 	}
 
+	if oldStatus != treatmentRecord.Status {
+		tru.recordTransition(treatmentRecord.ID, farmerID, constant.RoleFarmer, oldStatus, treatmentRecord.Status, "", farmerID, treatmentRecord.RequesterID)
+	}
+
 	return treatmentRecord, http.StatusOK, nil
 }
 
@@ -257,6 +303,8 @@ func (tru *TreatmentRecordUseCase) UpdateTreatmentRecord(domain *Domain, farmerI
 		return Domain{}, http.StatusBadRequest, errors.New("riwayat perawatan belum bisa diisi")
 	} else if treatmentRecord.Status == constant.TreatmentRecordStatusApproved {
 		return Domain{}, http.StatusBadRequest, errors.New("riwayat perawatan sudah diterima")
+	} else if treatmentRecord.Status == constant.TreatmentRecordStatusClosed {
+		return Domain{}, http.StatusBadRequest, errors.New("riwayat perawatan sudah ditutup karena batch dibatalkan")
 	}
 
 	if len(updateImages) > 0 && len(notes) > 0 {
@@ -283,6 +331,7 @@ func (tru *TreatmentRecordUseCase) UpdateTreatmentRecord(domain *Domain, farmerI
 		return Domain{}, http.StatusBadRequest, errors.New("gambar dan catatan tidak boleh kosong")
 	}
 
+	oldStatus := treatmentRecord.Status
 	treatmentRecord.Status = constant.TreatmentRecordStatusPending
 	treatmentRecord.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
 
@@ -291,6 +340,10 @@ func (tru *TreatmentRecordUseCase) UpdateTreatmentRecord(domain *Domain, farmerI
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui riwayat perawatan")
 	}
 
+	if oldStatus != treatmentRecord.Status {
+		tru.recordTransition(treatmentRecord.ID, farmerID, constant.RoleFarmer, oldStatus, treatmentRecord.Status, "", farmerID, treatmentRecord.RequesterID)
+	}
+
 	return treatmentRecord, http.StatusOK, nil
 }
 
@@ -311,6 +364,11 @@ func (tru *TreatmentRecordUseCase) Validate(domain *Domain, validatorID primitiv
 		return Domain{}, http.StatusBadRequest, errors.New("riwayat perawatan tidak dalam status menunggu validasi")
 	}
 
+	_, proposal, statusCode, err := tru.policyUseCase.GetBatchOfValidator(treatmentRecord.BatchID, validatorID)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
 	if domain.Status == constant.TreatmentRecordStatusRevision && domain.RevisionNote == "" {
 		return Domain{}, http.StatusBadRequest, errors.New("catatan revisi tidak boleh kosong")
 	}
@@ -331,10 +389,13 @@ func (tru *TreatmentRecordUseCase) Validate(domain *Domain, validatorID primitiv
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui riwayat perawatan")
 	}
 
+	commodity, _ := tru.commodityRepository.GetByID(proposal.CommodityID)
+	tru.recordTransition(treatmentRecord.ID, validatorID, constant.RoleValidator, constant.TreatmentRecordStatusPending, treatmentRecord.Status, treatmentRecord.RevisionNote, commodity.FarmerID, treatmentRecord.RequesterID, validatorID)
+
 	return treatmentRecord, http.StatusOK, nil
 }
 
-func (tru *TreatmentRecordUseCase) UpdateNotes(domain *Domain) (Domain, int, error) {
+func (tru *TreatmentRecordUseCase) UpdateNotes(domain *Domain, validatorID primitive.ObjectID) (Domain, int, error) {
 	treatmentRecord, err := tru.treatmentRecordRepository.GetByID(domain.ID)
 	if err == mongo.ErrNoDocuments {
 		return Domain{}, http.StatusNotFound, errors.New("riwayat perawatan tidak ditemukan")
@@ -342,6 +403,11 @@ func (tru *TreatmentRecordUseCase) UpdateNotes(domain *Domain) (Domain, int, err
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan riwayat perawatan")
 	}
 
+	_, _, statusCode, err := tru.policyUseCase.GetBatchOfValidator(treatmentRecord.BatchID, validatorID)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
 	if treatmentRecord.Status != constant.TreatmentRecordStatusRevision && domain.RevisionNote != "" {
 		return Domain{}, http.StatusBadRequest, errors.New("riwayat perawatan tidak dalam status revisi")
 	}
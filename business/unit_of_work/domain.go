@@ -0,0 +1,11 @@
+package unit_of_work
+
+import "context"
+
+type UnitOfWork interface {
+	// Execute runs fn as a single database transaction, every repository call that receives ctx
+	// is committed when fn returns nil and rolled back when fn returns an error.
+	// fn can run more than once when the transaction is retried, so it has to work on copies of the state it changes
+	// and wrap repository errors with %w to keep the labels the driver retries on.
+	Execute(fn func(ctx context.Context) error) error
+}
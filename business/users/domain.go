@@ -1,22 +1,51 @@
 package users
 
 import (
+	"context"
+	"crop_connect/business/sessions"
 	"crop_connect/dto"
+	"crop_connect/helper"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+// Area is a province, or one regency in it, that a validator reviews. An empty Regency covers the whole province.
+type Area struct {
+	Province string
+	Regency  string
+}
+
+// TwoFactor keeps the totp enrolment of an account, the secret is only trusted once EnabledAt is set after the first code is verified.
+// LastStep is the time step of the last accepted code so a code cannot be replayed, RecoveryCodes only holds hashes.
+type TwoFactor struct {
+	Secret        string
+	RecoveryCodes []string
+	LastStep      int64
+	Failures      int
+	LockedUntil   primitive.DateTime
+	EnabledAt     primitive.DateTime
+}
+
 type Domain struct {
-	ID          primitive.ObjectID
-	RegionID    primitive.ObjectID
-	Name        string
-	Email       string
-	Description string
-	PhoneNumber string
-	Password    string
-	Role        string
-	CreatedAt   primitive.DateTime
-	UpdatedAt   primitive.DateTime
+	ID              primitive.ObjectID
+	RegionID        primitive.ObjectID
+	Name            string
+	Email           string
+	Description     string
+	PhoneNumber     string
+	Password        string
+	Role            string
+	Areas           []Area
+	Status          string
+	SuspendReason   string
+	RatingAverage   float64
+	RatingCount     int
+	EmailVerifiedAt primitive.DateTime
+	SuspendedAt     primitive.DateTime
+	TwoFactor       TwoFactor
+	DeletedAt       primitive.DateTime
+	CreatedAt       primitive.DateTime
+	UpdatedAt       primitive.DateTime
 }
 
 type Query struct {
@@ -43,20 +72,29 @@ type Repository interface {
 	GetByNameAndRole(name string, role string) ([]Domain, error)
 	GetByQuery(query Query) ([]Domain, int, error)
 	GetFarmerByID(id primitive.ObjectID) (Domain, error)
+	GetActiveValidatorsByArea(province string, regency string) ([]Domain, error)
 	StatisticNewUserByYear(year int) ([]dto.StatisticByYear, error)
 	CountTotalValidatorByYear(year int) (int, error)
 	// Update
 	Update(domain *Domain) (Domain, error)
+	UpdateTwoFactorStep(id primitive.ObjectID, step int64) error
+	RemoveRecoveryCode(id primitive.ObjectID, hash string) error
+	UpdateTwoFactorFailures(id primitive.ObjectID, failures int, lockedUntil primitive.DateTime) error
+	AddRating(ctx context.Context, id primitive.ObjectID, score int) error
 	// Delete
 }
 
 type UseCase interface {
 	// Create
-	Register(domain *Domain) (string, int, error)
-	RegisterValidator(domain *Domain) (string, int, error)
+	Register(domain *Domain) (Domain, int, error)
+	RegisterValidator(domain *Domain) (sessions.TokenPair, int, error)
 	// Read
-	Login(domain *Domain) (string, int, error)
+	Login(domain *Domain) (sessions.TokenPair, string, int, error)
+	LoginTwoFactor(twoFactorToken string, code string) (sessions.TokenPair, int, error)
 	GetByID(id primitive.ObjectID) (Domain, int, error)
+	CheckActive(id primitive.ObjectID) (int, error)
+	GetActiveRole(id primitive.ObjectID) (string, int, error)
+	CheckTwoFactor(id primitive.ObjectID) (int, error)
 	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
 	GetFarmerByID(id primitive.ObjectID) (Domain, int, error)
 	StatisticNewUserByYear(year int) ([]dto.StatisticByYear, int, error)
@@ -64,5 +102,13 @@ type UseCase interface {
 	// Update
 	UpdateProfile(domain *Domain) (Domain, int, error)
 	UpdatePassword(domain *Domain, newPassword string) (Domain, int, error)
+	Suspend(id primitive.ObjectID, reason string) (Domain, int, error)
+	Reactivate(id primitive.ObjectID) (Domain, int, error)
+	UpdateAreas(id primitive.ObjectID, areas []Area) (Domain, int, error)
+	SetupTwoFactor(id primitive.ObjectID) (helper.TOTPKey, int, error)
+	EnableTwoFactor(id primitive.ObjectID, code string) ([]string, int, error)
+	DisableTwoFactor(id primitive.ObjectID, password string, code string) (int, error)
+	RegenerateRecoveryCodes(id primitive.ObjectID, code string) ([]string, int, error)
 	// Delete
+	Delete(id primitive.ObjectID, password string) (int, error)
 }
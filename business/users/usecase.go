@@ -2,12 +2,18 @@ package users
 
 import (
 	"crop_connect/business/regions"
+	"crop_connect/business/sessions"
 	"crop_connect/constant"
 	"crop_connect/dto"
 	"crop_connect/helper"
 	"crop_connect/util"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
+	"fmt"
 	"net/http"
+	"strings"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
@@ -15,33 +21,139 @@ import (
 	"golang.org/x/crypto/bcrypt"
 )
 
+const (
+	twoFactorTokenDuration = 5 * time.Minute
+	twoFactorMaxFailures   = 5
+	twoFactorLockDuration  = 15 * time.Minute
+	recoveryCodeCount      = 10
+)
+
 type UserUseCase struct {
 	userRepository   Repository
 	regionRepository regions.Repository
+	sessionUseCase   sessions.UseCase
 }
 
-func NewUseCase(ur Repository, rr regions.Repository) UseCase {
+func NewUseCase(ur Repository, rr regions.Repository, su sessions.UseCase) UseCase {
 	return &UserUseCase{
 		userRepository:   ur,
 		regionRepository: rr,
+		sessionUseCase:   su,
+	}
+}
+
+/*
+Util
+*/
+
+func checkStatus(user Domain) (int, error) {
+	switch user.Status {
+	case constant.UserStatusUnverified:
+		return http.StatusForbidden, errors.New("email belum diverifikasi")
+	case constant.UserStatusSuspended:
+		return http.StatusForbidden, errors.New("akun ditangguhkan")
+	case constant.UserStatusDeleted:
+		return http.StatusForbidden, errors.New("akun telah dihapus")
+	}
+
+	return http.StatusOK, nil
+}
+
+// IsTwoFactorRequired reports whether the role can only use its permissions after enrolling two factor authentication.
+func IsTwoFactorRequired(role string) bool {
+	return util.CheckStringOnArray([]string{constant.RoleAdmin, constant.RoleValidator}, role)
+}
+
+func hashRecoveryCode(code string) string {
+	hash := sha256.Sum256([]byte(strings.ToLower(strings.TrimSpace(code))))
+	return hex.EncodeToString(hash[:])
+}
+
+// generateRecoveryCodes returns the codes to show once next to the hashes to keep.
+func generateRecoveryCodes() ([]string, []string, error) {
+	codes := []string{}
+	hashes := []string{}
+	for i := 0; i < recoveryCodeCount; i++ {
+		secret := make([]byte, 5)
+		if _, err := rand.Read(secret); err != nil {
+			return nil, nil, err
+		}
+
+		code := hex.EncodeToString(secret)
+		code = code[:5] + "-" + code[5:]
+		codes = append(codes, code)
+		hashes = append(hashes, hashRecoveryCode(code))
+	}
+
+	return codes, hashes, nil
+}
+
+// verifyTwoFactor accepts a totp code of a step later than the last accepted one or, when allowed, an unused recovery code which is then spent.
+// The step and the recovery code are spent with a conditional update, so two requests with the same code cannot both pass.
+// Every failure is counted and the account is locked for a while after too many of them.
+func (uu *UserUseCase) verifyTwoFactor(user *Domain, code string, allowRecoveryCode bool) (int, error) {
+	now := time.Now()
+	if user.TwoFactor.LockedUntil.Time().After(now) {
+		return http.StatusTooManyRequests, errors.New("terlalu banyak percobaan kode, coba lagi nanti")
+	}
+
+	var err error
+	if step, ok := helper.ValidateTOTP(code, user.TwoFactor.Secret, now); ok && step > user.TwoFactor.LastStep {
+		err = uu.userRepository.UpdateTwoFactorStep(user.ID, step)
+		if err == nil {
+			user.TwoFactor.LastStep = step
+		}
+	} else if allowRecoveryCode {
+		hash := hashRecoveryCode(code)
+		err = uu.userRepository.RemoveRecoveryCode(user.ID, hash)
+		for i, recoveryCode := range user.TwoFactor.RecoveryCodes {
+			if err == nil && recoveryCode == hash {
+				user.TwoFactor.RecoveryCodes = append(user.TwoFactor.RecoveryCodes[:i:i], user.TwoFactor.RecoveryCodes[i+1:]...)
+				break
+			}
+		}
+	} else {
+		err = mongo.ErrNoDocuments
+	}
+
+	if err == nil {
+		user.TwoFactor.Failures = 0
+		user.TwoFactor.LockedUntil = 0
+		return http.StatusOK, nil
+	} else if err != mongo.ErrNoDocuments {
+		return http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	user.TwoFactor.Failures++
+	if user.TwoFactor.Failures >= twoFactorMaxFailures {
+		user.TwoFactor.Failures = 0
+		user.TwoFactor.LockedUntil = primitive.NewDateTimeFromTime(now.Add(twoFactorLockDuration))
+	}
+
+	err = uu.userRepository.UpdateTwoFactorFailures(user.ID, user.TwoFactor.Failures, user.TwoFactor.LockedUntil)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengupdate user")
 	}
+
+	return http.StatusUnauthorized, errors.New("kode autentikasi dua faktor salah")
 }
 
 /*
 Create
 */
 
-func (uu *UserUseCase) Register(domain *Domain) (string, int, error) {
+// Register creates an unverified account, the user can only login after verifying the email.
+func (uu *UserUseCase) Register(domain *Domain) (Domain, int, error) {
 	isRoleAvailable := util.CheckStringOnArray([]string{constant.RoleBuyer, constant.RoleFarmer}, domain.Role)
 	if !isRoleAvailable {
-		return "", http.StatusBadRequest, errors.New("role tersedia hanya buyer dan farmer")
+		return Domain{}, http.StatusBadRequest, errors.New("role tersedia hanya buyer dan farmer")
 	}
 
 	_, err := uu.regionRepository.GetByID(domain.RegionID)
 	if err == mongo.ErrNoDocuments {
-		return "", http.StatusNotFound, errors.New("daerah tidak ditemukan")
+		return Domain{}, http.StatusNotFound, errors.New("daerah tidak ditemukan")
 	} else if err != nil {
-		return "", http.StatusInternalServerError, errors.New("gagal mengambil data proposal")
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengambil data proposal")
 	}
 
 	_, err = uu.userRepository.GetByEmail(domain.Email)
@@ -49,26 +161,26 @@ func (uu *UserUseCase) Register(domain *Domain) (string, int, error) {
 		encryptedPassword, _ := bcrypt.GenerateFromPassword([]byte(domain.Password), bcrypt.DefaultCost)
 		domain.ID = primitive.NewObjectID()
 		domain.Password = string(encryptedPassword)
+		domain.Status = constant.UserStatusUnverified
 		domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 
 		user, err := uu.userRepository.Create(domain)
 		if err != nil {
-			return "", http.StatusInternalServerError, errors.New("gagal melakuakn registrasi user")
+			return Domain{}, http.StatusInternalServerError, errors.New("gagal melakuakn registrasi user")
 		}
 
-		token := helper.GenerateToken(user.ID.Hex(), user.Role)
-		return token, http.StatusCreated, nil
+		return user, http.StatusCreated, nil
 	} else {
-		return "", http.StatusConflict, errors.New("email telah terdaftar")
+		return Domain{}, http.StatusConflict, errors.New("email telah terdaftar")
 	}
 }
 
-func (uu *UserUseCase) RegisterValidator(domain *Domain) (string, int, error) {
+func (uu *UserUseCase) RegisterValidator(domain *Domain) (sessions.TokenPair, int, error) {
 	_, err := uu.regionRepository.GetByID(domain.RegionID)
 	if err == mongo.ErrNoDocuments {
-		return "", http.StatusNotFound, errors.New("daerah tidak ditemukan")
+		return sessions.TokenPair{}, http.StatusNotFound, errors.New("daerah tidak ditemukan")
 	} else if err != nil {
-		return "", http.StatusInternalServerError, errors.New("gagal mengambil data proposal")
+		return sessions.TokenPair{}, http.StatusInternalServerError, errors.New("gagal mengambil data proposal")
 	}
 
 	_, err = uu.userRepository.GetByEmail(domain.Email)
@@ -77,17 +189,22 @@ func (uu *UserUseCase) RegisterValidator(domain *Domain) (string, int, error) {
 		domain.ID = primitive.NewObjectID()
 		domain.Password = string(encryptedPassword)
 		domain.Role = constant.RoleValidator
+		domain.Status = constant.UserStatusActive
 		domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
 
 		user, err := uu.userRepository.Create(domain)
 		if err != nil {
-			return "", http.StatusInternalServerError, err
+			return sessions.TokenPair{}, http.StatusInternalServerError, err
+		}
+
+		tokenPair, statusCode, err := uu.sessionUseCase.Create(user.ID, user.Role)
+		if err != nil {
+			return sessions.TokenPair{}, statusCode, err
 		}
 
-		token := helper.GenerateToken(user.ID.Hex(), user.Role)
-		return token, http.StatusCreated, nil
+		return tokenPair, http.StatusCreated, nil
 	} else {
-		return "", http.StatusConflict, errors.New("email telah terdaftar")
+		return sessions.TokenPair{}, http.StatusConflict, errors.New("email telah terdaftar")
 	}
 }
 
@@ -95,21 +212,84 @@ func (uu *UserUseCase) RegisterValidator(domain *Domain) (string, int, error) {
 Read
 */
 
-func (uu *UserUseCase) Login(domain *Domain) (string, int, error) {
+// Login only issues the tokens right away for an account without two factor authentication.
+// Otherwise it returns a short lived two factor token that has to be exchanged with a code through LoginTwoFactor.
+func (uu *UserUseCase) Login(domain *Domain) (sessions.TokenPair, string, int, error) {
 	user, err := uu.userRepository.GetByEmail(domain.Email)
 	if err == mongo.ErrNoDocuments {
-		return "", http.StatusNotFound, errors.New("email tidak terdaftar")
+		return sessions.TokenPair{}, "", http.StatusNotFound, errors.New("email tidak terdaftar")
 	} else if err != nil {
-		return "", http.StatusInternalServerError, errors.New("gagal mengambil data proposal")
+		return sessions.TokenPair{}, "", http.StatusInternalServerError, errors.New("gagal mengambil data proposal")
 	}
 
 	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(domain.Password))
 	if err != nil {
-		return "", http.StatusUnauthorized, errors.New("password salah")
+		return sessions.TokenPair{}, "", http.StatusUnauthorized, errors.New("password salah")
+	}
+
+	statusCode, err := checkStatus(user)
+	if err != nil {
+		return sessions.TokenPair{}, "", statusCode, err
+	}
+
+	if user.TwoFactor.EnabledAt != 0 {
+		twoFactorToken := helper.GenerateToken(user.ID.Hex(), user.Role, "", primitive.NewObjectID().Hex(), constant.TokenTypeTwoFactor, time.Now().Add(twoFactorTokenDuration))
+		return sessions.TokenPair{}, twoFactorToken, http.StatusOK, nil
+	}
+
+	tokenPair, statusCode, err := uu.sessionUseCase.Create(user.ID, user.Role)
+	if err != nil {
+		return sessions.TokenPair{}, "", statusCode, err
+	}
+
+	return tokenPair, "", http.StatusOK, nil
+}
+
+// LoginTwoFactor accepts a totp code or a recovery code for the two factor token given by Login.
+// The two factor token is consumed before the code is checked, so it cannot be used for a second attempt or a second session.
+func (uu *UserUseCase) LoginTwoFactor(twoFactorToken string, code string) (sessions.TokenPair, int, error) {
+	claims, err := helper.GetPayloadToken(twoFactorToken)
+	if err != nil || claims.Type != constant.TokenTypeTwoFactor {
+		return sessions.TokenPair{}, http.StatusUnauthorized, errors.New("token dua faktor tidak valid")
+	}
+
+	userID, err := primitive.ObjectIDFromHex(claims.UID)
+	if err != nil {
+		return sessions.TokenPair{}, http.StatusUnauthorized, errors.New("token dua faktor tidak valid")
+	}
+
+	statusCode, err := uu.sessionUseCase.Consume(claims.ID, userID, primitive.NewDateTimeFromTime(claims.ExpiresAt.Time))
+	if err != nil {
+		return sessions.TokenPair{}, statusCode, err
+	}
+
+	user, err := uu.userRepository.GetByID(userID)
+	if err == mongo.ErrNoDocuments {
+		return sessions.TokenPair{}, http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return sessions.TokenPair{}, http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	statusCode, err = checkStatus(user)
+	if err != nil {
+		return sessions.TokenPair{}, statusCode, err
+	}
+
+	if user.TwoFactor.EnabledAt == 0 {
+		return sessions.TokenPair{}, http.StatusConflict, errors.New("autentikasi dua faktor belum diaktifkan")
+	}
+
+	statusCode, err = uu.verifyTwoFactor(&user, code, true)
+	if err != nil {
+		return sessions.TokenPair{}, statusCode, err
 	}
 
-	token := helper.GenerateToken(user.ID.Hex(), user.Role)
-	return token, http.StatusOK, nil
+	tokenPair, statusCode, err := uu.sessionUseCase.Create(user.ID, user.Role)
+	if err != nil {
+		return sessions.TokenPair{}, statusCode, err
+	}
+
+	return tokenPair, http.StatusOK, nil
 }
 
 func (uu *UserUseCase) GetByID(id primitive.ObjectID) (Domain, int, error) {
@@ -121,6 +301,50 @@ func (uu *UserUseCase) GetByID(id primitive.ObjectID) (Domain, int, error) {
 	return user, http.StatusOK, nil
 }
 
+func (uu *UserUseCase) CheckActive(id primitive.ObjectID) (int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	return checkStatus(user)
+}
+
+// GetActiveRole reads the role of an account that can still sign in, a refreshed session takes it instead of the role in the old token.
+func (uu *UserUseCase) GetActiveRole(id primitive.ObjectID) (string, int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return "", http.StatusUnauthorized, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return "", http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	statusCode, err := checkStatus(user)
+	if err != nil {
+		return "", statusCode, err
+	}
+
+	return user.Role, http.StatusOK, nil
+}
+
+// CheckTwoFactor rejects an account of a role that has to enrol two factor authentication but has not done so yet.
+func (uu *UserUseCase) CheckTwoFactor(id primitive.ObjectID) (int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if IsTwoFactorRequired(user.Role) && user.TwoFactor.EnabledAt == 0 {
+		return http.StatusForbidden, errors.New("autentikasi dua faktor wajib diaktifkan")
+	}
+
+	return http.StatusOK, nil
+}
+
 func (uu *UserUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
 	users, totalData, err := uu.userRepository.GetByQuery(query)
 	if err != nil {
@@ -226,9 +450,294 @@ func (uu *UserUseCase) UpdatePassword(domain *Domain, newPassword string) (Domai
 		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengupdate user")
 	}
 
+	statusCode, err := uu.sessionUseCase.RevokeAllByUserID(user.ID)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	return user, http.StatusOK, nil
+}
+
+// Suspend blocks an active account and ends all of its sessions right away.
+func (uu *UserUseCase) Suspend(id primitive.ObjectID, reason string) (Domain, int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if user.Role == constant.RoleAdmin {
+		return Domain{}, http.StatusForbidden, errors.New("admin tidak dapat ditangguhkan")
+	}
+
+	if user.Status != constant.UserStatusActive {
+		return Domain{}, http.StatusConflict, errors.New("hanya akun aktif yang dapat ditangguhkan")
+	}
+
+	user.Status = constant.UserStatusSuspended
+	user.SuspendReason = reason
+	user.SuspendedAt = primitive.NewDateTimeFromTime(time.Now())
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	user, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	statusCode, err := uu.sessionUseCase.RevokeAllByUserID(user.ID)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
 	return user, http.StatusOK, nil
 }
 
+func (uu *UserUseCase) Reactivate(id primitive.ObjectID) (Domain, int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if user.Status != constant.UserStatusSuspended {
+		return Domain{}, http.StatusConflict, errors.New("akun tidak sedang ditangguhkan")
+	}
+
+	user.Status = constant.UserStatusActive
+	user.SuspendReason = ""
+	user.SuspendedAt = 0
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	user, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	return user, http.StatusOK, nil
+}
+
+// UpdateAreas replaces the areas of a validator, every area has to be a province or regency that is known as a region.
+func (uu *UserUseCase) UpdateAreas(id primitive.ObjectID, areas []Area) (Domain, int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if user.Role != constant.RoleValidator {
+		return Domain{}, http.StatusBadRequest, errors.New("wilayah hanya dapat diatur untuk validator")
+	}
+
+	for _, area := range areas {
+		regionList, err := uu.regionRepository.GetByQuery(regions.Query{
+			Province: area.Province,
+			Regency:  area.Regency,
+		})
+		if err != nil {
+			return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan daerah")
+		}
+
+		if len(regionList) == 0 {
+			return Domain{}, http.StatusNotFound, errors.New("daerah tidak ditemukan")
+		}
+	}
+
+	user.Areas = areas
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	user, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	return user, http.StatusOK, nil
+}
+
+// SetupTwoFactor starts an enrolment with a new secret, calling it again before EnableTwoFactor replaces the secret.
+func (uu *UserUseCase) SetupTwoFactor(id primitive.ObjectID) (helper.TOTPKey, int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return helper.TOTPKey{}, http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return helper.TOTPKey{}, http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if user.TwoFactor.EnabledAt != 0 {
+		return helper.TOTPKey{}, http.StatusConflict, errors.New("autentikasi dua faktor telah diaktifkan")
+	}
+
+	key, err := helper.GenerateTOTPKey(user.Email)
+	if err != nil {
+		return helper.TOTPKey{}, http.StatusInternalServerError, errors.New("gagal membuat kunci autentikasi dua faktor")
+	}
+
+	user.TwoFactor = TwoFactor{Secret: key.Secret}
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return helper.TOTPKey{}, http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	return key, http.StatusOK, nil
+}
+
+// EnableTwoFactor confirms the secret of SetupTwoFactor with a code from the authenticator app and returns the recovery codes, they are only shown this once.
+func (uu *UserUseCase) EnableTwoFactor(id primitive.ObjectID, code string) ([]string, int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return nil, http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if user.TwoFactor.EnabledAt != 0 {
+		return nil, http.StatusConflict, errors.New("autentikasi dua faktor telah diaktifkan")
+	}
+
+	if user.TwoFactor.Secret == "" {
+		return nil, http.StatusConflict, errors.New("autentikasi dua faktor belum disiapkan")
+	}
+
+	statusCode, err := uu.verifyTwoFactor(&user, code, false)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("gagal membuat kode pemulihan")
+	}
+
+	user.TwoFactor.RecoveryCodes = hashes
+	user.TwoFactor.EnabledAt = primitive.NewDateTimeFromTime(time.Now())
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	return codes, http.StatusOK, nil
+}
+
+func (uu *UserUseCase) DisableTwoFactor(id primitive.ObjectID, password string, code string) (int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if IsTwoFactorRequired(user.Role) {
+		return http.StatusForbidden, errors.New("autentikasi dua faktor wajib untuk admin dan validator")
+	}
+
+	if user.TwoFactor.EnabledAt == 0 {
+		return http.StatusConflict, errors.New("autentikasi dua faktor belum diaktifkan")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err != nil {
+		return http.StatusUnauthorized, errors.New("password salah")
+	}
+
+	statusCode, err := uu.verifyTwoFactor(&user, code, true)
+	if err != nil {
+		return statusCode, err
+	}
+
+	user.TwoFactor = TwoFactor{}
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	// sessions opened with the second factor should not outlive it
+	return uu.sessionUseCase.RevokeAllByUserID(user.ID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code, the old ones stop working right away.
+func (uu *UserUseCase) RegenerateRecoveryCodes(id primitive.ObjectID, code string) ([]string, int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return nil, http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if user.TwoFactor.EnabledAt == 0 {
+		return nil, http.StatusConflict, errors.New("autentikasi dua faktor belum diaktifkan")
+	}
+
+	statusCode, err := uu.verifyTwoFactor(&user, code, false)
+	if err != nil {
+		return nil, statusCode, err
+	}
+
+	codes, hashes, err := generateRecoveryCodes()
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("gagal membuat kode pemulihan")
+	}
+
+	user.TwoFactor.RecoveryCodes = hashes
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return nil, http.StatusInternalServerError, errors.New("gagal mengupdate user")
+	}
+
+	return codes, http.StatusOK, nil
+}
+
 /*
 Delete
 */
+
+// Delete anonymises the account instead of removing it, so the transactions and batchs that point to the user stay intact.
+func (uu *UserUseCase) Delete(id primitive.ObjectID, password string) (int, error) {
+	user, err := uu.userRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("user tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mengambil data pengguna")
+	}
+
+	if user.Role == constant.RoleAdmin {
+		return http.StatusForbidden, errors.New("akun admin tidak dapat dihapus")
+	}
+
+	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
+	if err != nil {
+		return http.StatusUnauthorized, errors.New("password salah")
+	}
+
+	user.Name = "Pengguna Terhapus"
+	user.Email = fmt.Sprintf("deleted-%s@crop-connect.invalid", user.ID.Hex())
+	user.Description = ""
+	user.PhoneNumber = ""
+	user.Password = ""
+	user.Status = constant.UserStatusDeleted
+	user.SuspendReason = ""
+	user.TwoFactor = TwoFactor{}
+	user.DeletedAt = primitive.NewDateTimeFromTime(time.Now())
+	user.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = uu.userRepository.Update(&user)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal menghapus user")
+	}
+
+	statusCode, err := uu.sessionUseCase.RevokeAllByUserID(user.ID)
+	if err != nil {
+		return statusCode, err
+	}
+
+	return http.StatusOK, nil
+}
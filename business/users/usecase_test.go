@@ -0,0 +1,247 @@
+package users_test
+
+import (
+	"crop_connect/business/sessions"
+	"crop_connect/business/users"
+	"crop_connect/constant"
+	"crop_connect/driver"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/helper"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"golang.org/x/crypto/bcrypt"
+)
+
+const (
+	email    = "pembeli@example.com"
+	password = "rahasia123"
+)
+
+func newUseCase(db *memoryDriver.Database) users.UseCase {
+	helper.JWTSecretKey = "secret"
+
+	sessionUseCase := sessions.NewUseCase(driver.NewSessionMemoryRepository(db), driver.NewRevokedTokenMemoryRepository(db), driver.NewUnitOfWorkMemory(db))
+	return users.NewUseCase(driver.NewUserMemoryRepository(db), driver.NewRegionMemoryRepository(db), sessionUseCase)
+}
+
+// enrolBuyer saves a buyer and enables two factor authentication with the code of now, it returns the buyer id, the secret and the recovery codes.
+func enrolBuyer(t *testing.T, db *memoryDriver.Database, useCase users.UseCase, now time.Time) (primitive.ObjectID, string, []string) {
+	t.Helper()
+
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("bcrypt: %v", err)
+	}
+
+	buyerID := primitive.NewObjectID()
+	db.Users = []users.Domain{
+		{ID: buyerID, Name: "pembeli", Email: email, Password: string(hash), Role: constant.RoleBuyer, Status: constant.UserStatusActive},
+	}
+
+	key, _, err := useCase.SetupTwoFactor(buyerID)
+	if err != nil {
+		t.Fatalf("SetupTwoFactor() error = %v", err)
+	}
+
+	recoveryCodes, _, err := useCase.EnableTwoFactor(buyerID, generateCode(t, key.Secret, now))
+	if err != nil {
+		t.Fatalf("EnableTwoFactor() error = %v", err)
+	}
+
+	return buyerID, key.Secret, recoveryCodes
+}
+
+func generateCode(t *testing.T, secret string, at time.Time) string {
+	t.Helper()
+
+	code, err := totp.GenerateCode(secret, at)
+	if err != nil {
+		t.Fatalf("GenerateCode() error = %v", err)
+	}
+
+	return code
+}
+
+func login(t *testing.T, useCase users.UseCase) string {
+	t.Helper()
+
+	_, twoFactorToken, _, err := useCase.Login(&users.Domain{Email: email, Password: password})
+	if err != nil || twoFactorToken == "" {
+		t.Fatalf("Login() = %q, %v, want a two factor token", twoFactorToken, err)
+	}
+
+	return twoFactorToken
+}
+
+func TestLoginTwoFactor(t *testing.T) {
+	const (
+		nextCode = iota
+		enrolmentCode
+		recoveryCode
+	)
+
+	type attempt struct {
+		reuseToken     bool
+		code           int
+		wantStatusCode int
+	}
+
+	tests := []struct {
+		name     string
+		attempts []attempt
+	}{
+		{
+			name:     "a code of a later step logs in",
+			attempts: []attempt{{code: nextCode, wantStatusCode: http.StatusOK}},
+		},
+		{
+			name:     "the code that enabled two factor cannot be replayed",
+			attempts: []attempt{{code: enrolmentCode, wantStatusCode: http.StatusUnauthorized}},
+		},
+		{
+			name: "a code that logged in cannot be replayed",
+			attempts: []attempt{
+				{code: nextCode, wantStatusCode: http.StatusOK},
+				{code: nextCode, wantStatusCode: http.StatusUnauthorized},
+			},
+		},
+		{
+			name: "the two factor token is spent by the first login",
+			attempts: []attempt{
+				{code: nextCode, wantStatusCode: http.StatusOK},
+				{reuseToken: true, code: recoveryCode, wantStatusCode: http.StatusUnauthorized},
+			},
+		},
+		{
+			name: "the two factor token is spent by a wrong code as well",
+			attempts: []attempt{
+				{code: enrolmentCode, wantStatusCode: http.StatusUnauthorized},
+				{reuseToken: true, code: nextCode, wantStatusCode: http.StatusUnauthorized},
+			},
+		},
+		{
+			name: "a recovery code only works once",
+			attempts: []attempt{
+				{code: recoveryCode, wantStatusCode: http.StatusOK},
+				{code: recoveryCode, wantStatusCode: http.StatusUnauthorized},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memoryDriver.Init()
+			useCase := newUseCase(db)
+
+			now := time.Now()
+			_, secret, recoveryCodes := enrolBuyer(t, db, useCase, now)
+
+			// one step ahead is still accepted for clock drift
+			codes := map[int]string{
+				nextCode:      generateCode(t, secret, now.Add(30*time.Second)),
+				enrolmentCode: generateCode(t, secret, now),
+				recoveryCode:  recoveryCodes[0],
+			}
+
+			var twoFactorToken string
+			for i, attempt := range tt.attempts {
+				if !attempt.reuseToken {
+					twoFactorToken = login(t, useCase)
+				}
+
+				_, statusCode, err := useCase.LoginTwoFactor(twoFactorToken, codes[attempt.code])
+				if statusCode != attempt.wantStatusCode {
+					t.Fatalf("attempt %d status code = %d, want %d (err: %v)", i, statusCode, attempt.wantStatusCode, err)
+				}
+			}
+		})
+	}
+}
+
+func TestLoginTwoFactorConcurrently(t *testing.T) {
+	tests := []struct {
+		name string
+		code func(t *testing.T, secret string, recoveryCodes []string, now time.Time) string
+	}{
+		{
+			name: "a totp code logs in once",
+			code: func(t *testing.T, secret string, recoveryCodes []string, now time.Time) string {
+				return generateCode(t, secret, now.Add(30*time.Second))
+			},
+		},
+		{
+			name: "a recovery code logs in once",
+			code: func(t *testing.T, secret string, recoveryCodes []string, now time.Time) string {
+				return recoveryCodes[0]
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := memoryDriver.Init()
+			useCase := newUseCase(db)
+
+			now := time.Now()
+			_, secret, recoveryCodes := enrolBuyer(t, db, useCase, now)
+			code := tt.code(t, secret, recoveryCodes, now)
+
+			// fewer attempts than the failures that lock the account
+			twoFactorTokens := make([]string, 4)
+			for i := range twoFactorTokens {
+				twoFactorTokens[i] = login(t, useCase)
+			}
+
+			var (
+				wg        sync.WaitGroup
+				mu        sync.Mutex
+				succeeded int
+			)
+
+			for _, twoFactorToken := range twoFactorTokens {
+				wg.Add(1)
+				go func(twoFactorToken string) {
+					defer wg.Done()
+
+					if _, _, err := useCase.LoginTwoFactor(twoFactorToken, code); err == nil {
+						mu.Lock()
+						succeeded++
+						mu.Unlock()
+					}
+				}(twoFactorToken)
+			}
+			wg.Wait()
+
+			if succeeded != 1 {
+				t.Errorf("successful logins = %d, want 1", succeeded)
+			}
+		})
+	}
+}
+
+func TestDisableTwoFactorRevokesSessions(t *testing.T) {
+	db := memoryDriver.Init()
+	useCase := newUseCase(db)
+
+	now := time.Now()
+	buyerID, secret, recoveryCodes := enrolBuyer(t, db, useCase, now)
+
+	if _, _, err := useCase.LoginTwoFactor(login(t, useCase), generateCode(t, secret, now.Add(30*time.Second))); err != nil {
+		t.Fatalf("LoginTwoFactor() error = %v", err)
+	}
+
+	if _, err := useCase.DisableTwoFactor(buyerID, password, recoveryCodes[0]); err != nil {
+		t.Fatalf("DisableTwoFactor() error = %v", err)
+	}
+
+	for _, session := range db.Sessions {
+		if session.RevokedAt == 0 {
+			t.Errorf("session %s is still active", session.ID.Hex())
+		}
+	}
+}
@@ -0,0 +1,131 @@
+package webhooks
+
+import (
+	"context"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Domain is an endpoint of a partner, only the events it subscribes to are delivered and every delivery is signed with its secret.
+type Domain struct {
+	ID          primitive.ObjectID
+	URL         string
+	Secret      string
+	Events      []string
+	Description string
+	IsActive    bool
+	CreatedAt   primitive.DateTime
+	UpdatedAt   primitive.DateTime
+}
+
+// Delivery is one event sent to one webhook. EventID stays the same when the delivery is replayed, so the partner can ignore an event it already handled.
+type Delivery struct {
+	ID             primitive.ObjectID
+	WebhookID      primitive.ObjectID
+	EventID        primitive.ObjectID
+	Event          string
+	Payload        string
+	Status         string
+	Attempts       int
+	ResponseStatus int
+	LastError      string
+	ReplayOf       primitive.ObjectID
+	CreatedAt      primitive.DateTime
+	UpdatedAt      primitive.DateTime
+	DeliveredAt    primitive.DateTime
+}
+
+// Event is the body posted to the webhook.
+type Event struct {
+	ID        primitive.ObjectID `json:"id"`
+	Event     string             `json:"event"`
+	CreatedAt primitive.DateTime `json:"createdAt"`
+	Data      interface{}        `json:"data"`
+}
+
+type TransactionPayload struct {
+	ID              primitive.ObjectID `json:"id"`
+	TransactionType string             `json:"transactionType"`
+	ProposalID      primitive.ObjectID `json:"proposalID"`
+	BatchID         primitive.ObjectID `json:"batchID,omitempty"`
+	BuyerID         primitive.ObjectID `json:"buyerID"`
+	Status          string             `json:"status"`
+	Quantity        float64            `json:"quantity"`
+	PricePerKg      float64            `json:"pricePerKg"`
+	TotalPrice      float64            `json:"totalPrice"`
+}
+
+type HarvestPayload struct {
+	ID           primitive.ObjectID `json:"id"`
+	BatchID      primitive.ObjectID `json:"batchID"`
+	ProposalID   primitive.ObjectID `json:"proposalID"`
+	Status       string             `json:"status"`
+	Date         primitive.DateTime `json:"date"`
+	TotalHarvest float64            `json:"totalHarvest"`
+	Condition    string             `json:"condition"`
+}
+
+type ProposalPayload struct {
+	ID           primitive.ObjectID `json:"id"`
+	CommodityID  primitive.ObjectID `json:"commodityID"`
+	Status       string             `json:"status"`
+	RejectReason string             `json:"rejectReason,omitempty"`
+}
+
+type Query struct {
+	Skip     int64
+	Limit    int64
+	Sort     string
+	Order    int
+	Event    string
+	IsActive *bool
+}
+
+type DeliveryQuery struct {
+	Skip      int64
+	Limit     int64
+	Sort      string
+	Order     int
+	WebhookID primitive.ObjectID
+	Event     string
+	Status    string
+}
+
+// Client posts a signed body to the url of a webhook and returns the response status.
+type Client interface {
+	Post(url string, header map[string]string, body []byte) (int, error)
+}
+
+type Repository interface {
+	// Create
+	Create(domain *Domain) (Domain, error)
+	CreateDelivery(ctx context.Context, delivery *Delivery) (Delivery, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, error)
+	GetActiveByEvent(event string) ([]Domain, error)
+	GetByQuery(query Query) ([]Domain, int, error)
+	GetDeliveryByID(id primitive.ObjectID) (Delivery, error)
+	GetDeliveriesByQuery(query DeliveryQuery) ([]Delivery, int, error)
+	// Update
+	Update(domain *Domain) (Domain, error)
+	UpdateDelivery(delivery *Delivery) (Delivery, error)
+	// Delete
+	Delete(id primitive.ObjectID) error
+}
+
+type UseCase interface {
+	// Create
+	Create(domain *Domain) (Domain, int, error)
+	Dispatch(ctx context.Context, event string, data interface{}) error
+	Replay(deliveryID primitive.ObjectID) (Delivery, int, error)
+	// Read
+	GetByID(id primitive.ObjectID) (Domain, int, error)
+	GetByPaginationAndQuery(query Query) ([]Domain, int, int, error)
+	GetDeliveryByID(id primitive.ObjectID) (Delivery, int, error)
+	GetDeliveriesByPaginationAndQuery(query DeliveryQuery) ([]Delivery, int, int, error)
+	// Update
+	Update(domain *Domain) (Domain, int, error)
+	Deliver(deliveryID primitive.ObjectID) error
+	// Delete
+	Delete(id primitive.ObjectID) (int, error)
+}
@@ -0,0 +1,54 @@
+package webhooks
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Sign returns the signature header of a delivery, an HMAC-SHA256 of the timestamp and the body so a captured request cannot be replayed later with a new timestamp.
+func Sign(secret string, timestamp int64, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(fmt.Sprintf("%d.", timestamp)))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%d,v1=%s", timestamp, hex.EncodeToString(mac.Sum(nil)))
+}
+
+// VerifySignature checks a signature header made by Sign, a timestamp older than tolerance is rejected.
+func VerifySignature(secret string, header string, body []byte, tolerance time.Duration) error {
+	var (
+		timestamp int64
+		signature string
+	)
+
+	for _, part := range strings.Split(header, ",") {
+		key, value, _ := strings.Cut(part, "=")
+		switch key {
+		case "t":
+			timestamp, _ = strconv.ParseInt(value, 10, 64)
+		case "v1":
+			signature = value
+		}
+	}
+
+	if timestamp == 0 || signature == "" {
+		return errors.New("format signature tidak valid")
+	}
+
+	if time.Since(time.Unix(timestamp, 0)) > tolerance {
+		return errors.New("signature sudah kedaluwarsa")
+	}
+
+	expected := Sign(secret, timestamp, body)
+	if !hmac.Equal([]byte(expected), []byte(fmt.Sprintf("t=%d,v1=%s", timestamp, signature))) {
+		return errors.New("signature tidak valid")
+	}
+
+	return nil
+}
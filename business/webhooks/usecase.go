@@ -0,0 +1,343 @@
+package webhooks
+
+import (
+	"context"
+	"crop_connect/business/jobs"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"crop_connect/constant"
+	"crop_connect/util"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WebhookUseCase struct {
+	webhookRepository Repository
+	jobUseCase        jobs.UseCase
+	client            Client
+	unitOfWork        unitOfWork.UnitOfWork
+}
+
+func NewUseCase(wr Repository, ju jobs.UseCase, client Client, uow unitOfWork.UnitOfWork) UseCase {
+	return &WebhookUseCase{
+		webhookRepository: wr,
+		jobUseCase:        ju,
+		client:            client,
+		unitOfWork:        uow,
+	}
+}
+
+var eventTypes = []string{
+	constant.WebhookEventTransactionCreated,
+	constant.WebhookEventTransactionAccepted,
+	constant.WebhookEventHarvestApproved,
+	constant.WebhookEventProposalValidated,
+}
+
+/*
+Util
+*/
+
+func validate(domain *Domain) (int, error) {
+	webhookURL, err := url.Parse(domain.URL)
+	if err != nil || (webhookURL.Scheme != "http" && webhookURL.Scheme != "https") || webhookURL.Host == "" {
+		return http.StatusBadRequest, errors.New("url webhook harus diawali http:// atau https://")
+	}
+
+	for _, event := range domain.Events {
+		if !util.CheckStringOnArray(eventTypes, event) {
+			return http.StatusBadRequest, fmt.Errorf("event %s tidak tersedia", event)
+		}
+	}
+
+	return http.StatusOK, nil
+}
+
+// enqueue saves the delivery and queues the job that posts it, with ctx so a delivery dispatched inside a unit of work is only sent when it commits.
+func (wu *WebhookUseCase) enqueue(ctx context.Context, delivery *Delivery) error {
+	_, err := wu.webhookRepository.CreateDelivery(ctx, delivery)
+	if err != nil {
+		return fmt.Errorf("gagal membuat pengiriman webhook: %w", err)
+	}
+
+	err = wu.jobUseCase.Enqueue(ctx, constant.JobTypeDeliverWebhook, jobs.DeliverWebhookPayload{
+		DeliveryID: delivery.ID.Hex(),
+	})
+	if err != nil {
+		return fmt.Errorf("gagal menjadwalkan pengiriman webhook: %w", err)
+	}
+
+	return nil
+}
+
+/*
+Create
+*/
+
+func (wu *WebhookUseCase) Create(domain *Domain) (Domain, int, error) {
+	statusCode, err := validate(domain)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	domain.ID = primitive.NewObjectID()
+	domain.IsActive = true
+	domain.CreatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	_, err = wu.webhookRepository.Create(domain)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal membuat webhook")
+	}
+
+	return *domain, http.StatusCreated, nil
+}
+
+// Dispatch queues the event for every active webhook subscribed to it, the data is sent as it is under "data".
+func (wu *WebhookUseCase) Dispatch(ctx context.Context, event string, data interface{}) error {
+	webhooks, err := wu.webhookRepository.GetActiveByEvent(event)
+	if err != nil {
+		return fmt.Errorf("gagal mendapatkan webhook: %w", err)
+	}
+
+	if len(webhooks) == 0 {
+		return nil
+	}
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	eventID := primitive.NewObjectID()
+
+	payload, err := json.Marshal(Event{
+		ID:        eventID,
+		Event:     event,
+		CreatedAt: now,
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("gagal membuat payload webhook: %w", err)
+	}
+
+	for _, webhook := range webhooks {
+		err := wu.enqueue(ctx, &Delivery{
+			ID:        primitive.NewObjectID(),
+			WebhookID: webhook.ID,
+			EventID:   eventID,
+			Event:     event,
+			Payload:   string(payload),
+			Status:    constant.WebhookDeliveryStatusPending,
+			CreatedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Replay sends the payload of a finished delivery again as a new delivery, the original is kept in the log as it was.
+func (wu *WebhookUseCase) Replay(deliveryID primitive.ObjectID) (Delivery, int, error) {
+	delivery, err := wu.webhookRepository.GetDeliveryByID(deliveryID)
+	if err == mongo.ErrNoDocuments {
+		return Delivery{}, http.StatusNotFound, errors.New("pengiriman webhook tidak ditemukan")
+	} else if err != nil {
+		return Delivery{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman webhook")
+	}
+
+	if delivery.Status == constant.WebhookDeliveryStatusPending {
+		return Delivery{}, http.StatusConflict, errors.New("pengiriman webhook masih diproses")
+	}
+
+	webhook, err := wu.webhookRepository.GetByID(delivery.WebhookID)
+	if err == mongo.ErrNoDocuments {
+		return Delivery{}, http.StatusNotFound, errors.New("webhook tidak ditemukan")
+	} else if err != nil {
+		return Delivery{}, http.StatusInternalServerError, errors.New("gagal mendapatkan webhook")
+	}
+
+	if !webhook.IsActive {
+		return Delivery{}, http.StatusBadRequest, errors.New("webhook tidak aktif")
+	}
+
+	replay := Delivery{
+		ID:        primitive.NewObjectID(),
+		WebhookID: delivery.WebhookID,
+		EventID:   delivery.EventID,
+		Event:     delivery.Event,
+		Payload:   delivery.Payload,
+		Status:    constant.WebhookDeliveryStatusPending,
+		ReplayOf:  delivery.ID,
+		CreatedAt: primitive.NewDateTimeFromTime(time.Now()),
+	}
+
+	err = wu.unitOfWork.Execute(func(ctx context.Context) error {
+		return wu.enqueue(ctx, &replay)
+	})
+	if err != nil {
+		return Delivery{}, http.StatusInternalServerError, err
+	}
+
+	return replay, http.StatusCreated, nil
+}
+
+/*
+Read
+*/
+
+func (wu *WebhookUseCase) GetByID(id primitive.ObjectID) (Domain, int, error) {
+	webhook, err := wu.webhookRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("webhook tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan webhook")
+	}
+
+	return webhook, http.StatusOK, nil
+}
+
+func (wu *WebhookUseCase) GetByPaginationAndQuery(query Query) ([]Domain, int, int, error) {
+	webhooks, totalData, err := wu.webhookRepository.GetByQuery(query)
+	if err != nil {
+		return []Domain{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan webhook")
+	}
+
+	return webhooks, totalData, http.StatusOK, nil
+}
+
+func (wu *WebhookUseCase) GetDeliveryByID(id primitive.ObjectID) (Delivery, int, error) {
+	delivery, err := wu.webhookRepository.GetDeliveryByID(id)
+	if err == mongo.ErrNoDocuments {
+		return Delivery{}, http.StatusNotFound, errors.New("pengiriman webhook tidak ditemukan")
+	} else if err != nil {
+		return Delivery{}, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman webhook")
+	}
+
+	return delivery, http.StatusOK, nil
+}
+
+func (wu *WebhookUseCase) GetDeliveriesByPaginationAndQuery(query DeliveryQuery) ([]Delivery, int, int, error) {
+	deliveries, totalData, err := wu.webhookRepository.GetDeliveriesByQuery(query)
+	if err != nil {
+		return []Delivery{}, 0, http.StatusInternalServerError, errors.New("gagal mendapatkan pengiriman webhook")
+	}
+
+	return deliveries, totalData, http.StatusOK, nil
+}
+
+/*
+Update
+*/
+
+// Update keeps the current secret when no new one is given.
+func (wu *WebhookUseCase) Update(domain *Domain) (Domain, int, error) {
+	webhook, err := wu.webhookRepository.GetByID(domain.ID)
+	if err == mongo.ErrNoDocuments {
+		return Domain{}, http.StatusNotFound, errors.New("webhook tidak ditemukan")
+	} else if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal mendapatkan webhook")
+	}
+
+	statusCode, err := validate(domain)
+	if err != nil {
+		return Domain{}, statusCode, err
+	}
+
+	webhook.URL = domain.URL
+	webhook.Events = domain.Events
+	webhook.Description = domain.Description
+	webhook.IsActive = domain.IsActive
+	webhook.UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+
+	if domain.Secret != "" {
+		webhook.Secret = domain.Secret
+	}
+
+	webhook, err = wu.webhookRepository.Update(&webhook)
+	if err != nil {
+		return Domain{}, http.StatusInternalServerError, errors.New("gagal memperbarui webhook")
+	}
+
+	return webhook, http.StatusOK, nil
+}
+
+// Deliver posts a pending delivery and records the attempt, a returned error makes the job worker retry it with backoff.
+// The delivery is marked failed together with the last attempt of its job, or at once when its webhook is gone.
+func (wu *WebhookUseCase) Deliver(deliveryID primitive.ObjectID) error {
+	delivery, err := wu.webhookRepository.GetDeliveryByID(deliveryID)
+	if err != nil {
+		return errors.New("gagal mendapatkan pengiriman webhook")
+	}
+
+	if delivery.Status != constant.WebhookDeliveryStatusPending {
+		return nil
+	}
+
+	now := time.Now()
+	delivery.UpdatedAt = primitive.NewDateTimeFromTime(now)
+
+	webhook, err := wu.webhookRepository.GetByID(delivery.WebhookID)
+	if err == mongo.ErrNoDocuments || (err == nil && !webhook.IsActive) {
+		delivery.Status = constant.WebhookDeliveryStatusFailed
+		delivery.LastError = "webhook sudah dihapus atau tidak aktif"
+		_, err = wu.webhookRepository.UpdateDelivery(&delivery)
+		return err
+	} else if err != nil {
+		return errors.New("gagal mendapatkan webhook")
+	}
+
+	body := []byte(delivery.Payload)
+	responseStatus, err := wu.client.Post(webhook.URL, map[string]string{
+		"Content-Type":                  "application/json",
+		constant.WebhookHeaderEvent:     delivery.Event,
+		constant.WebhookHeaderDelivery:  delivery.ID.Hex(),
+		constant.WebhookHeaderSignature: Sign(webhook.Secret, now.Unix(), body),
+	}, body)
+	if err == nil && (responseStatus < 200 || responseStatus >= 300) {
+		err = fmt.Errorf("webhook membalas dengan status %d", responseStatus)
+	}
+
+	delivery.Attempts++
+	delivery.ResponseStatus = responseStatus
+
+	if err == nil {
+		delivery.Status = constant.WebhookDeliveryStatusSuccess
+		delivery.LastError = ""
+		delivery.DeliveredAt = delivery.UpdatedAt
+	} else {
+		delivery.LastError = err.Error()
+		if delivery.Attempts >= constant.JobMaxAttempts {
+			delivery.Status = constant.WebhookDeliveryStatusFailed
+		}
+	}
+
+	// only the result of the post decides the retry, posting again because the log could not be written would send the event twice
+	_, _ = wu.webhookRepository.UpdateDelivery(&delivery)
+
+	return err
+}
+
+/*
+Delete
+*/
+
+// Delete removes the webhook, its deliveries stay in the log.
+func (wu *WebhookUseCase) Delete(id primitive.ObjectID) (int, error) {
+	_, err := wu.webhookRepository.GetByID(id)
+	if err == mongo.ErrNoDocuments {
+		return http.StatusNotFound, errors.New("webhook tidak ditemukan")
+	} else if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal mendapatkan webhook")
+	}
+
+	err = wu.webhookRepository.Delete(id)
+	if err != nil {
+		return http.StatusInternalServerError, errors.New("gagal menghapus webhook")
+	}
+
+	return http.StatusOK, nil
+}
@@ -7,6 +7,114 @@ const (
 	RoleFarmer    = "farmer"
 	RoleBuyer     = "buyer"
 
+	// permission
+	PermissionUserCreateValidator          = "user:createValidator"
+	PermissionUserRead                     = "user:read"
+	PermissionUserSuspend                  = "user:suspend"
+	PermissionUserAssignArea               = "user:assignArea"
+	PermissionUserStatistic                = "user:statistic"
+	PermissionValidatorStatistic           = "validator:statistic"
+	PermissionCommodityManage              = "commodity:manage"
+	PermissionCommodityStatistic           = "commodity:statistic"
+	PermissionProposalManage               = "proposal:manage"
+	PermissionProposalRead                 = "proposal:read"
+	PermissionProposalList                 = "proposal:list"
+	PermissionProposalValidate             = "proposal:validate"
+	PermissionProposalQueue                = "proposal:queue"
+	PermissionProposalAssign               = "proposal:assign"
+	PermissionProposalStatistic            = "proposal:statistic"
+	PermissionTransactionRead              = "transaction:read"
+	PermissionTransactionCreate            = "transaction:create"
+	PermissionTransactionCancel            = "transaction:cancel"
+	PermissionTransactionDecide            = "transaction:decide"
+	PermissionTransactionNegotiate         = "transaction:negotiate"
+	PermissionTransactionStatistic         = "transaction:statistic"
+	PermissionTransactionStatisticProvince = "transaction:statisticProvince"
+	PermissionPaymentCreate                = "payment:create"
+	PermissionPaymentRead                  = "payment:read"
+	PermissionPaymentRefund                = "payment:refund"
+	PermissionBatchRead                    = "batch:read"
+	PermissionBatchManage                  = "batch:manage"
+	PermissionBatchStatistic               = "batch:statistic"
+	PermissionTreatmentRecordRead          = "treatmentRecord:read"
+	PermissionTreatmentRecordRequest       = "treatmentRecord:request"
+	PermissionTreatmentRecordFill          = "treatmentRecord:fill"
+	PermissionTreatmentRecordValidate      = "treatmentRecord:validate"
+	PermissionTreatmentRecordStatistic     = "treatmentRecord:statistic"
+	PermissionTreatmentRecordCount         = "treatmentRecord:count"
+	PermissionHarvestRead                  = "harvest:read"
+	PermissionHarvestSubmit                = "harvest:submit"
+	PermissionHarvestValidate              = "harvest:validate"
+	PermissionHarvestStatistic             = "harvest:statistic"
+	PermissionShipmentRead                 = "shipment:read"
+	PermissionShipmentDispatch             = "shipment:dispatch"
+	PermissionShipmentConfirm              = "shipment:confirm"
+	PermissionJobHistoryRead               = "jobHistory:read"
+	PermissionPolicyRead                   = "policy:read"
+	PermissionAuditEventRead               = "auditEvent:read"
+	PermissionAuditEventHistory            = "auditEvent:history"
+	PermissionDisputeOpen                  = "dispute:open"
+	PermissionDisputeRead                  = "dispute:read"
+	PermissionDisputeMessage               = "dispute:message"
+	PermissionDisputeResolve               = "dispute:resolve"
+	PermissionRatingCreate                 = "rating:create"
+	PermissionConversationStart            = "conversation:start"
+	PermissionConversationRead             = "conversation:read"
+	PermissionConversationMessage          = "conversation:message"
+	PermissionWebhookManage                = "webhook:manage"
+	PermissionAPIKeyManage                 = "apiKey:manage"
+
+	// type resource
+	ResourceCommodity = "commodity"
+	ResourceProposal  = "proposal"
+	ResourceBatch     = "batch"
+
+	// type entity audit event
+	AuditEntityProposal        = "proposal"
+	AuditEntityTransaction     = "transaction"
+	AuditEntityBatch           = "batch"
+	AuditEntityTreatmentRecord = "treatmentRecord"
+	AuditEntityHarvest         = "harvest"
+	AuditEntityDispute         = "dispute"
+
+	// actor of transitions made by the scheduler or a payment gateway
+	AuditActorSystem = "system"
+
+	// status user
+	UserStatusUnverified = "unverified"
+	UserStatusActive     = "active"
+	UserStatusSuspended  = "suspended"
+	UserStatusDeleted    = "deleted"
+
+	// type token
+	TokenTypeAccess  = "access"
+	TokenTypeRefresh = "refresh"
+	TokenTypeAPIKey  = "apiKey"
+
+	// type token for the second login step of an account with two factor authentication
+	TokenTypeTwoFactor = "twoFactor"
+
+	// issuer shown by authenticator apps
+	TwoFactorIssuer = "Crop Connect"
+
+	// scope api key
+	ScopeCommoditiesRead   = "commodities:read"
+	ScopeCommoditiesWrite  = "commodities:write"
+	ScopeProposalsRead     = "proposals:read"
+	ScopeProposalsWrite    = "proposals:write"
+	ScopeTransactionsRead  = "transactions:read"
+	ScopeTransactionsWrite = "transactions:write"
+	ScopeBatchsRead        = "batchs:read"
+	ScopeBatchsWrite       = "batchs:write"
+	ScopeShipmentsRead     = "shipments:read"
+	ScopeShipmentsWrite    = "shipments:write"
+
+	// api key
+	APIKeyHeader           = "X-API-Key"
+	APIKeyPrefix           = "ck_"
+	ContextKeyAPIKeyClaims = "apiKeyClaims"
+	ContextKeyAPIKeyScopes = "apiKeyScopes"
+
 	// status proposal
 	ProposalStatusPending  = "pending"
 	ProposalStatusApproved = "approved"
@@ -17,10 +125,14 @@ const (
 	TransactionTypeAnnuals    = "annuals"
 
 	// status transaction
-	TransactionStatusPending  = "pending"
-	TransactionStatusAccepted = "accepted"
-	TransactionStatusRejected = "rejected"
-	TransactionStatusCancel   = "cancelled"
+	TransactionStatusPending           = "pending"
+	TransactionStatusAccepted          = "accepted"
+	TransactionStatusRejected          = "rejected"
+	TransactionStatusCancel            = "cancelled"
+	TransactionStatusPaid              = "paid"
+	TransactionStatusRefunded          = "refunded"
+	TransactionStatusPartiallyRefunded = "partiallyRefunded"
+	TransactionStatusExpired           = "expired"
 
 	// status batch
 	BatchStatusPlanting = "planting"
@@ -37,12 +149,111 @@ const (
 	TreatmentRecordStatusPending         = "pending"
 	TreatmentRecordStatusApproved        = "approved"
 	TreatmentRecordStatusRevision        = "revision"
+	TreatmentRecordStatusClosed          = "closed"
+
+	// status payment
+	PaymentStatusPending           = "pending"
+	PaymentStatusPaid              = "paid"
+	PaymentStatusRefunding         = "refunding"
+	PaymentStatusExpired           = "expired"
+	PaymentStatusRefunded          = "refunded"
+	PaymentStatusPartiallyRefunded = "partiallyRefunded"
+
+	// payment gateway
+	PaymentGatewayLocal = "local"
+
+	// status shipment
+	ShipmentStatusWaitingPickup = "waitingPickup"
+	ShipmentStatusDispatched    = "dispatched"
+	ShipmentStatusDelivered     = "delivered"
+
+	// status dispute
+	DisputeStatusOpen     = "open"
+	DisputeStatusResolved = "resolved"
+
+	// reason dispute
+	DisputeReasonCondition = "condition"
+	DisputeReasonWeight    = "weight"
+
+	// outcome dispute
+	DisputeOutcomeRefund        = "refund"
+	DisputeOutcomePartialRefund = "partialRefund"
+	DisputeOutcomeDismissed     = "dismissed"
+
+	// subject conversation
+	ConversationSubjectCommodity   = "commodity"
+	ConversationSubjectProposal    = "proposal"
+	ConversationSubjectTransaction = "transaction"
+
+	// event webhook
+	WebhookEventTransactionCreated  = "transaction.created"
+	WebhookEventTransactionAccepted = "transaction.accepted"
+	WebhookEventHarvestApproved     = "harvest.approved"
+	WebhookEventProposalValidated   = "proposal.validated"
+
+	// status webhook delivery
+	WebhookDeliveryStatusPending = "pending"
+	WebhookDeliveryStatusSuccess = "success"
+	WebhookDeliveryStatusFailed  = "failed"
+
+	// header webhook delivery
+	WebhookHeaderEvent     = "X-Webhook-Event"
+	WebhookHeaderDelivery  = "X-Webhook-Delivery"
+	WebhookHeaderSignature = "X-Webhook-Signature"
+
+	// type notification
+	NotificationTypeTreatmentRecordRequest = "treatmentRecordRequest"
+	NotificationTypeTransactionDecision    = "transactionDecision"
+	NotificationTypeProposalReview         = "proposalReview"
+	NotificationTypeHarvestReview          = "harvestReview"
+	NotificationTypeTransactionExpired     = "transactionExpired"
+	NotificationTypeBatchOverdue           = "batchOverdue"
+	NotificationTypeBatchCancelled         = "batchCancelled"
+	NotificationTypeDisputeOpened          = "disputeOpened"
+	NotificationTypeDisputeMessage         = "disputeMessage"
+	NotificationTypeDisputeResolved        = "disputeResolved"
+	NotificationTypeRatingReceived         = "ratingReceived"
+	NotificationTypeConversationMessage    = "conversationMessage"
+	NotificationTypePriceTopUp             = "priceTopUp"
+
+	// status job
+	JobStatusPending    = "pending"
+	JobStatusProcessing = "processing"
+	JobStatusDone       = "done"
+	JobStatusDead       = "dead"
+
+	// type job
+	JobTypeSendEmail      = "sendEmail"
+	JobTypeDeleteImages   = "deleteImages"
+	JobTypeRefundPayment  = "refundPayment"
+	JobTypeDeliverWebhook = "deliverWebhook"
+
+	// a job is dead lettered after failing this many times
+	JobMaxAttempts = 5
+
+	// scheduled job
+	ScheduledJobExpireTransactions      = "expireTransactions"
+	ScheduledJobMarkOverdueBatchs       = "markOverdueBatchs"
+	ScheduledJobPurgeForgotPasswords    = "purgeForgotPasswords"
+	ScheduledJobPurgeSessions           = "purgeSessions"
+	ScheduledJobPurgeEmailVerifications = "purgeEmailVerifications"
+
+	// status job history
+	JobHistoryStatusSuccess = "success"
+	JobHistoryStatusFailed  = "failed"
 
 	// folder cloudinary
 	CloudinaryFolderCommodities      = "commodities"
 	CloudinaryFolderTreatmentRecords = "treatmentRecords"
 	CloudinaryFolderHarvests         = "harvests"
+	CloudinaryFolderDisputes         = "disputes"
+	CloudinaryFolderConversations    = "conversations"
 
 	// template mailgun
-	MailgunForgotPasswordTemplate = "forgot_password"
+	MailgunForgotPasswordTemplate         = "forgot_password"
+	MailgunTransactionDecisionTemplate    = "transaction_decision"
+	MailgunProposalValidationTemplate     = "proposal_validation"
+	MailgunTreatmentRecordRequestTemplate = "treatment_record_request"
+	MailgunHarvestApprovalTemplate        = "harvest_approval"
+	MailgunEmailVerificationTemplate      = "email_verification"
 )
@@ -0,0 +1,182 @@
+package api_keys
+
+import (
+	apiKeys "crop_connect/business/api_keys"
+	"crop_connect/business/policies"
+	"crop_connect/controller/api_keys/request"
+	"crop_connect/controller/api_keys/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	apiKeyUC apiKeys.UseCase
+	policyUC policies.UseCase
+}
+
+func NewController(apiKeyUC apiKeys.UseCase, policyUC policies.UseCase) *Controller {
+	return &Controller{
+		apiKeyUC: apiKeyUC,
+		policyUC: policyUC,
+	}
+}
+
+/*
+Create
+*/
+
+func (akc *Controller) Create(c echo.Context) error {
+	userInput := request.Create{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	inputDomain, err := userInput.ToDomain()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	adminID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	inputDomain.CreatedBy = adminID
+
+	apiKey, key, statusCode, err := akc.apiKeyUC.Create(inputDomain)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil membuat api key, simpan key karena tidak dapat ditampilkan lagi",
+		Data: response.CreatedAPIKey{
+			APIKey: response.FromDomain(&apiKey),
+			Key:    key,
+		},
+	})
+}
+
+/*
+Read
+*/
+
+func (akc *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"createdAt", "expiresAt", "lastUsedAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	apiKeyList, totalData, statusCode, err := akc.apiKeyUC.GetByPaginationAndQuery(apiKeys.Query{
+		Skip:      queryPagination.Skip,
+		Limit:     queryPagination.Limit,
+		Sort:      queryPagination.Sort,
+		Order:     queryPagination.Order,
+		UserID:    queryParam.UserID,
+		IsRevoked: queryParam.IsRevoked,
+	})
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan api key",
+		Data:       response.FromDomainArray(apiKeyList),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (akc *Controller) GetByID(c echo.Context) error {
+	apiKeyID, err := primitive.ObjectIDFromHex(c.Param("api-key-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "api key id tidak valid",
+		})
+	}
+
+	apiKey, statusCode, err := akc.apiKeyUC.GetByID(apiKeyID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan api key",
+		Data:    response.FromDomain(&apiKey),
+	})
+}
+
+func (akc *Controller) GetScopes(c echo.Context) error {
+	return c.JSON(http.StatusOK, helper.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "berhasil mendapatkan scope api key",
+		Data:    akc.policyUC.GetScopes(),
+	})
+}
+
+/*
+Update
+*/
+
+func (akc *Controller) Revoke(c echo.Context) error {
+	apiKeyID, err := primitive.ObjectIDFromHex(c.Param("api-key-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "api key id tidak valid",
+		})
+	}
+
+	apiKey, statusCode, err := akc.apiKeyUC.Revoke(apiKeyID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mencabut api key",
+		Data:    response.FromDomain(&apiKey),
+	})
+}
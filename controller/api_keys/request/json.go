@@ -0,0 +1,69 @@
+package request
+
+import (
+	apiKeys "crop_connect/business/api_keys"
+	"crop_connect/helper"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fatih/structs"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Create struct {
+	UserID    string   `json:"userID" validate:"required"`
+	Name      string   `json:"name" validate:"required,max=100"`
+	Scopes    []string `json:"scopes" validate:"required,min=1"`
+	ExpiresAt string   `json:"expiresAt" validate:"required"`
+}
+
+func (req *Create) ToDomain() (*apiKeys.Domain, error) {
+	userID, err := primitive.ObjectIDFromHex(req.UserID)
+	if err != nil {
+		return nil, errors.New("userID tidak valid")
+	}
+
+	expiresAt, err := time.Parse("2006-01-02", req.ExpiresAt)
+	if err != nil {
+		return nil, errors.New("format tanggal kedaluwarsa tidak valid")
+	}
+
+	return &apiKeys.Domain{
+		UserID:    userID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: primitive.NewDateTimeFromTime(expiresAt),
+	}, nil
+}
+
+func (req *Create) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
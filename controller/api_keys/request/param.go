@@ -0,0 +1,38 @@
+package request
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FilterQuery struct {
+	UserID    primitive.ObjectID
+	IsRevoked *bool
+}
+
+func QueryParamValidation(c echo.Context) (FilterQuery, error) {
+	filter := FilterQuery{}
+
+	if c.QueryParam("userID") != "" {
+		userID, err := primitive.ObjectIDFromHex(c.QueryParam("userID"))
+		if err != nil {
+			return FilterQuery{}, errors.New("userID tidak valid")
+		}
+
+		filter.UserID = userID
+	}
+
+	if c.QueryParam("isRevoked") != "" {
+		isRevoked, err := strconv.ParseBool(c.QueryParam("isRevoked"))
+		if err != nil {
+			return FilterQuery{}, errors.New("isRevoked hanya tersedia true dan false")
+		}
+
+		filter.IsRevoked = &isRevoked
+	}
+
+	return filter, nil
+}
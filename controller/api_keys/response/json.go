@@ -0,0 +1,50 @@
+package response
+
+import (
+	apiKeys "crop_connect/business/api_keys"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type APIKey struct {
+	ID         primitive.ObjectID `json:"_id"`
+	UserID     primitive.ObjectID `json:"userID"`
+	Name       string             `json:"name"`
+	Prefix     string             `json:"prefix"`
+	Scopes     []string           `json:"scopes"`
+	ExpiresAt  primitive.DateTime `json:"expiresAt"`
+	LastUsedAt primitive.DateTime `json:"lastUsedAt,omitempty"`
+	RevokedAt  primitive.DateTime `json:"revokedAt,omitempty"`
+	CreatedBy  primitive.ObjectID `json:"createdBy"`
+	CreatedAt  primitive.DateTime `json:"createdAt"`
+}
+
+// CreatedAPIKey is only returned when the key is issued, the key is not stored and cannot be read again.
+type CreatedAPIKey struct {
+	APIKey
+	Key string `json:"key"`
+}
+
+func FromDomain(domain *apiKeys.Domain) APIKey {
+	return APIKey{
+		ID:         domain.ID,
+		UserID:     domain.UserID,
+		Name:       domain.Name,
+		Prefix:     domain.Prefix,
+		Scopes:     domain.Scopes,
+		ExpiresAt:  domain.ExpiresAt,
+		LastUsedAt: domain.LastUsedAt,
+		RevokedAt:  domain.RevokedAt,
+		CreatedBy:  domain.CreatedBy,
+		CreatedAt:  domain.CreatedAt,
+	}
+}
+
+func FromDomainArray(domain []apiKeys.Domain) []APIKey {
+	var response []APIKey
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
@@ -0,0 +1,107 @@
+package audit_events
+
+import (
+	auditEvents "crop_connect/business/audit_events"
+	"crop_connect/controller/audit_events/request"
+	"crop_connect/controller/audit_events/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	auditEventUC auditEvents.UseCase
+}
+
+func NewController(auditEventUC auditEvents.UseCase) *Controller {
+	return &Controller{
+		auditEventUC: auditEventUC,
+	}
+}
+
+/*
+Create
+*/
+
+/*
+Read
+*/
+
+func (aec *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"entityType", "newStatus", "createdAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	auditEventQuery := auditEvents.Query{
+		Skip:       queryPagination.Skip,
+		Limit:      queryPagination.Limit,
+		Sort:       queryPagination.Sort,
+		Order:      queryPagination.Order,
+		EntityType: queryParam.EntityType,
+		EntityID:   queryParam.EntityID,
+		ActorID:    queryParam.ActorID,
+		ActorRole:  queryParam.ActorRole,
+		NewStatus:  queryParam.Status,
+	}
+
+	auditEvents, totalData, statusCode, err := aec.auditEventUC.GetByPaginationAndQuery(auditEventQuery)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan riwayat status",
+		Data:       response.FromDomainArray(auditEvents),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (aec *Controller) GetByEntity(c echo.Context) error {
+	entityID, err := primitive.ObjectIDFromHex(c.Param("entity-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id entitas tidak valid",
+		})
+	}
+
+	auditEvents, statusCode, err := aec.auditEventUC.GetByEntity(c.Param("entity-type"), entityID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan riwayat status",
+		Data:    response.FromDomainArray(auditEvents),
+	})
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
@@ -0,0 +1,59 @@
+package request
+
+import (
+	"crop_connect/constant"
+	"crop_connect/util"
+	"errors"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type FilterQuery struct {
+	EntityType string
+	EntityID   primitive.ObjectID
+	ActorID    primitive.ObjectID
+	ActorRole  string
+	Status     string
+}
+
+func QueryParamValidation(c echo.Context) (FilterQuery, error) {
+	filter := FilterQuery{
+		EntityType: c.QueryParam("entityType"),
+		ActorRole:  c.QueryParam("actorRole"),
+		Status:     c.QueryParam("status"),
+	}
+
+	if filter.EntityType != "" {
+		if !util.CheckStringOnArray([]string{constant.AuditEntityProposal, constant.AuditEntityTransaction, constant.AuditEntityBatch, constant.AuditEntityTreatmentRecord, constant.AuditEntityHarvest, constant.AuditEntityDispute}, filter.EntityType) {
+			return FilterQuery{}, fmt.Errorf("entityType tersedia hanya %s, %s, %s, %s, %s, dan %s", constant.AuditEntityProposal, constant.AuditEntityTransaction, constant.AuditEntityBatch, constant.AuditEntityTreatmentRecord, constant.AuditEntityHarvest, constant.AuditEntityDispute)
+		}
+	}
+
+	if filter.ActorRole != "" {
+		if !util.CheckStringOnArray([]string{constant.RoleAdmin, constant.RoleValidator, constant.RoleFarmer, constant.RoleBuyer, constant.AuditActorSystem}, filter.ActorRole) {
+			return FilterQuery{}, fmt.Errorf("actorRole tersedia hanya %s, %s, %s, %s, dan %s", constant.RoleAdmin, constant.RoleValidator, constant.RoleFarmer, constant.RoleBuyer, constant.AuditActorSystem)
+		}
+	}
+
+	if entity := c.QueryParam("entityID"); entity != "" {
+		entityID, err := primitive.ObjectIDFromHex(entity)
+		if err != nil {
+			return FilterQuery{}, errors.New("entityID harus berupa hex")
+		}
+
+		filter.EntityID = entityID
+	}
+
+	if actor := c.QueryParam("actorID"); actor != "" {
+		actorID, err := primitive.ObjectIDFromHex(actor)
+		if err != nil {
+			return FilterQuery{}, errors.New("actorID harus berupa hex")
+		}
+
+		filter.ActorID = actorID
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,42 @@
+package response
+
+import (
+	auditEvents "crop_connect/business/audit_events"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditEvent struct {
+	ID         primitive.ObjectID `json:"_id"`
+	EntityType string             `json:"entityType"`
+	EntityID   primitive.ObjectID `json:"entityID"`
+	ActorID    primitive.ObjectID `json:"actorID,omitempty"`
+	ActorRole  string             `json:"actorRole"`
+	OldStatus  string             `json:"oldStatus,omitempty"`
+	NewStatus  string             `json:"newStatus"`
+	Reason     string             `json:"reason,omitempty"`
+	CreatedAt  primitive.DateTime `json:"createdAt"`
+}
+
+func FromDomain(domain *auditEvents.Domain) AuditEvent {
+	return AuditEvent{
+		ID:         domain.ID,
+		EntityType: domain.EntityType,
+		EntityID:   domain.EntityID,
+		ActorID:    domain.ActorID,
+		ActorRole:  domain.ActorRole,
+		OldStatus:  domain.OldStatus,
+		NewStatus:  domain.NewStatus,
+		Reason:     domain.Reason,
+		CreatedAt:  domain.CreatedAt,
+	}
+}
+
+func FromDomainArray(domain []auditEvents.Domain) []AuditEvent {
+	var response []AuditEvent
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
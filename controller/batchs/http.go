@@ -336,51 +336,51 @@ func (bc *Controller) GetForHarvestByCommmodityID(c echo.Context) error {
 Update
 */
 
-// func (bc *Controller) Cancel(c echo.Context) error {
-// 	batchID, err := primitive.ObjectIDFromHex(c.Param("batch-id"))
-// 	if err != nil {
-// 		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
-// 			Status:  http.StatusBadRequest,
-// 			Message: "id batch tidak valid",
-// 		})
-// 	}
-
-// 	farmerID, err := helper.GetUIDFromToken(c)
-// 	if err != nil {
-// 		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
-// 			Status:  http.StatusUnauthorized,
-// 			Message: err.Error(),
-// 		})
-// 	}
-
-// 	userInput := request.Cancel{}
-// 	c.Bind(&userInput)
-
-// 	validationErr := userInput.Validate()
-// 	if validationErr != nil {
-// 		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
-// 			Status:  http.StatusBadRequest,
-// 			Message: "validasi gagal",
-// 			Error:   validationErr,
-// 		})
-// 	}
-
-// 	inputDomain := userInput.ToDomain()
-// 	inputDomain.ID = batchID
-
-// 	statusCode, err := bc.batchUC.Cancel(inputDomain, farmerID)
-// 	if err != nil {
-// 		return c.JSON(statusCode, helper.BaseResponse{
-// 			Status:  statusCode,
-// 			Message: err.Error(),
-// 		})
-// 	}
-
-// 	return c.JSON(statusCode, helper.BaseResponse{
-// 		Status:  statusCode,
-// 		Message: "berhasil membatalkan batch",
-// 	})
-// }
+func (bc *Controller) Cancel(c echo.Context) error {
+	batchID, err := primitive.ObjectIDFromHex(c.Param("batch-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id batch tidak valid",
+		})
+	}
+
+	farmerID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userInput := request.Cancel{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	inputDomain := userInput.ToDomain()
+	inputDomain.ID = batchID
+
+	statusCode, err := bc.transactionUC.CancelBatch(inputDomain, farmerID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil membatalkan batch",
+	})
+}
 
 /*
 Delete
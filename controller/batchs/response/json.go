@@ -21,6 +21,8 @@ type Batch struct {
 	Status               string                                 `json:"status"`
 	CancelReason         string                                 `json:"cancelReason,omitempty"`
 	IsAvailable          bool                                   `json:"isAvailable"`
+	IsOverdue            bool                                   `json:"isOverdue"`
+	RemainingQuantity    float64                                `json:"remainingQuantity"`
 	CreatedAt            primitive.DateTime                     `json:"createdAt"`
 	UpdatedAt            primitive.DateTime                     `json:"updatedAt,omitempty"`
 }
@@ -44,6 +46,8 @@ func FromDomain(domain batchs.Domain, proposalUC proposals.UseCase, commodityUC
 		Status:               domain.Status,
 		CancelReason:         domain.CancelReason,
 		IsAvailable:          domain.IsAvailable,
+		IsOverdue:            domain.IsOverdue,
+		RemainingQuantity:    domain.RemainingQuantity,
 		CreatedAt:            domain.CreatedAt,
 		UpdatedAt:            domain.UpdatedAt,
 	}, http.StatusOK, nil
@@ -70,6 +74,8 @@ type BatchWithoutProposal struct {
 	Status               string             `json:"status"`
 	CancelReason         string             `json:"cancelReason,omitempty"`
 	IsAvailable          bool               `json:"isAvailable"`
+	IsOverdue            bool               `json:"isOverdue"`
+	RemainingQuantity    float64            `json:"remainingQuantity"`
 	CreatedAt            primitive.DateTime `json:"createdAt"`
 	UpdatedAt            primitive.DateTime `json:"updatedAt,omitempty"`
 }
@@ -82,6 +88,8 @@ func FromDomainWithoutProposal(domain *batchs.Domain) BatchWithoutProposal {
 		Status:               domain.Status,
 		CancelReason:         domain.CancelReason,
 		IsAvailable:          domain.IsAvailable,
+		IsOverdue:            domain.IsOverdue,
+		RemainingQuantity:    domain.RemainingQuantity,
 		CreatedAt:            domain.CreatedAt,
 		UpdatedAt:            domain.UpdatedAt,
 	}
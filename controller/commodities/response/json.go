@@ -22,6 +22,8 @@ type Commodity struct {
 	PricePerKg     int                `json:"pricePerKg"`
 	IsPerennials   bool               `json:"isPerennials"`
 	IsAvailable    bool               `json:"isAvailable"`
+	RatingAverage  float64            `json:"ratingAverage"`
+	RatingCount    int                `json:"ratingCount"`
 	CreatedAt      primitive.DateTime `json:"createdAt"`
 	UpdatedAt      primitive.DateTime `json:"updatedAt,omitempty"`
 	DeletedAt      primitive.DateTime `json:"deletedAt,omitempty"`
@@ -50,6 +52,8 @@ func FromDomain(domain commodities.Domain, userUC users.UseCase, regionUC region
 		PricePerKg:     domain.PricePerKg,
 		IsPerennials:   domain.IsPerennials,
 		IsAvailable:    domain.IsAvailable,
+		RatingAverage:  domain.RatingAverage,
+		RatingCount:    domain.RatingCount,
 		CreatedAt:      domain.CreatedAt,
 		UpdatedAt:      domain.UpdatedAt,
 		DeletedAt:      domain.DeletedAt,
@@ -0,0 +1,362 @@
+package conversations
+
+import (
+	"crop_connect/business/conversations"
+	"crop_connect/constant"
+	"crop_connect/controller/conversations/request"
+	"crop_connect/controller/conversations/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	conversationUC conversations.UseCase
+}
+
+func NewController(conversationUC conversations.UseCase) *Controller {
+	return &Controller{
+		conversationUC: conversationUC,
+	}
+}
+
+/*
+Create
+*/
+
+func (cc *Controller) Start(c echo.Context) error {
+	userInput := request.Start{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	images, statusCode, err := helper.GetCreateImageRequest(c, []string{"image1", "image2", "image3"})
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	inputDomain, inputMessage, err := userInput.ToDomain()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	inputDomain.BuyerID = userID
+
+	conversation, statusCode, err := cc.conversationUC.Start(inputDomain, inputMessage, images)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	conversationResponse, _, err := response.FromDomain(conversation, cc.conversationUC, userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, helper.BaseResponse{
+			Status:  http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil memulai percakapan",
+		Data:    conversationResponse,
+	})
+}
+
+func (cc *Controller) SendMessage(c echo.Context) error {
+	conversationID, err := primitive.ObjectIDFromHex(c.Param("conversation-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "conversation id tidak valid",
+		})
+	}
+
+	userInput := request.Message{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	images, statusCode, err := helper.GetCreateImageRequest(c, []string{"image1", "image2", "image3"})
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	inputMessage := userInput.ToDomain()
+	inputMessage.ConversationID = conversationID
+	inputMessage.SenderID = userID
+
+	message, statusCode, err := cc.conversationUC.SendMessage(inputMessage, images)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mengirim pesan",
+		Data:    response.FromMessageDomain(&message),
+	})
+}
+
+/*
+Read
+*/
+
+func (cc *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"lastMessageAt", "createdAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	conversationQuery := conversations.Query{
+		Skip:        queryPagination.Skip,
+		Limit:       queryPagination.Limit,
+		Sort:        queryPagination.Sort,
+		Order:       queryPagination.Order,
+		SubjectType: queryParam.SubjectType,
+	}
+
+	if token.Role == constant.RoleBuyer {
+		conversationQuery.BuyerID = userID
+	} else if token.Role == constant.RoleFarmer {
+		conversationQuery.FarmerID = userID
+	}
+
+	conversations, totalData, statusCode, err := cc.conversationUC.GetByPaginationAndQuery(conversationQuery)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	conversationResponse, statusCode, err := response.FromDomainArray(conversations, cc.conversationUC, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan percakapan",
+		Data:       conversationResponse,
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (cc *Controller) GetByID(c echo.Context) error {
+	conversationID, err := primitive.ObjectIDFromHex(c.Param("conversation-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "conversation id tidak valid",
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	conversation, statusCode, err := cc.conversationUC.GetByID(conversationID, userID, token.Role)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	conversationResponse, statusCode, err := response.FromDomain(conversation, cc.conversationUC, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan percakapan",
+		Data:    conversationResponse,
+	})
+}
+
+func (cc *Controller) GetMessages(c echo.Context) error {
+	conversationID, err := primitive.ObjectIDFromHex(c.Param("conversation-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "conversation id tidak valid",
+		})
+	}
+
+	queryPagination, err := helper.PaginationToQuery(c, []string{"createdAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	messages, totalData, statusCode, err := cc.conversationUC.GetMessages(conversations.MessageQuery{
+		Skip:           queryPagination.Skip,
+		Limit:          queryPagination.Limit,
+		Sort:           queryPagination.Sort,
+		Order:          queryPagination.Order,
+		ConversationID: conversationID,
+	}, userID, token.Role)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan pesan",
+		Data:       response.FromMessageDomainArray(messages),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+/*
+Update
+*/
+
+func (cc *Controller) MarkAsRead(c echo.Context) error {
+	conversationID, err := primitive.ObjectIDFromHex(c.Param("conversation-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "conversation id tidak valid",
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	statusCode, err := cc.conversationUC.MarkAsRead(conversationID, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil menandai pesan sebagai telah dibaca",
+	})
+}
+
+/*
+Delete
+*/
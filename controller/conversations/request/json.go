@@ -0,0 +1,102 @@
+package request
+
+import (
+	"crop_connect/business/conversations"
+	"crop_connect/helper"
+	"errors"
+	"strings"
+
+	"github.com/fatih/structs"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Start struct {
+	SubjectType string `form:"subjectType" json:"subjectType" validate:"required"`
+	SubjectID   string `form:"subjectID" json:"subjectID" validate:"required"`
+	Message     string `form:"message" json:"message" validate:"required"`
+}
+
+func (req *Start) ToDomain() (*conversations.Domain, *conversations.Message, error) {
+	subjectID, err := primitive.ObjectIDFromHex(req.SubjectID)
+	if err != nil {
+		return nil, nil, errors.New("subjectID tidak valid")
+	}
+
+	return &conversations.Domain{
+		SubjectType: req.SubjectType,
+		SubjectID:   subjectID,
+	}, &conversations.Message{
+		Message: req.Message,
+	}, nil
+}
+
+func (req *Start) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type Message struct {
+	Message string `form:"message" json:"message" validate:"required"`
+}
+
+func (req *Message) ToDomain() *conversations.Message {
+	return &conversations.Message{
+		Message: req.Message,
+	}
+}
+
+func (req *Message) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"crop_connect/constant"
+	"crop_connect/util"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FilterQuery struct {
+	SubjectType string
+}
+
+func QueryParamValidation(c echo.Context) (FilterQuery, error) {
+	filter := FilterQuery{
+		SubjectType: c.QueryParam("subjectType"),
+	}
+
+	if filter.SubjectType != "" {
+		if !util.CheckStringOnArray([]string{constant.ConversationSubjectCommodity, constant.ConversationSubjectProposal, constant.ConversationSubjectTransaction}, filter.SubjectType) {
+			return FilterQuery{}, fmt.Errorf("subjectType tersedia hanya %s, %s, dan %s", constant.ConversationSubjectCommodity, constant.ConversationSubjectProposal, constant.ConversationSubjectTransaction)
+		}
+	}
+
+	return filter, nil
+}
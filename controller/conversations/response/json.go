@@ -0,0 +1,90 @@
+package response
+
+import (
+	"crop_connect/business/conversations"
+	"net/http"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Conversation struct {
+	ID            primitive.ObjectID `json:"_id"`
+	BuyerID       primitive.ObjectID `json:"buyerID"`
+	FarmerID      primitive.ObjectID `json:"farmerID"`
+	SubjectType   string             `json:"subjectType"`
+	SubjectID     primitive.ObjectID `json:"subjectID"`
+	LastMessage   string             `json:"lastMessage"`
+	LastMessageAt primitive.DateTime `json:"lastMessageAt"`
+	UnreadCount   int                `json:"unreadCount"`
+	CreatedAt     primitive.DateTime `json:"createdAt"`
+}
+
+type Message struct {
+	ID             primitive.ObjectID `json:"_id"`
+	ConversationID primitive.ObjectID `json:"conversationID"`
+	SenderID       primitive.ObjectID `json:"senderID"`
+	Message        string             `json:"message"`
+	Images         []string           `json:"images,omitempty"`
+	ReadAt         primitive.DateTime `json:"readAt,omitempty"`
+	CreatedAt      primitive.DateTime `json:"createdAt"`
+}
+
+// FromDomain counts the messages the reader has not read yet, admins only observe so their count stays zero.
+func FromDomain(domain conversations.Domain, conversationUC conversations.UseCase, readerID primitive.ObjectID) (Conversation, int, error) {
+	unreadCount := 0
+	if readerID == domain.BuyerID || readerID == domain.FarmerID {
+		total, statusCode, err := conversationUC.CountUnreadMessages(domain.ID, readerID)
+		if err != nil {
+			return Conversation{}, statusCode, err
+		}
+
+		unreadCount = total
+	}
+
+	return Conversation{
+		ID:            domain.ID,
+		BuyerID:       domain.BuyerID,
+		FarmerID:      domain.FarmerID,
+		SubjectType:   domain.SubjectType,
+		SubjectID:     domain.SubjectID,
+		LastMessage:   domain.LastMessage,
+		LastMessageAt: domain.LastMessageAt,
+		UnreadCount:   unreadCount,
+		CreatedAt:     domain.CreatedAt,
+	}, http.StatusOK, nil
+}
+
+func FromDomainArray(domain []conversations.Domain, conversationUC conversations.UseCase, readerID primitive.ObjectID) ([]Conversation, int, error) {
+	var response []Conversation
+	for _, value := range domain {
+		conversation, statusCode, err := FromDomain(value, conversationUC, readerID)
+		if err != nil {
+			return []Conversation{}, statusCode, err
+		}
+
+		response = append(response, conversation)
+	}
+
+	return response, http.StatusOK, nil
+}
+
+func FromMessageDomain(domain *conversations.Message) Message {
+	return Message{
+		ID:             domain.ID,
+		ConversationID: domain.ConversationID,
+		SenderID:       domain.SenderID,
+		Message:        domain.Message,
+		Images:         domain.Images,
+		ReadAt:         domain.ReadAt,
+		CreatedAt:      domain.CreatedAt,
+	}
+}
+
+func FromMessageDomainArray(domain []conversations.Message) []Message {
+	var response []Message
+	for _, value := range domain {
+		response = append(response, FromMessageDomain(&value))
+	}
+
+	return response
+}
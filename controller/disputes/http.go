@@ -0,0 +1,318 @@
+package disputes
+
+import (
+	"crop_connect/business/disputes"
+	"crop_connect/constant"
+	"crop_connect/controller/disputes/request"
+	"crop_connect/controller/disputes/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	disputeUC disputes.UseCase
+}
+
+func NewController(disputeUC disputes.UseCase) *Controller {
+	return &Controller{
+		disputeUC: disputeUC,
+	}
+}
+
+/*
+Create
+*/
+
+func (dc *Controller) Open(c echo.Context) error {
+	transactionID, err := primitive.ObjectIDFromHex(c.Param("transaction-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "transaction id tidak valid",
+		})
+	}
+
+	userInput := request.Open{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	images, statusCode, err := helper.GetCreateImageRequest(c, []string{"image1", "image2", "image3", "image4", "image5"})
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	inputDomain := userInput.ToDomain()
+	inputDomain.TransactionID = transactionID
+
+	dispute, statusCode, err := dc.disputeUC.Open(inputDomain, userID, images)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mengajukan sengketa",
+		Data:    response.FromDomain(&dispute),
+	})
+}
+
+/*
+Read
+*/
+
+func (dc *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"status", "createdAt", "updatedAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	disputeQuery := disputes.Query{
+		Skip:   queryPagination.Skip,
+		Limit:  queryPagination.Limit,
+		Sort:   queryPagination.Sort,
+		Order:  queryPagination.Order,
+		Status: queryParam.Status,
+	}
+
+	if token.Role == constant.RoleBuyer {
+		disputeQuery.BuyerID = userID
+	} else if token.Role == constant.RoleFarmer {
+		disputeQuery.FarmerID = userID
+	} else if token.Role == constant.RoleValidator {
+		disputeQuery.ValidatorID = userID
+	}
+
+	disputes, totalData, statusCode, err := dc.disputeUC.GetByPaginationAndQuery(disputeQuery)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan sengketa",
+		Data:       response.FromDomainArray(disputes),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (dc *Controller) GetByID(c echo.Context) error {
+	disputeID, err := primitive.ObjectIDFromHex(c.Param("dispute-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "dispute id tidak valid",
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	dispute, statusCode, err := dc.disputeUC.GetByID(disputeID, userID, token.Role)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan sengketa",
+		Data:    response.FromDomain(&dispute),
+	})
+}
+
+/*
+Update
+*/
+
+func (dc *Controller) AddMessage(c echo.Context) error {
+	disputeID, err := primitive.ObjectIDFromHex(c.Param("dispute-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "dispute id tidak valid",
+		})
+	}
+
+	userInput := request.Message{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	images, statusCode, err := helper.GetCreateImageRequest(c, []string{"image1", "image2", "image3"})
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	inputDomain := userInput.ToDomain()
+	inputDomain.SenderID = userID
+	inputDomain.SenderRole = token.Role
+
+	dispute, statusCode, err := dc.disputeUC.AddMessage(disputeID, inputDomain, images)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mengirim pesan sengketa",
+		Data:    response.FromDomain(&dispute),
+	})
+}
+
+func (dc *Controller) Resolve(c echo.Context) error {
+	disputeID, err := primitive.ObjectIDFromHex(c.Param("dispute-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "dispute id tidak valid",
+		})
+	}
+
+	userInput := request.Resolve{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	inputDomain := userInput.ToDomain()
+	inputDomain.ID = disputeID
+
+	dispute, statusCode, err := dc.disputeUC.Resolve(inputDomain, userID, token.Role)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil menyelesaikan sengketa",
+		Data:    response.FromDomain(&dispute),
+	})
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,137 @@
+package request
+
+import (
+	"crop_connect/business/disputes"
+	"crop_connect/helper"
+	"errors"
+	"strings"
+
+	"github.com/fatih/structs"
+	"github.com/go-playground/validator/v10"
+)
+
+type Open struct {
+	Reason      string `form:"reason" json:"reason" validate:"required"`
+	Description string `form:"description" json:"description" validate:"required"`
+}
+
+func (req *Open) ToDomain() *disputes.Domain {
+	return &disputes.Domain{
+		Reason:      req.Reason,
+		Description: req.Description,
+	}
+}
+
+func (req *Open) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type Message struct {
+	Message string `form:"message" json:"message" validate:"required"`
+}
+
+func (req *Message) ToDomain() *disputes.Message {
+	return &disputes.Message{
+		Message: req.Message,
+	}
+}
+
+func (req *Message) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type Resolve struct {
+	Outcome        string  `json:"outcome" validate:"required"`
+	RefundAmount   float64 `json:"refundAmount"`
+	ResolutionNote string  `json:"resolutionNote" validate:"required"`
+}
+
+func (req *Resolve) ToDomain() *disputes.Domain {
+	return &disputes.Domain{
+		Outcome:        req.Outcome,
+		RefundAmount:   req.RefundAmount,
+		ResolutionNote: req.ResolutionNote,
+	}
+}
+
+func (req *Resolve) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
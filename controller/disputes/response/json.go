@@ -0,0 +1,79 @@
+package response
+
+import (
+	"crop_connect/business/disputes"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Message struct {
+	ID         primitive.ObjectID `json:"_id"`
+	SenderID   primitive.ObjectID `json:"senderID"`
+	SenderRole string             `json:"senderRole"`
+	Message    string             `json:"message"`
+	Images     []string           `json:"images,omitempty"`
+	CreatedAt  primitive.DateTime `json:"createdAt"`
+}
+
+type Dispute struct {
+	ID             primitive.ObjectID `json:"_id"`
+	TransactionID  primitive.ObjectID `json:"transactionID"`
+	ShipmentID     primitive.ObjectID `json:"shipmentID"`
+	BuyerID        primitive.ObjectID `json:"buyerID"`
+	FarmerID       primitive.ObjectID `json:"farmerID"`
+	ValidatorID    primitive.ObjectID `json:"validatorID,omitempty"`
+	Reason         string             `json:"reason"`
+	Description    string             `json:"description"`
+	Evidence       []string           `json:"evidence"`
+	Messages       []Message          `json:"messages"`
+	Status         string             `json:"status"`
+	Outcome        string             `json:"outcome,omitempty"`
+	RefundAmount   float64            `json:"refundAmount,omitempty"`
+	ResolutionNote string             `json:"resolutionNote,omitempty"`
+	ResolverID     primitive.ObjectID `json:"resolverID,omitempty"`
+	CreatedAt      primitive.DateTime `json:"createdAt"`
+	ResolvedAt     primitive.DateTime `json:"resolvedAt,omitempty"`
+}
+
+func FromDomain(domain *disputes.Domain) Dispute {
+	messages := []Message{}
+	for _, message := range domain.Messages {
+		messages = append(messages, Message{
+			ID:         message.ID,
+			SenderID:   message.SenderID,
+			SenderRole: message.SenderRole,
+			Message:    message.Message,
+			Images:     message.Images,
+			CreatedAt:  message.CreatedAt,
+		})
+	}
+
+	return Dispute{
+		ID:             domain.ID,
+		TransactionID:  domain.TransactionID,
+		ShipmentID:     domain.ShipmentID,
+		BuyerID:        domain.BuyerID,
+		FarmerID:       domain.FarmerID,
+		ValidatorID:    domain.ValidatorID,
+		Reason:         domain.Reason,
+		Description:    domain.Description,
+		Evidence:       domain.Evidence,
+		Messages:       messages,
+		Status:         domain.Status,
+		Outcome:        domain.Outcome,
+		RefundAmount:   domain.RefundAmount,
+		ResolutionNote: domain.ResolutionNote,
+		ResolverID:     domain.ResolverID,
+		CreatedAt:      domain.CreatedAt,
+		ResolvedAt:     domain.ResolvedAt,
+	}
+}
+
+func FromDomainArray(domain []disputes.Domain) []Dispute {
+	var response []Dispute
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
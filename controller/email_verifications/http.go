@@ -0,0 +1,62 @@
+package email_verifications
+
+import (
+	emailVerifications "crop_connect/business/email_verifications"
+	"crop_connect/controller/email_verifications/request"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Controller struct {
+	emailVerificationUC emailVerifications.UseCase
+}
+
+func NewController(emailVerificationUC emailVerifications.UseCase) *Controller {
+	return &Controller{
+		emailVerificationUC: emailVerificationUC,
+	}
+}
+
+/*
+Create
+*/
+
+func (evc *Controller) Generate(c echo.Context) error {
+	userInput := request.Generate{}
+	c.Bind(&userInput)
+
+	if err := userInput.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   err,
+		})
+	}
+
+	statusCode, _ := evc.emailVerificationUC.Generate(userInput.Domain, userInput.Email)
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "jika email terdaftar dan belum diverifikasi, maka akan dikirimkan link untuk verifikasi",
+	})
+}
+
+/*
+Update
+*/
+
+func (evc *Controller) Verify(c echo.Context) error {
+	statusCode, err := evc.emailVerificationUC.Verify(c.Param("token"))
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "email berhasil diverifikasi",
+	})
+}
@@ -0,0 +1,83 @@
+package events
+
+import (
+	"crop_connect/business/events"
+	"crop_connect/controller/events/response"
+	"crop_connect/helper"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// heartbeatInterval keeps proxies from closing a stream that has no events for a while.
+const heartbeatInterval = 30 * time.Second
+
+type Controller struct {
+	eventHub events.Hub
+}
+
+func NewController(eventHub events.Hub) *Controller {
+	return &Controller{
+		eventHub: eventHub,
+	}
+}
+
+/*
+Read
+*/
+
+// Stream sends the events of the user as Server-Sent Events until the client disconnects or the hub shuts down.
+// Each event is named after its entity type and carries the id of the transition in the audit log.
+func (ec *Controller) Stream(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	stream, unsubscribe := ec.eventHub.Subscribe(userID)
+	defer unsubscribe()
+
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.WriteHeader(http.StatusOK)
+	res.Flush()
+
+	heartbeat := time.NewTicker(heartbeatInterval)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": ping\n\n"); err != nil {
+				return nil
+			}
+
+			res.Flush()
+		case event, ok := <-stream:
+			if !ok {
+				return nil
+			}
+
+			data, err := json.Marshal(response.FromDomain(&event))
+			if err != nil {
+				continue
+			}
+
+			if _, err := fmt.Fprintf(res, "id: %s\nevent: %s\ndata: %s\n\n", event.ID.Hex(), event.EntityType, data); err != nil {
+				return nil
+			}
+
+			res.Flush()
+		}
+	}
+}
@@ -0,0 +1,29 @@
+package response
+
+import (
+	"crop_connect/business/events"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Event struct {
+	ID         primitive.ObjectID `json:"_id"`
+	EntityType string             `json:"entityType"`
+	EntityID   primitive.ObjectID `json:"entityID"`
+	OldStatus  string             `json:"oldStatus,omitempty"`
+	NewStatus  string             `json:"newStatus"`
+	Reason     string             `json:"reason,omitempty"`
+	CreatedAt  primitive.DateTime `json:"createdAt"`
+}
+
+func FromDomain(domain *events.Domain) Event {
+	return Event{
+		ID:         domain.ID,
+		EntityType: domain.EntityType,
+		EntityID:   domain.EntityID,
+		OldStatus:  domain.OldStatus,
+		NewStatus:  domain.NewStatus,
+		Reason:     domain.Reason,
+		CreatedAt:  domain.CreatedAt,
+	}
+}
@@ -0,0 +1,79 @@
+package job_histories
+
+import (
+	jobHistories "crop_connect/business/job_histories"
+	"crop_connect/controller/job_histories/request"
+	"crop_connect/controller/job_histories/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Controller struct {
+	jobHistoryUC jobHistories.UseCase
+}
+
+func NewController(jobHistoryUC jobHistories.UseCase) *Controller {
+	return &Controller{
+		jobHistoryUC: jobHistoryUC,
+	}
+}
+
+/*
+Create
+*/
+
+/*
+Read
+*/
+
+func (jhc *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"name", "createdAt", "finishedAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	jobHistoryQuery := jobHistories.Query{
+		Skip:   queryPagination.Skip,
+		Limit:  queryPagination.Limit,
+		Sort:   queryPagination.Sort,
+		Order:  queryPagination.Order,
+		Name:   queryParam.Name,
+		Status: queryParam.Status,
+	}
+
+	jobHistories, totalData, statusCode, err := jhc.jobHistoryUC.GetByPaginationAndQuery(jobHistoryQuery)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan riwayat job",
+		Data:       response.FromDomainArray(jobHistories),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
@@ -0,0 +1,26 @@
+package request
+
+import (
+	"crop_connect/constant"
+	"errors"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FilterQuery struct {
+	Name   string
+	Status string
+}
+
+func QueryParamValidation(c echo.Context) (FilterQuery, error) {
+	filter := FilterQuery{
+		Name:   c.QueryParam("name"),
+		Status: c.QueryParam("status"),
+	}
+
+	if filter.Status != "" && filter.Status != constant.JobHistoryStatusSuccess && filter.Status != constant.JobHistoryStatusFailed {
+		return FilterQuery{}, errors.New("status hanya tersedia success dan failed")
+	}
+
+	return filter, nil
+}
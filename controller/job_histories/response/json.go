@@ -0,0 +1,38 @@
+package response
+
+import (
+	jobHistories "crop_connect/business/job_histories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type JobHistory struct {
+	ID         primitive.ObjectID `json:"_id"`
+	Name       string             `json:"name"`
+	Status     string             `json:"status"`
+	Affected   int                `json:"affected"`
+	Error      string             `json:"error,omitempty"`
+	CreatedAt  primitive.DateTime `json:"createdAt"`
+	FinishedAt primitive.DateTime `json:"finishedAt"`
+}
+
+func FromDomain(domain *jobHistories.Domain) JobHistory {
+	return JobHistory{
+		ID:         domain.ID,
+		Name:       domain.Name,
+		Status:     domain.Status,
+		Affected:   domain.Affected,
+		Error:      domain.Error,
+		CreatedAt:  domain.CreatedAt,
+		FinishedAt: domain.FinishedAt,
+	}
+}
+
+func FromDomainArray(domain []jobHistories.Domain) []JobHistory {
+	var response []JobHistory
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
@@ -0,0 +1,168 @@
+package notifications
+
+import (
+	"crop_connect/business/notifications"
+	"crop_connect/controller/notifications/request"
+	"crop_connect/controller/notifications/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	notificationUC notifications.UseCase
+}
+
+func NewController(notificationUC notifications.UseCase) *Controller {
+	return &Controller{
+		notificationUC: notificationUC,
+	}
+}
+
+/*
+Create
+*/
+
+/*
+Read
+*/
+
+func (nc *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"type", "createdAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	notificationQuery := notifications.Query{
+		Skip:   queryPagination.Skip,
+		Limit:  queryPagination.Limit,
+		Sort:   queryPagination.Sort,
+		Order:  queryPagination.Order,
+		UserID: userID,
+		IsRead: queryParam.IsRead,
+	}
+
+	notifications, totalData, statusCode, err := nc.notificationUC.GetByPaginationAndQuery(notificationQuery)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan notifikasi",
+		Data:       response.FromDomainArray(notifications),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (nc *Controller) CountUnread(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	total, statusCode, err := nc.notificationUC.CountUnread(userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil menghitung notifikasi yang belum dibaca",
+		Data: response.UnreadCount{
+			Total: total,
+		},
+	})
+}
+
+/*
+Update
+*/
+
+func (nc *Controller) MarkAsRead(c echo.Context) error {
+	notificationID, err := primitive.ObjectIDFromHex(c.Param("notification-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "notification id tidak valid",
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	statusCode, err := nc.notificationUC.MarkAsRead(notificationID, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "notifikasi berhasil ditandai sudah dibaca",
+	})
+}
+
+func (nc *Controller) MarkAllAsRead(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	statusCode, err := nc.notificationUC.MarkAllAsRead(userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "semua notifikasi berhasil ditandai sudah dibaca",
+	})
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"errors"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FilterQuery struct {
+	IsRead *bool
+}
+
+func QueryParamValidation(c echo.Context) (FilterQuery, error) {
+	filter := FilterQuery{}
+
+	if c.QueryParam("isRead") != "" {
+		isRead, err := strconv.ParseBool(c.QueryParam("isRead"))
+		if err != nil {
+			return FilterQuery{}, errors.New("isRead hanya tersedia true dan false")
+		}
+
+		filter.IsRead = &isRead
+	}
+
+	return filter, nil
+}
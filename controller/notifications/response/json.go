@@ -0,0 +1,44 @@
+package response
+
+import (
+	"crop_connect/business/notifications"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Notification struct {
+	ID          primitive.ObjectID `json:"_id"`
+	Type        string             `json:"type"`
+	Title       string             `json:"title"`
+	Message     string             `json:"message"`
+	ReferenceID primitive.ObjectID `json:"referenceID,omitempty"`
+	IsRead      bool               `json:"isRead"`
+	ReadAt      primitive.DateTime `json:"readAt,omitempty"`
+	CreatedAt   primitive.DateTime `json:"createdAt"`
+}
+
+func FromDomain(domain *notifications.Domain) Notification {
+	return Notification{
+		ID:          domain.ID,
+		Type:        domain.Type,
+		Title:       domain.Title,
+		Message:     domain.Message,
+		ReferenceID: domain.ReferenceID,
+		IsRead:      domain.IsRead,
+		ReadAt:      domain.ReadAt,
+		CreatedAt:   domain.CreatedAt,
+	}
+}
+
+func FromDomainArray(domain []notifications.Domain) []Notification {
+	var response []Notification
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
+
+type UnreadCount struct {
+	Total int `json:"total"`
+}
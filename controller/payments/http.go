@@ -0,0 +1,186 @@
+package payments
+
+import (
+	"crop_connect/business/payments"
+	"crop_connect/business/transactions"
+	"crop_connect/constant"
+	"crop_connect/controller/payments/response"
+	"crop_connect/helper"
+	"io"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	paymentUC     payments.UseCase
+	transactionUC transactions.UseCase
+}
+
+func NewController(paymentUC payments.UseCase, transactionUC transactions.UseCase) *Controller {
+	return &Controller{
+		paymentUC:     paymentUC,
+		transactionUC: transactionUC,
+	}
+}
+
+/*
+Create
+*/
+
+func (pc *Controller) Create(c echo.Context) error {
+	transactionID, err := primitive.ObjectIDFromHex(c.Param("transaction-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "transaction id tidak valid",
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	payment, statusCode, err := pc.paymentUC.Create(transactionID, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "tagihan pembayaran berhasil dibuat",
+		Data:    response.FromDomain(&payment),
+	})
+}
+
+/*
+Read
+*/
+
+func (pc *Controller) GetByTransactionID(c echo.Context) error {
+	transactionID, err := primitive.ObjectIDFromHex(c.Param("transaction-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "transaction id tidak valid",
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	buyerID := primitive.NilObjectID
+	farmerID := primitive.NilObjectID
+
+	if token.Role == constant.RoleBuyer {
+		buyerID = userID
+	} else if token.Role == constant.RoleFarmer {
+		farmerID = userID
+	}
+
+	_, statusCode, err := pc.transactionUC.GetByIDAndBuyerIDOrFarmerID(transactionID, buyerID, farmerID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	payments, statusCode, err := pc.paymentUC.GetByTransactionID(transactionID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan pembayaran",
+		Data:    response.FromDomainArray(payments),
+	})
+}
+
+/*
+Update
+*/
+
+func (pc *Controller) Webhook(c echo.Context) error {
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "body tidak valid",
+		})
+	}
+
+	statusCode, err := pc.paymentUC.HandleCallback(c.Request().Header.Get("X-Callback-Token"), body)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "callback berhasil diproses",
+	})
+}
+
+func (pc *Controller) Refund(c echo.Context) error {
+	paymentID, err := primitive.ObjectIDFromHex(c.Param("payment-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "payment id tidak valid",
+		})
+	}
+
+	adminID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	payment, statusCode, err := pc.paymentUC.Refund(paymentID, adminID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "dana berhasil dikembalikan",
+		Data:    response.FromDomain(&payment),
+	})
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,50 @@
+package response
+
+import (
+	"crop_connect/business/payments"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Payment struct {
+	ID             primitive.ObjectID `json:"_id"`
+	TransactionID  primitive.ObjectID `json:"transactionID"`
+	Gateway        string             `json:"gateway"`
+	ExternalID     string             `json:"externalID"`
+	PaymentURL     string             `json:"paymentURL"`
+	Amount         float64            `json:"amount"`
+	RefundedAmount float64            `json:"refundedAmount,omitempty"`
+	IsTopUp        bool               `json:"isTopUp"`
+	Status         string             `json:"status"`
+	ExpiredAt      primitive.DateTime `json:"expiredAt"`
+	PaidAt         primitive.DateTime `json:"paidAt,omitempty"`
+	RefundedAt     primitive.DateTime `json:"refundedAt,omitempty"`
+	CreatedAt      primitive.DateTime `json:"createdAt"`
+}
+
+func FromDomain(domain *payments.Domain) Payment {
+	return Payment{
+		ID:             domain.ID,
+		TransactionID:  domain.TransactionID,
+		Gateway:        domain.Gateway,
+		ExternalID:     domain.ExternalID,
+		PaymentURL:     domain.PaymentURL,
+		Amount:         domain.Amount,
+		RefundedAmount: domain.RefundedAmount,
+		IsTopUp:        domain.IsTopUp,
+		Status:         domain.Status,
+		ExpiredAt:      domain.ExpiredAt,
+		PaidAt:         domain.PaidAt,
+		RefundedAt:     domain.RefundedAt,
+		CreatedAt:      domain.CreatedAt,
+	}
+}
+
+func FromDomainArray(domain []payments.Domain) []Payment {
+	var response []Payment
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
@@ -0,0 +1,32 @@
+package policies
+
+import (
+	"crop_connect/business/policies"
+	"crop_connect/controller/policies/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+type Controller struct {
+	policyUC policies.UseCase
+}
+
+func NewController(policyUC policies.UseCase) *Controller {
+	return &Controller{
+		policyUC: policyUC,
+	}
+}
+
+/*
+Read
+*/
+
+func (pc *Controller) GetMatrix(c echo.Context) error {
+	return c.JSON(http.StatusOK, helper.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "berhasil mendapatkan daftar hak akses",
+		Data:    response.FromDomainArray(pc.policyUC.GetMatrix()),
+	})
+}
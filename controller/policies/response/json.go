@@ -0,0 +1,26 @@
+package response
+
+import (
+	"crop_connect/business/policies"
+)
+
+type Policy struct {
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+func FromDomain(domain *policies.Domain) Policy {
+	return Policy{
+		Role:        domain.Role,
+		Permissions: domain.Permissions,
+	}
+}
+
+func FromDomainArray(domain []policies.Domain) []Policy {
+	var response []Policy
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
@@ -298,6 +298,56 @@ func (pc *Controller) GetByPaginationAndQuery(c echo.Context) error {
 	})
 }
 
+func (pc *Controller) GetQueue(c echo.Context) error {
+	validatorID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	queryPagination, err := helper.PaginationToQuery(c, []string{"name", "plantingArea", "estimatedTotalHarvest", "createdAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	query := proposals.Query{
+		Skip:        queryPagination.Skip,
+		Limit:       queryPagination.Limit,
+		Sort:        queryPagination.Sort,
+		Order:       queryPagination.Order,
+		ValidatorID: validatorID,
+		Status:      constant.ProposalStatusPending,
+	}
+
+	proposals, totalData, statusCode, err := pc.proposalUC.GetByPaginationAndQuery(query)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	proposalResponse, statusCode, err := response.FromDomainArrayToAdmin(proposals, pc.userUC, pc.commodityUC, pc.regionUC)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, helper.BaseResponse{
+		Status:     http.StatusOK,
+		Message:    "berhasil mendapatkan antrean proposal",
+		Data:       proposalResponse,
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
 func (pc *Controller) GetByID(c echo.Context) error {
 	proposalID, err := primitive.ObjectIDFromHex(c.Param("proposal-id"))
 	if err != nil {
@@ -496,6 +546,48 @@ func (pc *Controller) ValidateByValidator(c echo.Context) error {
 	})
 }
 
+func (pc *Controller) Assign(c echo.Context) error {
+	id, err := primitive.ObjectIDFromHex(c.Param("proposal-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id proposal tidak valid",
+		})
+	}
+
+	userInput := request.Assign{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	validatorID, err := primitive.ObjectIDFromHex(userInput.ValidatorID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id validator tidak valid",
+		})
+	}
+
+	statusCode, err := pc.proposalUC.Assign(id, validatorID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(http.StatusOK, helper.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "proposal berhasil ditugaskan",
+	})
+}
+
 /*
 Delete
 */
@@ -25,6 +25,7 @@ type Admin struct {
 	PlantingArea          float64                     `json:"plantingArea"`
 	Address               string                      `json:"address"`
 	IsAvailable           bool                        `json:"isAvailable"`
+	RemainingQuantity     float64                     `json:"remainingQuantity"`
 	CreatedAt             primitive.DateTime          `json:"createdAt"`
 	UpdatedAt             primitive.DateTime          `json:"updatedAt,omitempty"`
 	DeletedAt             primitive.DateTime          `json:"deletedAt,omitempty"`
@@ -66,6 +67,7 @@ func FromDomainToAdmin(domain *proposals.Domain, userUC users.UseCase, commodity
 		PlantingArea:          domain.PlantingArea,
 		Address:               domain.Address,
 		IsAvailable:           domain.IsAvailable,
+		RemainingQuantity:     domain.RemainingQuantity,
 		CreatedAt:             domain.CreatedAt,
 		UpdatedAt:             domain.UpdatedAt,
 		DeletedAt:             domain.DeletedAt,
@@ -94,6 +96,7 @@ type Buyer struct {
 	PlantingArea          float64            `json:"plantingArea"`
 	Address               string             `json:"address"`
 	IsAvailable           bool               `json:"isAvailable"`
+	RemainingQuantity     float64            `json:"remainingQuantity"`
 }
 
 func FromDomainToBuyer(domain *proposals.Domain) Buyer {
@@ -106,6 +109,7 @@ func FromDomainToBuyer(domain *proposals.Domain) Buyer {
 		PlantingArea:          domain.PlantingArea,
 		Address:               domain.Address,
 		IsAvailable:           domain.IsAvailable,
+		RemainingQuantity:     domain.RemainingQuantity,
 	}
 }
 
@@ -129,6 +133,7 @@ type ProposalWithCommodity struct {
 	PlantingArea          float64                     `json:"plantingArea"`
 	Address               string                      `json:"address"`
 	IsAvailable           bool                        `json:"isAvailable"`
+	RemainingQuantity     float64                     `json:"remainingQuantity"`
 	Status                string                      `json:"status"`
 	CreatedAt             primitive.DateTime          `json:"createdAt"`
 }
@@ -160,6 +165,7 @@ func FromDomainToProposalWithCommodity(domain *proposals.Domain, userUC users.Us
 		PlantingArea:          domain.PlantingArea,
 		Address:               domain.Address,
 		IsAvailable:           domain.IsAvailable,
+		RemainingQuantity:     domain.RemainingQuantity,
 		Status:                domain.Status,
 		CreatedAt:             domain.CreatedAt,
 	}, http.StatusOK, nil
@@ -0,0 +1,143 @@
+package ratings
+
+import (
+	"crop_connect/business/ratings"
+	"crop_connect/controller/ratings/request"
+	"crop_connect/controller/ratings/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	ratingUC ratings.UseCase
+}
+
+func NewController(ratingUC ratings.UseCase) *Controller {
+	return &Controller{
+		ratingUC: ratingUC,
+	}
+}
+
+/*
+Create
+*/
+
+func (rc *Controller) Create(c echo.Context) error {
+	transactionID, err := primitive.ObjectIDFromHex(c.Param("transaction-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "transaction id tidak valid",
+		})
+	}
+
+	userInput := request.Create{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	inputDomain := userInput.ToDomain()
+	inputDomain.TransactionID = transactionID
+
+	rating, statusCode, err := rc.ratingUC.Create(inputDomain, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil memberikan penilaian",
+		Data:    response.FromDomain(&rating),
+	})
+}
+
+/*
+Read
+*/
+
+func (rc *Controller) GetByFarmerID(c echo.Context) error {
+	farmerID, err := primitive.ObjectIDFromHex(c.Param("farmer-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id petani tidak valid",
+		})
+	}
+
+	return rc.getByQuery(c, ratings.Query{
+		FarmerID: farmerID,
+	})
+}
+
+func (rc *Controller) GetByCommodityID(c echo.Context) error {
+	commodityID, err := primitive.ObjectIDFromHex(c.Param("commodity-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id komoditas tidak valid",
+		})
+	}
+
+	return rc.getByQuery(c, ratings.Query{
+		CommodityID: commodityID,
+	})
+}
+
+func (rc *Controller) getByQuery(c echo.Context, ratingQuery ratings.Query) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"farmerRating", "commodityRating", "createdAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	ratingQuery.Skip = queryPagination.Skip
+	ratingQuery.Limit = queryPagination.Limit
+	ratingQuery.Sort = queryPagination.Sort
+	ratingQuery.Order = queryPagination.Order
+
+	ratings, totalData, statusCode, err := rc.ratingUC.GetByPaginationAndQuery(ratingQuery)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan penilaian",
+		Data:       response.FromDomainArray(ratings),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
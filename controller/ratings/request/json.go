@@ -0,0 +1,55 @@
+package request
+
+import (
+	"crop_connect/business/ratings"
+	"crop_connect/helper"
+	"errors"
+	"strings"
+
+	"github.com/fatih/structs"
+	"github.com/go-playground/validator/v10"
+)
+
+type Create struct {
+	FarmerRating    int    `json:"farmerRating" validate:"required"`
+	CommodityRating int    `json:"commodityRating" validate:"required"`
+	Review          string `json:"review"`
+}
+
+func (req *Create) ToDomain() *ratings.Domain {
+	return &ratings.Domain{
+		FarmerRating:    req.FarmerRating,
+		CommodityRating: req.CommodityRating,
+		Review:          req.Review,
+	}
+}
+
+func (req *Create) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
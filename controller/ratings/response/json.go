@@ -0,0 +1,42 @@
+package response
+
+import (
+	"crop_connect/business/ratings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Rating struct {
+	ID              primitive.ObjectID `json:"_id"`
+	TransactionID   primitive.ObjectID `json:"transactionID"`
+	BuyerID         primitive.ObjectID `json:"buyerID"`
+	FarmerID        primitive.ObjectID `json:"farmerID"`
+	CommodityID     primitive.ObjectID `json:"commodityID"`
+	FarmerRating    int                `json:"farmerRating"`
+	CommodityRating int                `json:"commodityRating"`
+	Review          string             `json:"review,omitempty"`
+	CreatedAt       primitive.DateTime `json:"createdAt"`
+}
+
+func FromDomain(domain *ratings.Domain) Rating {
+	return Rating{
+		ID:              domain.ID,
+		TransactionID:   domain.TransactionID,
+		BuyerID:         domain.BuyerID,
+		FarmerID:        domain.FarmerID,
+		CommodityID:     domain.CommodityID,
+		FarmerRating:    domain.FarmerRating,
+		CommodityRating: domain.CommodityRating,
+		Review:          domain.Review,
+		CreatedAt:       domain.CreatedAt,
+	}
+}
+
+func FromDomainArray(domain []ratings.Domain) []Rating {
+	var response []Rating
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
@@ -0,0 +1,257 @@
+package shipments
+
+import (
+	"crop_connect/business/shipments"
+	"crop_connect/constant"
+	"crop_connect/controller/shipments/request"
+	"crop_connect/controller/shipments/response"
+	"crop_connect/helper"
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	shipmentUC shipments.UseCase
+}
+
+func NewController(shipmentUC shipments.UseCase) *Controller {
+	return &Controller{
+		shipmentUC: shipmentUC,
+	}
+}
+
+/*
+Create
+*/
+
+/*
+Read
+*/
+
+func (sc *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"status", "pickupDate", "createdAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak valid",
+		})
+	}
+
+	shipmentQuery := shipments.Query{
+		Skip:   queryPagination.Skip,
+		Limit:  queryPagination.Limit,
+		Sort:   queryPagination.Sort,
+		Order:  queryPagination.Order,
+		Status: queryParam.Status,
+	}
+
+	if token.Role == constant.RoleFarmer {
+		shipmentQuery.FarmerID = userID
+	} else {
+		shipmentQuery.BuyerID = userID
+	}
+
+	shipments, totalData, statusCode, err := sc.shipmentUC.GetByPaginationAndQuery(shipmentQuery)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan pengiriman",
+		Data:       response.FromDomainArray(shipments),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (sc *Controller) GetByID(c echo.Context) error {
+	shipmentID, err := primitive.ObjectIDFromHex(c.Param("shipment-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "shipment id tidak valid",
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	shipment, statusCode, err := sc.shipmentUC.GetByID(shipmentID, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan pengiriman",
+		Data:    response.FromDomain(&shipment),
+	})
+}
+
+func (sc *Controller) GetByTransactionID(c echo.Context) error {
+	transactionID, err := primitive.ObjectIDFromHex(c.Param("transaction-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "transaction id tidak valid",
+		})
+	}
+
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	shipment, statusCode, err := sc.shipmentUC.GetByTransactionID(transactionID, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan pengiriman",
+		Data:    response.FromDomain(&shipment),
+	})
+}
+
+/*
+Update
+*/
+
+func (sc *Controller) Dispatch(c echo.Context) error {
+	shipmentID, err := primitive.ObjectIDFromHex(c.Param("shipment-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "shipment id tidak valid",
+		})
+	}
+
+	farmerID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userInput := request.Dispatch{}
+	c.Bind(&userInput)
+
+	if err := userInput.Validate(); err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   err,
+		})
+	}
+
+	inputDomain, err := userInput.ToDomain()
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	inputDomain.ID = shipmentID
+
+	shipment, statusCode, err := sc.shipmentUC.Dispatch(inputDomain, farmerID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "pengiriman berhasil dikirim",
+		Data:    response.FromDomain(&shipment),
+	})
+}
+
+func (sc *Controller) ConfirmReceipt(c echo.Context) error {
+	shipmentID, err := primitive.ObjectIDFromHex(c.Param("shipment-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "shipment id tidak valid",
+		})
+	}
+
+	buyerID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userInput := request.ConfirmReceipt{}
+	c.Bind(&userInput)
+
+	inputDomain := userInput.ToDomain()
+	inputDomain.ID = shipmentID
+
+	shipment, statusCode, err := sc.shipmentUC.ConfirmReceipt(inputDomain, buyerID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "pengiriman berhasil dikonfirmasi",
+		Data:    response.FromDomain(&shipment),
+	})
+}
+
+/*
+Delete
+*/
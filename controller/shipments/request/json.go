@@ -0,0 +1,74 @@
+package request
+
+import (
+	"crop_connect/business/shipments"
+	"crop_connect/helper"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/fatih/structs"
+	"github.com/go-playground/validator/v10"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Dispatch struct {
+	PickupDate      string  `form:"pickupDate" json:"pickupDate" validate:"required"`
+	Courier         string  `form:"courier" json:"courier" validate:"required"`
+	TrackingNumber  string  `form:"trackingNumber" json:"trackingNumber" validate:"required"`
+	DeliveredWeight float64 `form:"deliveredWeight" json:"deliveredWeight" validate:"required,gt=0"`
+}
+
+func (req *Dispatch) ToDomain() (*shipments.Domain, error) {
+	pickupDate, err := time.Parse("2006-01-02", req.PickupDate)
+	if err != nil {
+		return &shipments.Domain{}, errors.New("pickupDate harus berupa tanggal")
+	}
+
+	return &shipments.Domain{
+		PickupDate:      primitive.NewDateTimeFromTime(pickupDate),
+		Courier:         req.Courier,
+		TrackingNumber:  req.TrackingNumber,
+		DeliveredWeight: req.DeliveredWeight,
+	}, nil
+}
+
+func (req *Dispatch) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type ConfirmReceipt struct {
+	ReceiptNote string `form:"receiptNote" json:"receiptNote"`
+}
+
+func (req *ConfirmReceipt) ToDomain() *shipments.Domain {
+	return &shipments.Domain{
+		ReceiptNote: req.ReceiptNote,
+	}
+}
@@ -0,0 +1,27 @@
+package request
+
+import (
+	"crop_connect/constant"
+	"crop_connect/util"
+	"fmt"
+
+	"github.com/labstack/echo/v4"
+)
+
+type FilterQuery struct {
+	Status string
+}
+
+func QueryParamValidation(c echo.Context) (FilterQuery, error) {
+	filter := FilterQuery{
+		Status: c.QueryParam("status"),
+	}
+
+	if filter.Status != "" {
+		if !util.CheckStringOnArray([]string{constant.ShipmentStatusWaitingPickup, constant.ShipmentStatusDispatched, constant.ShipmentStatusDelivered}, filter.Status) {
+			return FilterQuery{}, fmt.Errorf("status tersedia hanya %s, %s, dan %s", constant.ShipmentStatusWaitingPickup, constant.ShipmentStatusDispatched, constant.ShipmentStatusDelivered)
+		}
+	}
+
+	return filter, nil
+}
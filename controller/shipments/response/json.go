@@ -0,0 +1,54 @@
+package response
+
+import (
+	"crop_connect/business/shipments"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Shipment struct {
+	ID              primitive.ObjectID `json:"_id"`
+	TransactionID   primitive.ObjectID `json:"transactionID"`
+	BatchID         primitive.ObjectID `json:"batchID"`
+	HarvestID       primitive.ObjectID `json:"harvestID"`
+	FarmerID        primitive.ObjectID `json:"farmerID"`
+	BuyerID         primitive.ObjectID `json:"buyerID"`
+	Status          string             `json:"status"`
+	PickupDate      primitive.DateTime `json:"pickupDate,omitempty"`
+	Courier         string             `json:"courier,omitempty"`
+	TrackingNumber  string             `json:"trackingNumber,omitempty"`
+	DeliveredWeight float64            `json:"deliveredWeight,omitempty"`
+	DispatchedAt    primitive.DateTime `json:"dispatchedAt,omitempty"`
+	ReceivedAt      primitive.DateTime `json:"receivedAt,omitempty"`
+	ReceiptNote     string             `json:"receiptNote,omitempty"`
+	CreatedAt       primitive.DateTime `json:"createdAt"`
+}
+
+func FromDomain(domain *shipments.Domain) Shipment {
+	return Shipment{
+		ID:              domain.ID,
+		TransactionID:   domain.TransactionID,
+		BatchID:         domain.BatchID,
+		HarvestID:       domain.HarvestID,
+		FarmerID:        domain.FarmerID,
+		BuyerID:         domain.BuyerID,
+		Status:          domain.Status,
+		PickupDate:      domain.PickupDate,
+		Courier:         domain.Courier,
+		TrackingNumber:  domain.TrackingNumber,
+		DeliveredWeight: domain.DeliveredWeight,
+		DispatchedAt:    domain.DispatchedAt,
+		ReceivedAt:      domain.ReceivedAt,
+		ReceiptNote:     domain.ReceiptNote,
+		CreatedAt:       domain.CreatedAt,
+	}
+}
+
+func FromDomainArray(domain []shipments.Domain) []Shipment {
+	var response []Shipment
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
@@ -14,17 +14,19 @@ import (
 )
 
 type Create struct {
-	TransactionType string `form:"transactionType" json:"transactionType" validate:"required"`
-	ProposalID      string `form:"proposalID" json:"proposalID"`
-	BatchID         string `form:"batchID" json:"batchID"`
-	RegionID        string `form:"regionID" json:"regionID" validate:"required"`
-	Address         string `form:"address" json:"address" validate:"required"`
+	TransactionType string  `form:"transactionType" json:"transactionType" validate:"required"`
+	ProposalID      string  `form:"proposalID" json:"proposalID"`
+	BatchID         string  `form:"batchID" json:"batchID"`
+	RegionID        string  `form:"regionID" json:"regionID" validate:"required"`
+	Address         string  `form:"address" json:"address" validate:"required"`
+	Quantity        float64 `form:"quantity" json:"quantity" validate:"required,gt=0"`
 }
 
 func (req *Create) ToDomain() (*transactions.Domain, error) {
 	domain := transactions.Domain{
 		TransactionType: req.TransactionType,
 		Address:         req.Address,
+		Quantity:        req.Quantity,
 	}
 
 	var err error
@@ -97,3 +99,43 @@ func (req *Decision) ToDomain() *transactions.Domain {
 		Status: req.Decision,
 	}
 }
+
+type Offer struct {
+	PricePerKg float64 `form:"pricePerKg" json:"pricePerKg" validate:"required,gt=0"`
+}
+
+func (req *Offer) ToDomain() *transactions.Offer {
+	return &transactions.Offer{
+		PricePerKg: req.PricePerKg,
+	}
+}
+
+func (req *Offer) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
@@ -28,7 +28,11 @@ type Buyer struct {
 	Address         string                             `json:"address"`
 	TransactionType string                             `json:"transactionType"`
 	Status          string                             `json:"status"`
+	Quantity        float64                            `json:"quantity"`
+	PricePerKg      float64                            `json:"pricePerKg"`
 	TotalPrice      float64                            `json:"totalPrice"`
+	Settlement      *Settlement                        `json:"settlement"`
+	Offers          []Offer                            `json:"offers"`
 	CreatedAt       primitive.DateTime                 `json:"createdAt"`
 }
 
@@ -67,7 +71,11 @@ func FromDomainToBuyer(domain *transactions.Domain, batchUC batchs.UseCase, prop
 		Address:         domain.Address,
 		Status:          domain.Status,
 		TransactionType: domain.TransactionType,
+		Quantity:        domain.Quantity,
+		PricePerKg:      domain.PricePerKg,
 		TotalPrice:      domain.TotalPrice,
+		Settlement:      FromDomainToSettlement(domain),
+		Offers:          FromDomainArrayToOffer(domain.Offers),
 		CreatedAt:       domain.CreatedAt,
 	}, http.StatusOK, nil
 }
@@ -96,7 +104,11 @@ type All struct {
 	Address         string                      `json:"address"`
 	Status          string                      `json:"status"`
 	TransactionType string                      `json:"transactionType"`
+	Quantity        float64                     `json:"quantity"`
+	PricePerKg      float64                     `json:"pricePerKg"`
 	TotalPrice      float64                     `json:"totalPrice"`
+	Settlement      *Settlement                 `json:"settlement"`
+	Offers          []Offer                     `json:"offers"`
 	CreatedAt       primitive.DateTime          `json:"createdAt"`
 }
 
@@ -145,7 +157,11 @@ func FromDomainToFarmer(domain *transactions.Domain, batchUC batchs.UseCase, pro
 		Address:         domain.Address,
 		TransactionType: domain.TransactionType,
 		Status:          domain.Status,
+		Quantity:        domain.Quantity,
+		PricePerKg:      domain.PricePerKg,
 		TotalPrice:      domain.TotalPrice,
+		Settlement:      FromDomainToSettlement(domain),
+		Offers:          FromDomainArrayToOffer(domain.Offers),
 		CreatedAt:       domain.CreatedAt,
 	}, http.StatusOK, nil
 }
@@ -242,6 +258,51 @@ func FromDomainToTransactionStatisticForCommodityPage(totalTransaction int, tota
 	}
 }
 
+type Offer struct {
+	AuthorID   primitive.ObjectID `json:"authorID"`
+	Role       string             `json:"role"`
+	PricePerKg float64            `json:"pricePerKg"`
+	CreatedAt  primitive.DateTime `json:"createdAt"`
+}
+
+func FromDomainArrayToOffer(domain []transactions.Offer) []Offer {
+	offers := []Offer{}
+	for _, offer := range domain {
+		offers = append(offers, Offer{
+			AuthorID:   offer.AuthorID,
+			Role:       offer.Role,
+			PricePerKg: offer.PricePerKg,
+			CreatedAt:  offer.CreatedAt,
+		})
+	}
+
+	return offers
+}
+
+type Settlement struct {
+	EstimatedQuantity float64            `json:"estimatedQuantity"`
+	ActualQuantity    float64            `json:"actualQuantity"`
+	EstimatedPrice    float64            `json:"estimatedPrice"`
+	FinalPrice        float64            `json:"finalPrice"`
+	PriceDifference   float64            `json:"priceDifference"`
+	SettledAt         primitive.DateTime `json:"settledAt"`
+}
+
+func FromDomainToSettlement(domain *transactions.Domain) *Settlement {
+	if domain.SettledAt == 0 {
+		return nil
+	}
+
+	return &Settlement{
+		EstimatedQuantity: domain.Quantity,
+		ActualQuantity:    domain.ActualQuantity,
+		EstimatedPrice:    domain.TotalPrice,
+		FinalPrice:        domain.FinalPrice,
+		PriceDifference:   domain.PriceDifference,
+		SettledAt:         domain.SettledAt,
+	}
+}
+
 type TransactionAnnuals struct {
 	ID              primitive.ObjectID                 `json:"_id"`
 	Region          regionResponse.Response            `json:"region"`
@@ -252,7 +313,11 @@ type TransactionAnnuals struct {
 	Address         string                             `json:"address"`
 	TransactionType string                             `json:"transactionType"`
 	Status          string                             `json:"status"`
+	Quantity        float64                            `json:"quantity"`
+	PricePerKg      float64                            `json:"pricePerKg"`
 	TotalPrice      float64                            `json:"totalPrice"`
+	Settlement      *Settlement                        `json:"settlement"`
+	Offers          []Offer                            `json:"offers"`
 	CreatedAt       primitive.DateTime                 `json:"createdAt"`
 }
 
@@ -279,7 +344,11 @@ func ConvertToTransactionResponse(domain *transactions.Domain, batchUC batchs.Us
 		Address:         domain.Address,
 		TransactionType: domain.TransactionType,
 		Status:          domain.Status,
+		Quantity:        domain.Quantity,
+		PricePerKg:      domain.PricePerKg,
 		TotalPrice:      domain.TotalPrice,
+		Settlement:      FromDomainToSettlement(domain),
+		Offers:          FromDomainArrayToOffer(domain.Offers),
 		CreatedAt:       domain.CreatedAt,
 	}
 
@@ -302,7 +371,7 @@ func ConvertToTransactionResponse(domain *transactions.Domain, batchUC batchs.Us
 		response.Commodity = commodityForResponse
 		response.Proposal = proposalResponse.FromDomainToBuyer(&proposal)
 
-		if domain.Status == constant.TransactionStatusAccepted {
+		if domain.Status == constant.TransactionStatusAccepted || domain.Status == constant.TransactionStatusPaid || domain.Status == constant.TransactionStatusRefunded || domain.Status == constant.TransactionStatusPartiallyRefunded {
 			batch, statusCode, err := batchUC.GetByID(domain.BatchID)
 			if err != nil {
 				return TransactionAnnuals{}, statusCode, err
@@ -455,13 +455,21 @@ func (trc *Controller) UpdateNotes(c echo.Context) error {
 		})
 	}
 
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
 	userInput := request.UpdateNotes{}
 	c.Bind(&userInput)
 
 	inputDomain := userInput.ToDomain()
 	inputDomain.ID = treatmentRecordID
 
-	_, statusCode, err := trc.treatmentRecordUC.UpdateNotes(inputDomain)
+	_, statusCode, err := trc.treatmentRecordUC.UpdateNotes(inputDomain, userID)
 	if err != nil {
 		return c.JSON(statusCode, helper.BaseResponse{
 			Status:  statusCode,
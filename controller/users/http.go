@@ -1,7 +1,9 @@
 package users
 
 import (
+	emailVerifications "crop_connect/business/email_verifications"
 	"crop_connect/business/regions"
+	"crop_connect/business/sessions"
 	"crop_connect/business/users"
 	"crop_connect/constant"
 	"crop_connect/controller/users/request"
@@ -14,14 +16,18 @@ import (
 )
 
 type Controller struct {
-	userUC   users.UseCase
-	regionUC regions.UseCase
+	userUC              users.UseCase
+	regionUC            regions.UseCase
+	sessionUC           sessions.UseCase
+	emailVerificationUC emailVerifications.UseCase
 }
 
-func NewController(userUC users.UseCase, regionUC regions.UseCase) *Controller {
+func NewController(userUC users.UseCase, regionUC regions.UseCase, sessionUC sessions.UseCase, emailVerificationUC emailVerifications.UseCase) *Controller {
 	return &Controller{
-		userUC:   userUC,
-		regionUC: regionUC,
+		userUC:              userUC,
+		regionUC:            regionUC,
+		sessionUC:           sessionUC,
+		emailVerificationUC: emailVerificationUC,
 	}
 }
 
@@ -49,7 +55,7 @@ func (uc *Controller) Register(c echo.Context) error {
 		})
 	}
 
-	token, statusCode, err := uc.userUC.Register(inputDomain)
+	user, statusCode, err := uc.userUC.Register(inputDomain)
 	if err != nil {
 		return c.JSON(statusCode, helper.BaseResponse{
 			Status:  statusCode,
@@ -57,10 +63,21 @@ func (uc *Controller) Register(c echo.Context) error {
 		})
 	}
 
+	// a failed email is not fatal, the user can ask for a new link
+	uc.emailVerificationUC.Generate(userInput.Domain, user.Email)
+
+	userResponse, _, err := response.FromDomain(user, uc.regionUC)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, helper.BaseResponse{
+			Status:  http.StatusInternalServerError,
+			Message: err.Error(),
+		})
+	}
+
 	return c.JSON(statusCode, helper.BaseResponse{
 		Status:  statusCode,
-		Message: "registrasi sukses",
-		Data:    token,
+		Message: "registrasi sukses, silakan cek email untuk verifikasi akun",
+		Data:    userResponse,
 	})
 }
 
@@ -84,7 +101,7 @@ func (uc *Controller) RegisterValidator(c echo.Context) error {
 		})
 	}
 
-	token, statusCode, err := uc.userUC.RegisterValidator(inputDomain)
+	tokenPair, statusCode, err := uc.userUC.RegisterValidator(inputDomain)
 	if err != nil {
 		return c.JSON(statusCode, helper.BaseResponse{
 			Status:  statusCode,
@@ -95,7 +112,7 @@ func (uc *Controller) RegisterValidator(c echo.Context) error {
 	return c.JSON(statusCode, helper.BaseResponse{
 		Status:  statusCode,
 		Message: "registrasi validator sukses",
-		Data:    token,
+		Data:    response.FromTokenPair(tokenPair),
 	})
 }
 
@@ -116,7 +133,45 @@ func (uc *Controller) Login(c echo.Context) error {
 		})
 	}
 
-	token, statusCode, err := uc.userUC.Login(userInput.ToDomain())
+	tokenPair, twoFactorToken, statusCode, err := uc.userUC.Login(userInput.ToDomain())
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	if twoFactorToken != "" {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: "masukkan kode autentikasi dua faktor",
+			Data: response.TwoFactorChallenge{
+				TwoFactorRequired: true,
+				TwoFactorToken:    twoFactorToken,
+			},
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "login sukses",
+		Data:    response.FromTokenPair(tokenPair),
+	})
+}
+
+func (uc *Controller) LoginTwoFactor(c echo.Context) error {
+	userInput := request.LoginTwoFactor{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	tokenPair, statusCode, err := uc.userUC.LoginTwoFactor(userInput.TwoFactorToken, userInput.Code)
 	if err != nil {
 		return c.JSON(statusCode, helper.BaseResponse{
 			Status:  statusCode,
@@ -127,7 +182,73 @@ func (uc *Controller) Login(c echo.Context) error {
 	return c.JSON(statusCode, helper.BaseResponse{
 		Status:  statusCode,
 		Message: "login sukses",
-		Data:    token,
+		Data:    response.FromTokenPair(tokenPair),
+	})
+}
+
+func (uc *Controller) Refresh(c echo.Context) error {
+	userInput := request.RefreshToken{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	tokenPair, statusCode, err := uc.sessionUC.Refresh(userInput.RefreshToken, uc.userUC.GetActiveRole)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil memperbarui token",
+		Data:    response.FromTokenPair(tokenPair),
+	})
+}
+
+func (uc *Controller) Logout(c echo.Context) error {
+	token, err := helper.GetPayloadFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	userID, err := primitive.ObjectIDFromHex(token.UID)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: "token tidak valid",
+		})
+	}
+
+	sessionID, err := primitive.ObjectIDFromHex(token.SessionID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "token tidak memiliki sesi, silakan login ulang",
+		})
+	}
+
+	statusCode, err := uc.sessionUC.Revoke(sessionID, userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil logout",
 	})
 }
 
@@ -465,6 +586,268 @@ func (uc *Controller) UpdatePassword(c echo.Context) error {
 	})
 }
 
+func (uc *Controller) Suspend(c echo.Context) error {
+	userID, err := primitive.ObjectIDFromHex(c.Param("user-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id user tidak valid",
+		})
+	}
+
+	userInput := request.Suspend{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	_, statusCode, err := uc.userUC.Suspend(userID, userInput.Reason)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil menangguhkan user",
+	})
+}
+
+func (uc *Controller) Reactivate(c echo.Context) error {
+	userID, err := primitive.ObjectIDFromHex(c.Param("user-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id user tidak valid",
+		})
+	}
+
+	_, statusCode, err := uc.userUC.Reactivate(userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mengaktifkan kembali user",
+	})
+}
+
+func (uc *Controller) UpdateAreas(c echo.Context) error {
+	userID, err := primitive.ObjectIDFromHex(c.Param("user-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "id user tidak valid",
+		})
+	}
+
+	userInput := request.UpdateAreas{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	user, statusCode, err := uc.userUC.UpdateAreas(userID, userInput.ToDomain())
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	userResponse, statusCode, err := response.FromDomain(user, uc.regionUC)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mengatur wilayah validator",
+		Data:    userResponse,
+	})
+}
+
+func (uc *Controller) SetupTwoFactor(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	key, statusCode, err := uc.userUC.SetupTwoFactor(userID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "pindai kode qr lalu aktifkan dengan kode dari aplikasi autentikator",
+		Data:    response.FromTOTPKey(key),
+	})
+}
+
+func (uc *Controller) EnableTwoFactor(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userInput := request.TwoFactorCode{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	recoveryCodes, statusCode, err := uc.userUC.EnableTwoFactor(userID, userInput.Code)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mengaktifkan autentikasi dua faktor, simpan kode pemulihan karena tidak akan ditampilkan lagi",
+		Data:    response.RecoveryCodes{RecoveryCodes: recoveryCodes},
+	})
+}
+
+func (uc *Controller) DisableTwoFactor(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userInput := request.DisableTwoFactor{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	statusCode, err := uc.userUC.DisableTwoFactor(userID, userInput.Password, userInput.Code)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil menonaktifkan autentikasi dua faktor",
+	})
+}
+
+func (uc *Controller) RegenerateRecoveryCodes(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userInput := request.TwoFactorCode{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	recoveryCodes, statusCode, err := uc.userUC.RegenerateRecoveryCodes(userID, userInput.Code)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil membuat ulang kode pemulihan",
+		Data:    response.RecoveryCodes{RecoveryCodes: recoveryCodes},
+	})
+}
+
 /*
 Delete
 */
+
+func (uc *Controller) Delete(c echo.Context) error {
+	userID, err := helper.GetUIDFromToken(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	userInput := request.Delete{}
+	c.Bind(&userInput)
+
+	if validationErr := userInput.Validate(); validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	statusCode, err := uc.userUC.Delete(userID, userInput.Password)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil menghapus akun",
+	})
+}
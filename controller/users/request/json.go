@@ -12,6 +12,7 @@ import (
 )
 
 type RegisterUser struct {
+	Domain      string `form:"domain" json:"domain" validate:"required"`
 	RegionID    string `form:"regionID" json:"regionID" validate:"required"`
 	Name        string `form:"name" json:"name" validate:"required"`
 	Description string `form:"description" json:"description"`
@@ -110,6 +111,40 @@ func (req *Login) Validate() []helper.ValidationError {
 	return nil
 }
 
+type RefreshToken struct {
+	RefreshToken string `form:"refreshToken" json:"refreshToken" validate:"required"`
+}
+
+func (req *RefreshToken) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
 type Update struct {
 	RegionID    string `form:"regionID" json:"regionID" validate:"required"`
 	Name        string `form:"name" json:"name" validate:"required"`
@@ -258,3 +293,226 @@ func (req *ChangePassword) ToDomain() *users.Domain {
 		Password: req.OldPassword,
 	}
 }
+
+type Suspend struct {
+	Reason string `form:"reason" json:"reason" validate:"required"`
+}
+
+func (req *Suspend) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type Area struct {
+	Province string `form:"province" json:"province" validate:"required"`
+	Regency  string `form:"regency" json:"regency"`
+}
+
+type UpdateAreas struct {
+	Areas []Area `form:"areas" json:"areas" validate:"required,dive"`
+}
+
+func (req *UpdateAreas) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+func (req *UpdateAreas) ToDomain() []users.Area {
+	areas := []users.Area{}
+	for _, area := range req.Areas {
+		areas = append(areas, users.Area{
+			Province: area.Province,
+			Regency:  area.Regency,
+		})
+	}
+
+	return areas
+}
+
+type Delete struct {
+	Password string `form:"password" json:"password" validate:"required"`
+}
+
+func (req *Delete) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type LoginTwoFactor struct {
+	TwoFactorToken string `form:"twoFactorToken" json:"twoFactorToken" validate:"required"`
+	Code           string `form:"code" json:"code" validate:"required"`
+}
+
+func (req *LoginTwoFactor) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type TwoFactorCode struct {
+	Code string `form:"code" json:"code" validate:"required,len=6,numeric"`
+}
+
+func (req *TwoFactorCode) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type DisableTwoFactor struct {
+	Password string `form:"password" json:"password" validate:"required"`
+	Code     string `form:"code" json:"code" validate:"required"`
+}
+
+func (req *DisableTwoFactor) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
@@ -2,23 +2,51 @@ package response
 
 import (
 	"crop_connect/business/regions"
+	"crop_connect/business/sessions"
 	"crop_connect/business/users"
 	regionResponse "crop_connect/controller/regions/response"
+	"crop_connect/helper"
 	"net/http"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
 type User struct {
-	ID          primitive.ObjectID      `json:"_id"`
-	Region      regionResponse.Response `json:"region"`
-	Name        string                  `json:"name"`
-	Email       string                  `json:"email"`
-	Description string                  `json:"description"`
-	PhoneNumber string                  `json:"phoneNumber"`
-	Role        string                  `json:"role"`
-	CreatedAt   primitive.DateTime      `json:"createdAt"`
-	UpdatedAt   primitive.DateTime      `json:"updatedAt,omitempty"`
+	ID               primitive.ObjectID      `json:"_id"`
+	Region           regionResponse.Response `json:"region"`
+	Name             string                  `json:"name"`
+	Email            string                  `json:"email"`
+	Description      string                  `json:"description"`
+	PhoneNumber      string                  `json:"phoneNumber"`
+	Role             string                  `json:"role"`
+	Areas            []Area                  `json:"areas,omitempty"`
+	Status           string                  `json:"status"`
+	SuspendReason    string                  `json:"suspendReason,omitempty"`
+	RatingAverage    float64                 `json:"ratingAverage,omitempty"`
+	RatingCount      int                     `json:"ratingCount,omitempty"`
+	EmailVerifiedAt  primitive.DateTime      `json:"emailVerifiedAt,omitempty"`
+	SuspendedAt      primitive.DateTime      `json:"suspendedAt,omitempty"`
+	TwoFactorEnabled bool                    `json:"twoFactorEnabled"`
+	DeletedAt        primitive.DateTime      `json:"deletedAt,omitempty"`
+	CreatedAt        primitive.DateTime      `json:"createdAt"`
+	UpdatedAt        primitive.DateTime      `json:"updatedAt,omitempty"`
+}
+
+type Area struct {
+	Province string `json:"province"`
+	Regency  string `json:"regency,omitempty"`
+}
+
+func FromAreaDomainArray(data []users.Area) []Area {
+	var response []Area
+	for _, area := range data {
+		response = append(response, Area{
+			Province: area.Province,
+			Regency:  area.Regency,
+		})
+	}
+
+	return response
 }
 
 func FromDomain(domain users.Domain, regionUC regions.UseCase) (User, int, error) {
@@ -28,15 +56,24 @@ func FromDomain(domain users.Domain, regionUC regions.UseCase) (User, int, error
 	}
 
 	return User{
-		ID:          domain.ID,
-		Region:      regionResponse.FromDomain(&region),
-		Name:        domain.Name,
-		Email:       domain.Email,
-		Description: domain.Description,
-		PhoneNumber: domain.PhoneNumber,
-		Role:        domain.Role,
-		CreatedAt:   domain.CreatedAt,
-		UpdatedAt:   domain.UpdatedAt,
+		ID:               domain.ID,
+		Region:           regionResponse.FromDomain(&region),
+		Name:             domain.Name,
+		Email:            domain.Email,
+		Description:      domain.Description,
+		PhoneNumber:      domain.PhoneNumber,
+		Role:             domain.Role,
+		Areas:            FromAreaDomainArray(domain.Areas),
+		Status:           domain.Status,
+		SuspendReason:    domain.SuspendReason,
+		RatingAverage:    domain.RatingAverage,
+		RatingCount:      domain.RatingCount,
+		EmailVerifiedAt:  domain.EmailVerifiedAt,
+		SuspendedAt:      domain.SuspendedAt,
+		TwoFactorEnabled: domain.TwoFactor.EnabledAt != 0,
+		DeletedAt:        domain.DeletedAt,
+		CreatedAt:        domain.CreatedAt,
+		UpdatedAt:        domain.UpdatedAt,
 	}, http.StatusOK, nil
 }
 
@@ -53,3 +90,42 @@ func FromDomainArray(data []users.Domain, regionUC regions.UseCase) ([]User, int
 
 	return response, http.StatusOK, nil
 }
+
+type Token struct {
+	AccessToken           string             `json:"accessToken"`
+	RefreshToken          string             `json:"refreshToken"`
+	AccessTokenExpiredAt  primitive.DateTime `json:"accessTokenExpiredAt"`
+	RefreshTokenExpiredAt primitive.DateTime `json:"refreshTokenExpiredAt"`
+}
+
+func FromTokenPair(tokenPair sessions.TokenPair) Token {
+	return Token{
+		AccessToken:           tokenPair.AccessToken,
+		RefreshToken:          tokenPair.RefreshToken,
+		AccessTokenExpiredAt:  tokenPair.AccessTokenExpiredAt,
+		RefreshTokenExpiredAt: tokenPair.RefreshTokenExpiredAt,
+	}
+}
+
+type TwoFactorChallenge struct {
+	TwoFactorRequired bool   `json:"twoFactorRequired"`
+	TwoFactorToken    string `json:"twoFactorToken"`
+}
+
+type TwoFactorSetup struct {
+	Secret string `json:"secret"`
+	URL    string `json:"url"`
+	QRCode string `json:"qrCode"`
+}
+
+func FromTOTPKey(key helper.TOTPKey) TwoFactorSetup {
+	return TwoFactorSetup{
+		Secret: key.Secret,
+		URL:    key.URL,
+		QRCode: key.QRCode,
+	}
+}
+
+type RecoveryCodes struct {
+	RecoveryCodes []string `json:"recoveryCodes"`
+}
@@ -0,0 +1,342 @@
+package webhooks
+
+import (
+	"crop_connect/business/webhooks"
+	"crop_connect/constant"
+	"crop_connect/controller/webhooks/request"
+	"crop_connect/controller/webhooks/response"
+	"crop_connect/helper"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Controller struct {
+	webhookUC             webhooks.UseCase
+	testReceiverSecret    string
+	testReceiverDirectory string
+}
+
+// NewController takes the secret and directory of the test receiver, the receiver is disabled when the secret is empty.
+func NewController(webhookUC webhooks.UseCase, testReceiverSecret string, testReceiverDirectory string) *Controller {
+	if testReceiverDirectory == "" {
+		testReceiverDirectory = "webhooks"
+	}
+
+	return &Controller{
+		webhookUC:             webhookUC,
+		testReceiverSecret:    testReceiverSecret,
+		testReceiverDirectory: testReceiverDirectory,
+	}
+}
+
+/*
+Create
+*/
+
+func (wc *Controller) Create(c echo.Context) error {
+	userInput := request.Create{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	webhook, statusCode, err := wc.webhookUC.Create(userInput.ToDomain())
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil membuat webhook",
+		Data:    response.FromDomain(&webhook),
+	})
+}
+
+func (wc *Controller) Replay(c echo.Context) error {
+	deliveryID, err := primitive.ObjectIDFromHex(c.Param("delivery-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "delivery id tidak valid",
+		})
+	}
+
+	delivery, statusCode, err := wc.webhookUC.Replay(deliveryID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mengirim ulang webhook",
+		Data:    response.FromDeliveryDomain(&delivery),
+	})
+}
+
+// TestReceiver accepts deliveries for local development, the signature is checked like a partner would and the body is written to the test receiver directory.
+func (wc *Controller) TestReceiver(c echo.Context) error {
+	if wc.testReceiverSecret == "" {
+		return c.JSON(http.StatusNotFound, helper.BaseResponse{
+			Status:  http.StatusNotFound,
+			Message: "test receiver tidak aktif",
+		})
+	}
+
+	body, err := io.ReadAll(c.Request().Body)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "body tidak valid",
+		})
+	}
+
+	err = webhooks.VerifySignature(wc.testReceiverSecret, c.Request().Header.Get(constant.WebhookHeaderSignature), body, 5*time.Minute)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, helper.BaseResponse{
+			Status:  http.StatusUnauthorized,
+			Message: err.Error(),
+		})
+	}
+
+	if err := os.MkdirAll(wc.testReceiverDirectory, 0755); err != nil {
+		return c.JSON(http.StatusInternalServerError, helper.BaseResponse{
+			Status:  http.StatusInternalServerError,
+			Message: "gagal menyimpan webhook",
+		})
+	}
+
+	filename := filepath.Join(wc.testReceiverDirectory, fmt.Sprintf("%d-%s-%s.json", time.Now().Unix(), c.Request().Header.Get(constant.WebhookHeaderEvent), filepath.Base(c.Request().Header.Get(constant.WebhookHeaderDelivery))))
+	if err := os.WriteFile(filename, body, 0644); err != nil {
+		return c.JSON(http.StatusInternalServerError, helper.BaseResponse{
+			Status:  http.StatusInternalServerError,
+			Message: "gagal menyimpan webhook",
+		})
+	}
+
+	return c.JSON(http.StatusOK, helper.BaseResponse{
+		Status:  http.StatusOK,
+		Message: "webhook diterima",
+	})
+}
+
+/*
+Read
+*/
+
+func (wc *Controller) GetByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"createdAt", "updatedAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.QueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	webhookList, totalData, statusCode, err := wc.webhookUC.GetByPaginationAndQuery(webhooks.Query{
+		Skip:     queryPagination.Skip,
+		Limit:    queryPagination.Limit,
+		Sort:     queryPagination.Sort,
+		Order:    queryPagination.Order,
+		Event:    queryParam.Event,
+		IsActive: queryParam.IsActive,
+	})
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan webhook",
+		Data:       response.FromDomainArray(webhookList),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (wc *Controller) GetByID(c echo.Context) error {
+	webhookID, err := primitive.ObjectIDFromHex(c.Param("webhook-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "webhook id tidak valid",
+		})
+	}
+
+	webhook, statusCode, err := wc.webhookUC.GetByID(webhookID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan webhook",
+		Data:    response.FromDomain(&webhook),
+	})
+}
+
+func (wc *Controller) GetDeliveriesByPaginationAndQuery(c echo.Context) error {
+	queryPagination, err := helper.PaginationToQuery(c, []string{"createdAt", "updatedAt"})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	queryParam, err := request.DeliveryQueryParamValidation(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: err.Error(),
+		})
+	}
+
+	deliveries, totalData, statusCode, err := wc.webhookUC.GetDeliveriesByPaginationAndQuery(webhooks.DeliveryQuery{
+		Skip:      queryPagination.Skip,
+		Limit:     queryPagination.Limit,
+		Sort:      queryPagination.Sort,
+		Order:     queryPagination.Order,
+		WebhookID: queryParam.WebhookID,
+		Event:     queryParam.Event,
+		Status:    queryParam.Status,
+	})
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:     statusCode,
+		Message:    "berhasil mendapatkan pengiriman webhook",
+		Data:       response.FromDeliveryDomainArray(deliveries),
+		Pagination: helper.ConvertToPaginationResponse(queryPagination, totalData),
+	})
+}
+
+func (wc *Controller) GetDeliveryByID(c echo.Context) error {
+	deliveryID, err := primitive.ObjectIDFromHex(c.Param("delivery-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "delivery id tidak valid",
+		})
+	}
+
+	delivery, statusCode, err := wc.webhookUC.GetDeliveryByID(deliveryID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil mendapatkan pengiriman webhook",
+		Data:    response.FromDeliveryDomain(&delivery),
+	})
+}
+
+/*
+Update
+*/
+
+func (wc *Controller) Update(c echo.Context) error {
+	webhookID, err := primitive.ObjectIDFromHex(c.Param("webhook-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "webhook id tidak valid",
+		})
+	}
+
+	userInput := request.Update{}
+	c.Bind(&userInput)
+
+	validationErr := userInput.Validate()
+	if validationErr != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "validasi gagal",
+			Error:   validationErr,
+		})
+	}
+
+	inputDomain := userInput.ToDomain()
+	inputDomain.ID = webhookID
+
+	webhook, statusCode, err := wc.webhookUC.Update(inputDomain)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil memperbarui webhook",
+		Data:    response.FromDomain(&webhook),
+	})
+}
+
+/*
+Delete
+*/
+
+func (wc *Controller) Delete(c echo.Context) error {
+	webhookID, err := primitive.ObjectIDFromHex(c.Param("webhook-id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, helper.BaseResponse{
+			Status:  http.StatusBadRequest,
+			Message: "webhook id tidak valid",
+		})
+	}
+
+	statusCode, err := wc.webhookUC.Delete(webhookID)
+	if err != nil {
+		return c.JSON(statusCode, helper.BaseResponse{
+			Status:  statusCode,
+			Message: err.Error(),
+		})
+	}
+
+	return c.JSON(statusCode, helper.BaseResponse{
+		Status:  statusCode,
+		Message: "berhasil menghapus webhook",
+	})
+}
@@ -0,0 +1,105 @@
+package request
+
+import (
+	"crop_connect/business/webhooks"
+	"crop_connect/helper"
+	"errors"
+	"strings"
+
+	"github.com/fatih/structs"
+	"github.com/go-playground/validator/v10"
+)
+
+type Create struct {
+	URL         string   `json:"url" validate:"required"`
+	Secret      string   `json:"secret" validate:"required,min=16"`
+	Events      []string `json:"events" validate:"required,min=1"`
+	Description string   `json:"description"`
+}
+
+func (req *Create) ToDomain() *webhooks.Domain {
+	return &webhooks.Domain{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      req.Events,
+		Description: req.Description,
+	}
+}
+
+func (req *Create) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
+
+type Update struct {
+	URL         string   `json:"url" validate:"required"`
+	Secret      string   `json:"secret" validate:"omitempty,min=16"`
+	Events      []string `json:"events" validate:"required,min=1"`
+	Description string   `json:"description"`
+	IsActive    bool     `json:"isActive"`
+}
+
+func (req *Update) ToDomain() *webhooks.Domain {
+	return &webhooks.Domain{
+		URL:         req.URL,
+		Secret:      req.Secret,
+		Events:      req.Events,
+		Description: req.Description,
+		IsActive:    req.IsActive,
+	}
+}
+
+func (req *Update) Validate() []helper.ValidationError {
+	var ve validator.ValidationErrors
+
+	if err := validator.New().Struct(req); err != nil {
+		if errors.As(err, &ve) {
+			fields := structs.Fields(req)
+			out := make([]helper.ValidationError, len(ve))
+
+			for i, e := range ve {
+				out[i] = helper.ValidationError{
+					Field:   e.Field(),
+					Message: helper.MessageForTag(e.Tag()),
+				}
+
+				out[i].Message = strings.Replace(out[i].Message, "[PARAM]", e.Param(), 1)
+
+				for _, f := range fields {
+					if f.Name() == e.Field() {
+						out[i].Field = f.Tag("json")
+						break
+					}
+				}
+			}
+			return out
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,74 @@
+package request
+
+import (
+	"crop_connect/constant"
+	"crop_connect/util"
+	"errors"
+	"fmt"
+	"strconv"
+
+	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+var events = []string{constant.WebhookEventTransactionCreated, constant.WebhookEventTransactionAccepted, constant.WebhookEventHarvestApproved, constant.WebhookEventProposalValidated}
+
+type FilterQuery struct {
+	Event    string
+	IsActive *bool
+}
+
+func QueryParamValidation(c echo.Context) (FilterQuery, error) {
+	filter := FilterQuery{
+		Event: c.QueryParam("event"),
+	}
+
+	if filter.Event != "" && !util.CheckStringOnArray(events, filter.Event) {
+		return FilterQuery{}, fmt.Errorf("event tersedia hanya %s, %s, %s, dan %s", events[0], events[1], events[2], events[3])
+	}
+
+	if c.QueryParam("isActive") != "" {
+		isActive, err := strconv.ParseBool(c.QueryParam("isActive"))
+		if err != nil {
+			return FilterQuery{}, errors.New("isActive hanya tersedia true dan false")
+		}
+
+		filter.IsActive = &isActive
+	}
+
+	return filter, nil
+}
+
+type DeliveryFilterQuery struct {
+	WebhookID primitive.ObjectID
+	Event     string
+	Status    string
+}
+
+func DeliveryQueryParamValidation(c echo.Context) (DeliveryFilterQuery, error) {
+	filter := DeliveryFilterQuery{
+		Event:  c.QueryParam("event"),
+		Status: c.QueryParam("status"),
+	}
+
+	if c.QueryParam("webhookID") != "" {
+		webhookID, err := primitive.ObjectIDFromHex(c.QueryParam("webhookID"))
+		if err != nil {
+			return DeliveryFilterQuery{}, errors.New("webhookID tidak valid")
+		}
+
+		filter.WebhookID = webhookID
+	}
+
+	if filter.Event != "" && !util.CheckStringOnArray(events, filter.Event) {
+		return DeliveryFilterQuery{}, fmt.Errorf("event tersedia hanya %s, %s, %s, dan %s", events[0], events[1], events[2], events[3])
+	}
+
+	if filter.Status != "" {
+		if !util.CheckStringOnArray([]string{constant.WebhookDeliveryStatusPending, constant.WebhookDeliveryStatusSuccess, constant.WebhookDeliveryStatusFailed}, filter.Status) {
+			return DeliveryFilterQuery{}, fmt.Errorf("status tersedia hanya %s, %s, dan %s", constant.WebhookDeliveryStatusPending, constant.WebhookDeliveryStatusSuccess, constant.WebhookDeliveryStatusFailed)
+		}
+	}
+
+	return filter, nil
+}
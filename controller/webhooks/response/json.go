@@ -0,0 +1,83 @@
+package response
+
+import (
+	"crop_connect/business/webhooks"
+	"encoding/json"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Webhook leaves out the secret, it is only shown to the admin who sets it.
+type Webhook struct {
+	ID          primitive.ObjectID `json:"_id"`
+	URL         string             `json:"url"`
+	Events      []string           `json:"events"`
+	Description string             `json:"description,omitempty"`
+	IsActive    bool               `json:"isActive"`
+	CreatedAt   primitive.DateTime `json:"createdAt"`
+	UpdatedAt   primitive.DateTime `json:"updatedAt,omitempty"`
+}
+
+type Delivery struct {
+	ID             primitive.ObjectID `json:"_id"`
+	WebhookID      primitive.ObjectID `json:"webhookID"`
+	EventID        primitive.ObjectID `json:"eventID"`
+	Event          string             `json:"event"`
+	Payload        json.RawMessage    `json:"payload"`
+	Status         string             `json:"status"`
+	Attempts       int                `json:"attempts"`
+	ResponseStatus int                `json:"responseStatus,omitempty"`
+	LastError      string             `json:"lastError,omitempty"`
+	ReplayOf       primitive.ObjectID `json:"replayOf,omitempty"`
+	CreatedAt      primitive.DateTime `json:"createdAt"`
+	UpdatedAt      primitive.DateTime `json:"updatedAt,omitempty"`
+	DeliveredAt    primitive.DateTime `json:"deliveredAt,omitempty"`
+}
+
+func FromDomain(domain *webhooks.Domain) Webhook {
+	return Webhook{
+		ID:          domain.ID,
+		URL:         domain.URL,
+		Events:      domain.Events,
+		Description: domain.Description,
+		IsActive:    domain.IsActive,
+		CreatedAt:   domain.CreatedAt,
+		UpdatedAt:   domain.UpdatedAt,
+	}
+}
+
+func FromDomainArray(domain []webhooks.Domain) []Webhook {
+	var response []Webhook
+	for _, value := range domain {
+		response = append(response, FromDomain(&value))
+	}
+
+	return response
+}
+
+func FromDeliveryDomain(domain *webhooks.Delivery) Delivery {
+	return Delivery{
+		ID:             domain.ID,
+		WebhookID:      domain.WebhookID,
+		EventID:        domain.EventID,
+		Event:          domain.Event,
+		Payload:        json.RawMessage(domain.Payload),
+		Status:         domain.Status,
+		Attempts:       domain.Attempts,
+		ResponseStatus: domain.ResponseStatus,
+		LastError:      domain.LastError,
+		ReplayOf:       domain.ReplayOf,
+		CreatedAt:      domain.CreatedAt,
+		UpdatedAt:      domain.UpdatedAt,
+		DeliveredAt:    domain.DeliveredAt,
+	}
+}
+
+func FromDeliveryDomainArray(domain []webhooks.Delivery) []Delivery {
+	var response []Delivery
+	for _, value := range domain {
+		response = append(response, FromDeliveryDomain(&value))
+	}
+
+	return response
+}
@@ -1,25 +1,84 @@
 package driver
 
 import (
+	apiKeyDomain "crop_connect/business/api_keys"
+	auditEventDomain "crop_connect/business/audit_events"
 	batchDomain "crop_connect/business/batchs"
 	commodityDomain "crop_connect/business/commodities"
+	conversationDomain "crop_connect/business/conversations"
+	disputeDomain "crop_connect/business/disputes"
+	emailVerificationDomain "crop_connect/business/email_verifications"
 	forgotPasswordDomain "crop_connect/business/forgot_password"
 	harvestDomain "crop_connect/business/harvests"
+	jobHistoryDomain "crop_connect/business/job_histories"
+	jobDomain "crop_connect/business/jobs"
+	notificationDomain "crop_connect/business/notifications"
+	paymentDomain "crop_connect/business/payments"
 	proposalDomain "crop_connect/business/proposals"
+	ratingDomain "crop_connect/business/ratings"
 	regionDomain "crop_connect/business/regions"
+	revokedTokenDomain "crop_connect/business/revoked_tokens"
+	sessionDomain "crop_connect/business/sessions"
+	shipmentDomain "crop_connect/business/shipments"
 	transactionDomain "crop_connect/business/transactions"
 	treatmentRecordDomain "crop_connect/business/treatment_records"
+	unitOfWorkDomain "crop_connect/business/unit_of_work"
 	userDomain "crop_connect/business/users"
+	webhookDomain "crop_connect/business/webhooks"
 
+	mongoDriver "crop_connect/driver/mongo"
+	apiKeyDB "crop_connect/driver/mongo/api_keys"
+	auditEventDB "crop_connect/driver/mongo/audit_events"
 	batchDB "crop_connect/driver/mongo/batchs"
 	commodityDB "crop_connect/driver/mongo/commodities"
+	conversationDB "crop_connect/driver/mongo/conversations"
+	disputeDB "crop_connect/driver/mongo/disputes"
+	emailVerificationDB "crop_connect/driver/mongo/email_verifications"
 	forgotPasswordDB "crop_connect/driver/mongo/forgot_password"
 	harvestDB "crop_connect/driver/mongo/harvests"
+	jobHistoryDB "crop_connect/driver/mongo/job_histories"
+	jobDB "crop_connect/driver/mongo/jobs"
+	notificationDB "crop_connect/driver/mongo/notifications"
+	paymentDB "crop_connect/driver/mongo/payments"
 	proposalDB "crop_connect/driver/mongo/proposals"
+	ratingDB "crop_connect/driver/mongo/ratings"
 	regionDB "crop_connect/driver/mongo/regions"
+	revokedTokenDB "crop_connect/driver/mongo/revoked_tokens"
+	sessionDB "crop_connect/driver/mongo/sessions"
+	shipmentDB "crop_connect/driver/mongo/shipments"
 	transactionDB "crop_connect/driver/mongo/transactions"
 	treatmentRecordDB "crop_connect/driver/mongo/treatment_records"
+	unitOfWorkDB "crop_connect/driver/mongo/unit_of_work"
 	userDB "crop_connect/driver/mongo/users"
+	webhookDB "crop_connect/driver/mongo/webhooks"
+
+	memoryDriver "crop_connect/driver/memory"
+	apiKeyMemory "crop_connect/driver/memory/api_keys"
+	auditEventMemory "crop_connect/driver/memory/audit_events"
+	batchMemory "crop_connect/driver/memory/batchs"
+	commodityMemory "crop_connect/driver/memory/commodities"
+	conversationMemory "crop_connect/driver/memory/conversations"
+	disputeMemory "crop_connect/driver/memory/disputes"
+	emailVerificationMemory "crop_connect/driver/memory/email_verifications"
+	forgotPasswordMemory "crop_connect/driver/memory/forgot_password"
+	harvestMemory "crop_connect/driver/memory/harvests"
+	jobHistoryMemory "crop_connect/driver/memory/job_histories"
+	jobMemory "crop_connect/driver/memory/jobs"
+	notificationMemory "crop_connect/driver/memory/notifications"
+	paymentMemory "crop_connect/driver/memory/payments"
+	proposalMemory "crop_connect/driver/memory/proposals"
+	ratingMemory "crop_connect/driver/memory/ratings"
+	regionMemory "crop_connect/driver/memory/regions"
+	revokedTokenMemory "crop_connect/driver/memory/revoked_tokens"
+	sessionMemory "crop_connect/driver/memory/sessions"
+	shipmentMemory "crop_connect/driver/memory/shipments"
+	transactionMemory "crop_connect/driver/memory/transactions"
+	treatmentRecordMemory "crop_connect/driver/memory/treatment_records"
+	unitOfWorkMemory "crop_connect/driver/memory/unit_of_work"
+	userMemory "crop_connect/driver/memory/users"
+	webhookMemory "crop_connect/driver/memory/webhooks"
+
+	"crop_connect/seeds"
 
 	"go.mongodb.org/mongo-driver/mongo"
 )
@@ -59,3 +118,271 @@ func NewRegionRepository(db *mongo.Database) regionDomain.Repository {
 func NewForgotPasswordRepository(db *mongo.Database) forgotPasswordDomain.Repository {
 	return forgotPasswordDB.NewRepository(db)
 }
+
+func NewPaymentRepository(db *mongo.Database) paymentDomain.Repository {
+	return paymentDB.NewRepository(db)
+}
+
+func NewShipmentRepository(db *mongo.Database) shipmentDomain.Repository {
+	return shipmentDB.NewRepository(db)
+}
+
+func NewNotificationRepository(db *mongo.Database) notificationDomain.Repository {
+	return notificationDB.NewRepository(db)
+}
+
+func NewJobRepository(db *mongo.Database) jobDomain.Repository {
+	return jobDB.NewRepository(db)
+}
+
+func NewJobHistoryRepository(db *mongo.Database) jobHistoryDomain.Repository {
+	return jobHistoryDB.NewRepository(db)
+}
+
+func NewSessionRepository(db *mongo.Database) sessionDomain.Repository {
+	return sessionDB.NewRepository(db)
+}
+
+func NewRevokedTokenRepository(db *mongo.Database) revokedTokenDomain.Repository {
+	return revokedTokenDB.NewRepository(db)
+}
+
+func NewEmailVerificationRepository(db *mongo.Database) emailVerificationDomain.Repository {
+	return emailVerificationDB.NewRepository(db)
+}
+
+func NewAuditEventRepository(db *mongo.Database) auditEventDomain.Repository {
+	return auditEventDB.NewRepository(db)
+}
+
+func NewDisputeRepository(db *mongo.Database) disputeDomain.Repository {
+	return disputeDB.NewRepository(db)
+}
+
+func NewRatingRepository(db *mongo.Database) ratingDomain.Repository {
+	return ratingDB.NewRepository(db)
+}
+
+func NewConversationRepository(db *mongo.Database) conversationDomain.Repository {
+	return conversationDB.NewRepository(db)
+}
+
+func NewWebhookRepository(db *mongo.Database) webhookDomain.Repository {
+	return webhookDB.NewRepository(db)
+}
+
+func NewAPIKeyRepository(db *mongo.Database) apiKeyDomain.Repository {
+	return apiKeyDB.NewRepository(db)
+}
+
+func NewUnitOfWork(db *mongo.Database) unitOfWorkDomain.UnitOfWork {
+	return unitOfWorkDB.NewUnitOfWork(db)
+}
+
+/*
+In-memory
+*/
+
+func NewUserMemoryRepository(db *memoryDriver.Database) userDomain.Repository {
+	return userMemory.NewRepository(db)
+}
+
+func NewCommodityMemoryRepository(db *memoryDriver.Database) commodityDomain.Repository {
+	return commodityMemory.NewRepository(db)
+}
+
+func NewProposalMemoryRepository(db *memoryDriver.Database) proposalDomain.Repository {
+	return proposalMemory.NewRepository(db)
+}
+
+func NewTransactionMemoryRepository(db *memoryDriver.Database) transactionDomain.Repository {
+	return transactionMemory.NewRepository(db)
+}
+
+func NewBatchMemoryRepository(db *memoryDriver.Database) batchDomain.Repository {
+	return batchMemory.NewRepository(db)
+}
+
+func NewTreatmentRecordMemoryRepository(db *memoryDriver.Database) treatmentRecordDomain.Repository {
+	return treatmentRecordMemory.NewRepository(db)
+}
+
+func NewHarvestMemoryRepository(db *memoryDriver.Database) harvestDomain.Repository {
+	return harvestMemory.NewRepository(db)
+}
+
+func NewRegionMemoryRepository(db *memoryDriver.Database) regionDomain.Repository {
+	return regionMemory.NewRepository(db)
+}
+
+func NewForgotPasswordMemoryRepository(db *memoryDriver.Database) forgotPasswordDomain.Repository {
+	return forgotPasswordMemory.NewRepository(db)
+}
+
+func NewPaymentMemoryRepository(db *memoryDriver.Database) paymentDomain.Repository {
+	return paymentMemory.NewRepository(db)
+}
+
+func NewShipmentMemoryRepository(db *memoryDriver.Database) shipmentDomain.Repository {
+	return shipmentMemory.NewRepository(db)
+}
+
+func NewNotificationMemoryRepository(db *memoryDriver.Database) notificationDomain.Repository {
+	return notificationMemory.NewRepository(db)
+}
+
+func NewJobMemoryRepository(db *memoryDriver.Database) jobDomain.Repository {
+	return jobMemory.NewRepository(db)
+}
+
+func NewJobHistoryMemoryRepository(db *memoryDriver.Database) jobHistoryDomain.Repository {
+	return jobHistoryMemory.NewRepository(db)
+}
+
+func NewSessionMemoryRepository(db *memoryDriver.Database) sessionDomain.Repository {
+	return sessionMemory.NewRepository(db)
+}
+
+func NewRevokedTokenMemoryRepository(db *memoryDriver.Database) revokedTokenDomain.Repository {
+	return revokedTokenMemory.NewRepository(db)
+}
+
+func NewEmailVerificationMemoryRepository(db *memoryDriver.Database) emailVerificationDomain.Repository {
+	return emailVerificationMemory.NewRepository(db)
+}
+
+func NewAuditEventMemoryRepository(db *memoryDriver.Database) auditEventDomain.Repository {
+	return auditEventMemory.NewRepository(db)
+}
+
+func NewDisputeMemoryRepository(db *memoryDriver.Database) disputeDomain.Repository {
+	return disputeMemory.NewRepository(db)
+}
+
+func NewRatingMemoryRepository(db *memoryDriver.Database) ratingDomain.Repository {
+	return ratingMemory.NewRepository(db)
+}
+
+func NewConversationMemoryRepository(db *memoryDriver.Database) conversationDomain.Repository {
+	return conversationMemory.NewRepository(db)
+}
+
+func NewWebhookMemoryRepository(db *memoryDriver.Database) webhookDomain.Repository {
+	return webhookMemory.NewRepository(db)
+}
+
+func NewAPIKeyMemoryRepository(db *memoryDriver.Database) apiKeyDomain.Repository {
+	return apiKeyMemory.NewRepository(db)
+}
+
+func NewUnitOfWorkMemory(db *memoryDriver.Database) unitOfWorkDomain.UnitOfWork {
+	return unitOfWorkMemory.NewUnitOfWork(db)
+}
+
+/*
+Repositories
+*/
+
+// Repositories holds the repositories and the unit of work of one database driver.
+// Seed fills the database with its initial data and Close releases it on shutdown.
+type Repositories struct {
+	User              userDomain.Repository
+	Commodity         commodityDomain.Repository
+	Proposal          proposalDomain.Repository
+	Transaction       transactionDomain.Repository
+	Batch             batchDomain.Repository
+	TreatmentRecord   treatmentRecordDomain.Repository
+	Harvest           harvestDomain.Repository
+	Region            regionDomain.Repository
+	ForgotPassword    forgotPasswordDomain.Repository
+	Payment           paymentDomain.Repository
+	Shipment          shipmentDomain.Repository
+	Notification      notificationDomain.Repository
+	Job               jobDomain.Repository
+	JobHistory        jobHistoryDomain.Repository
+	Session           sessionDomain.Repository
+	RevokedToken      revokedTokenDomain.Repository
+	EmailVerification emailVerificationDomain.Repository
+	AuditEvent        auditEventDomain.Repository
+	Dispute           disputeDomain.Repository
+	Rating            ratingDomain.Repository
+	Conversation      conversationDomain.Repository
+	Webhook           webhookDomain.Repository
+	APIKey            apiKeyDomain.Repository
+	UnitOfWork        unitOfWorkDomain.UnitOfWork
+	Seed              func(regionUC regionDomain.UseCase)
+	Close             func() error
+}
+
+// NewRepositories selects the repositories by dbDriver, "memory" keeps every record in the process and anything else connects to the mongo database dbName.
+func NewRepositories(dbDriver string, dbName string) Repositories {
+	if dbDriver == "memory" {
+		database := memoryDriver.Init()
+
+		return Repositories{
+			User:              NewUserMemoryRepository(database),
+			Commodity:         NewCommodityMemoryRepository(database),
+			Proposal:          NewProposalMemoryRepository(database),
+			Transaction:       NewTransactionMemoryRepository(database),
+			Batch:             NewBatchMemoryRepository(database),
+			TreatmentRecord:   NewTreatmentRecordMemoryRepository(database),
+			Harvest:           NewHarvestMemoryRepository(database),
+			Region:            NewRegionMemoryRepository(database),
+			ForgotPassword:    NewForgotPasswordMemoryRepository(database),
+			Payment:           NewPaymentMemoryRepository(database),
+			Shipment:          NewShipmentMemoryRepository(database),
+			Notification:      NewNotificationMemoryRepository(database),
+			Job:               NewJobMemoryRepository(database),
+			JobHistory:        NewJobHistoryMemoryRepository(database),
+			Session:           NewSessionMemoryRepository(database),
+			RevokedToken:      NewRevokedTokenMemoryRepository(database),
+			EmailVerification: NewEmailVerificationMemoryRepository(database),
+			AuditEvent:        NewAuditEventMemoryRepository(database),
+			Dispute:           NewDisputeMemoryRepository(database),
+			Rating:            NewRatingMemoryRepository(database),
+			Conversation:      NewConversationMemoryRepository(database),
+			Webhook:           NewWebhookMemoryRepository(database),
+			APIKey:            NewAPIKeyMemoryRepository(database),
+			UnitOfWork:        NewUnitOfWorkMemory(database),
+			Seed:              seeds.SeedMemoryDatabase,
+			Close: func() error {
+				return memoryDriver.Close(database)
+			},
+		}
+	}
+
+	database := mongoDriver.Init(dbName)
+
+	return Repositories{
+		User:              NewUserRepository(database),
+		Commodity:         NewCommodityRepository(database),
+		Proposal:          NewProposalRepository(database),
+		Transaction:       NewTransactionRepository(database),
+		Batch:             NewBatchRepository(database),
+		TreatmentRecord:   NewTreatmentRecordRepository(database),
+		Harvest:           NewHarvestRepository(database),
+		Region:            NewRegionRepository(database),
+		ForgotPassword:    NewForgotPasswordRepository(database),
+		Payment:           NewPaymentRepository(database),
+		Shipment:          NewShipmentRepository(database),
+		Notification:      NewNotificationRepository(database),
+		Job:               NewJobRepository(database),
+		JobHistory:        NewJobHistoryRepository(database),
+		Session:           NewSessionRepository(database),
+		RevokedToken:      NewRevokedTokenRepository(database),
+		EmailVerification: NewEmailVerificationRepository(database),
+		AuditEvent:        NewAuditEventRepository(database),
+		Dispute:           NewDisputeRepository(database),
+		Rating:            NewRatingRepository(database),
+		Conversation:      NewConversationRepository(database),
+		Webhook:           NewWebhookRepository(database),
+		APIKey:            NewAPIKeyRepository(database),
+		UnitOfWork:        NewUnitOfWork(database),
+		Seed: func(regionUC regionDomain.UseCase) {
+			seeds.SeedDatabase(database, regionUC)
+		},
+		Close: func() error {
+			return mongoDriver.Close(database)
+		},
+	}
+}
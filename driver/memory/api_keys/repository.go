@@ -0,0 +1,127 @@
+package api_keys
+
+import (
+	"context"
+	apiKeys "crop_connect/business/api_keys"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type APIKeyRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) apiKeys.Repository {
+	return &APIKeyRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(apiKeys.Domain) interface{} {
+	switch sort {
+	case "expiresAt":
+		return func(domain apiKeys.Domain) interface{} { return domain.ExpiresAt }
+	case "lastUsedAt":
+		return func(domain apiKeys.Domain) interface{} { return domain.LastUsedAt }
+	default:
+		return func(domain apiKeys.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (akr *APIKeyRepository) find(filter func(apiKeys.Domain) bool) []apiKeys.Domain {
+	akr.db.RLock()
+	defer akr.db.RUnlock()
+
+	result := []apiKeys.Domain{}
+	for _, apiKey := range akr.db.APIKeys {
+		if filter(apiKey) {
+			result = append(result, apiKey)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (akr *APIKeyRepository) Create(domain *apiKeys.Domain) (apiKeys.Domain, error) {
+	defer akr.db.LockWrite(context.Background())()
+
+	akr.db.APIKeys = append(akr.db.APIKeys, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (akr *APIKeyRepository) GetByID(id primitive.ObjectID) (apiKeys.Domain, error) {
+	result := akr.find(func(apiKey apiKeys.Domain) bool {
+		return apiKey.ID == id
+	})
+
+	if len(result) == 0 {
+		return apiKeys.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (akr *APIKeyRepository) GetByKeyHash(keyHash string) (apiKeys.Domain, error) {
+	result := akr.find(func(apiKey apiKeys.Domain) bool {
+		return apiKey.KeyHash == keyHash
+	})
+
+	if len(result) == 0 {
+		return apiKeys.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (akr *APIKeyRepository) GetByQuery(query apiKeys.Query) ([]apiKeys.Domain, int, error) {
+	result := akr.find(func(apiKey apiKeys.Domain) bool {
+		if query.UserID != primitive.NilObjectID && apiKey.UserID != query.UserID {
+			return false
+		}
+
+		return query.IsRevoked == nil || (apiKey.RevokedAt != 0) == *query.IsRevoked
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+/*
+Update
+*/
+
+func (akr *APIKeyRepository) Update(domain *apiKeys.Domain) (apiKeys.Domain, error) {
+	defer akr.db.LockWrite(context.Background())()
+
+	for i, apiKey := range akr.db.APIKeys {
+		if apiKey.ID == domain.ID {
+			akr.db.APIKeys[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (akr *APIKeyRepository) UpdateLastUsedAt(id primitive.ObjectID, lastUsedAt primitive.DateTime) error {
+	defer akr.db.LockWrite(context.Background())()
+
+	for i, apiKey := range akr.db.APIKeys {
+		if apiKey.ID == id {
+			akr.db.APIKeys[i].LastUsedAt = lastUsedAt
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,91 @@
+package audit_events
+
+import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type AuditEventRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) auditEvents.Repository {
+	return &AuditEventRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(auditEvents.Domain) interface{} {
+	switch sort {
+	case "entityType":
+		return func(domain auditEvents.Domain) interface{} { return domain.EntityType }
+	case "newStatus":
+		return func(domain auditEvents.Domain) interface{} { return domain.NewStatus }
+	default:
+		return func(domain auditEvents.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (aer *AuditEventRepository) find(filter func(auditEvents.Domain) bool) []auditEvents.Domain {
+	aer.db.RLock()
+	defer aer.db.RUnlock()
+
+	result := []auditEvents.Domain{}
+	for _, auditEvent := range aer.db.AuditEvents {
+		if filter(auditEvent) {
+			result = append(result, auditEvent)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (aer *AuditEventRepository) Create(ctx context.Context, domain *auditEvents.Domain) (auditEvents.Domain, error) {
+	defer aer.db.LockWrite(ctx)()
+
+	aer.db.AuditEvents = append(aer.db.AuditEvents, *domain)
+	return *domain, nil
+}
+
+func (aer *AuditEventRepository) CreateMany(ctx context.Context, domains []auditEvents.Domain) error {
+	defer aer.db.LockWrite(ctx)()
+
+	aer.db.AuditEvents = append(aer.db.AuditEvents, domains...)
+	return nil
+}
+
+/*
+Read
+*/
+
+func (aer *AuditEventRepository) GetByQuery(query auditEvents.Query) ([]auditEvents.Domain, int, error) {
+	result := aer.find(func(auditEvent auditEvents.Domain) bool {
+		return (query.EntityType == "" || auditEvent.EntityType == query.EntityType) &&
+			(query.EntityID == primitive.NilObjectID || auditEvent.EntityID == query.EntityID) &&
+			(query.ActorID == primitive.NilObjectID || auditEvent.ActorID == query.ActorID) &&
+			(query.ActorRole == "" || auditEvent.ActorRole == query.ActorRole) &&
+			(query.NewStatus == "" || auditEvent.NewStatus == query.NewStatus)
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (aer *AuditEventRepository) GetByEntity(entityType string, entityID primitive.ObjectID) ([]auditEvents.Domain, error) {
+	result := aer.find(func(auditEvent auditEvents.Domain) bool {
+		return auditEvent.EntityType == entityType && auditEvent.EntityID == entityID
+	})
+
+	memoryDriver.Sort(result, 1, sortKey("createdAt"))
+
+	return result, nil
+}
@@ -0,0 +1,213 @@
+package batchs
+
+import (
+	"context"
+	"crop_connect/business/batchs"
+	"crop_connect/business/commodities"
+	"crop_connect/constant"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/helper"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type BatchRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) batchs.Repository {
+	return &BatchRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(batchs.Domain) interface{} {
+	switch sort {
+	case "name":
+		return func(domain batchs.Domain) interface{} { return domain.Name }
+	case "status":
+		return func(domain batchs.Domain) interface{} { return domain.Status }
+	default:
+		return func(domain batchs.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+// commodity must be called while holding the lock.
+func (br *BatchRepository) commodity(batch batchs.Domain) commodities.Domain {
+	commodity, _ := br.db.FindCommodityByProposalID(batch.ProposalID)
+	return commodity
+}
+
+func (br *BatchRepository) find(filter func(batchs.Domain) bool) []batchs.Domain {
+	br.db.RLock()
+	defer br.db.RUnlock()
+
+	result := []batchs.Domain{}
+	for _, batch := range br.db.Batchs {
+		if filter(batch) {
+			result = append(result, batch)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (br *BatchRepository) Create(ctx context.Context, domain *batchs.Domain) (batchs.Domain, error) {
+	defer br.db.LockWrite(ctx)()
+
+	br.db.Batchs = append(br.db.Batchs, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (br *BatchRepository) GetByID(id primitive.ObjectID) (batchs.Domain, error) {
+	br.db.RLock()
+	defer br.db.RUnlock()
+
+	batch, ok := br.db.FindBatch(id)
+	if !ok {
+		return batchs.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return batch, nil
+}
+
+func (br *BatchRepository) CountByProposalCode(proposalCode primitive.ObjectID) (int, error) {
+	return len(br.find(func(batch batchs.Domain) bool {
+		proposal, ok := br.db.FindProposal(batch.ProposalID)
+		return ok && proposal.Code == proposalCode
+	})), nil
+}
+
+func (br *BatchRepository) GetByFarmerID(farmerID primitive.ObjectID) ([]batchs.Domain, error) {
+	return br.find(func(batch batchs.Domain) bool {
+		return br.commodity(batch).FarmerID == farmerID
+	}), nil
+}
+
+func (br *BatchRepository) GetByCommodityCode(commodityCode primitive.ObjectID) ([]batchs.Domain, error) {
+	return br.find(func(batch batchs.Domain) bool {
+		return br.commodity(batch).Code == commodityCode
+	}), nil
+}
+
+func (br *BatchRepository) GetByQuery(query batchs.Query) ([]batchs.Domain, int, error) {
+	result := br.find(func(batch batchs.Domain) bool {
+		if query.Status != "" && batch.Status != query.Status {
+			return false
+		}
+
+		if query.Name != "" && !memoryDriver.Regex(batch.Name, query.Name, true) {
+			return false
+		}
+
+		if query.CommodityID != primitive.NilObjectID {
+			proposal, ok := br.db.FindProposal(batch.ProposalID)
+			if !ok || proposal.CommodityID != query.CommodityID {
+				return false
+			}
+		}
+
+		if query.FarmerID != primitive.NilObjectID && br.commodity(batch).FarmerID != query.FarmerID {
+			return false
+		}
+
+		return true
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (br *BatchRepository) CountByYear(year int) (int, error) {
+	return len(br.find(func(batch batchs.Domain) bool {
+		return memoryDriver.IsInYear(batch.CreatedAt, year)
+	})), nil
+}
+
+func (br *BatchRepository) GetForTransactionByCommodityID(commodityID primitive.ObjectID) ([]batchs.Domain, error) {
+	return br.find(func(batch batchs.Domain) bool {
+		return batch.IsAvailable && br.commodity(batch).ID == commodityID
+	}), nil
+}
+
+func (br *BatchRepository) GetForTransactionByCommodityCode(commodityCode primitive.ObjectID) ([]batchs.Domain, error) {
+	return br.find(func(batch batchs.Domain) bool {
+		return batch.IsAvailable && br.commodity(batch).Code == commodityCode
+	}), nil
+}
+
+func (br *BatchRepository) GetForTransactionByID(id primitive.ObjectID) (batchs.Domain, error) {
+	br.db.RLock()
+	defer br.db.RUnlock()
+
+	batch, ok := br.db.FindBatch(id)
+	if !ok || !batch.IsAvailable {
+		return batchs.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return batch, nil
+}
+
+func (br *BatchRepository) GetForHarvestByFarmerID(farmerID primitive.ObjectID) ([]batchs.Domain, error) {
+	return br.find(func(batch batchs.Domain) bool {
+		if _, ok := br.db.FindHarvestByBatchID(batch.ID); ok {
+			return false
+		}
+
+		return br.commodity(batch).FarmerID == farmerID
+	}), nil
+}
+
+func (br *BatchRepository) GetPlantingByProposalID(proposalID primitive.ObjectID) (batchs.Domain, error) {
+	result := br.find(func(batch batchs.Domain) bool {
+		return batch.ProposalID == proposalID && batch.Status == constant.BatchStatusPlanting
+	})
+	if len(result) == 0 {
+		return batchs.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (br *BatchRepository) GetOverdue(now primitive.DateTime) ([]batchs.Domain, error) {
+	return br.find(func(batch batchs.Domain) bool {
+		return batch.Status == constant.BatchStatusPlanting && batch.EstimatedHarvestDate < now && !batch.IsOverdue
+	}), nil
+}
+
+/*
+Update
+*/
+
+func (br *BatchRepository) Update(ctx context.Context, domain *batchs.Domain) (batchs.Domain, error) {
+	defer br.db.LockWrite(ctx)()
+
+	for i, batch := range br.db.Batchs {
+		if batch.ID == domain.ID {
+			if batch.Version != domain.Version {
+				break
+			}
+
+			domain.Version++
+			br.db.Batchs[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return batchs.Domain{}, helper.NewConflictError("batch", domain.ID)
+}
+
+/*
+Delete
+*/
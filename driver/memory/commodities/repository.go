@@ -0,0 +1,242 @@
+package commodities
+
+import (
+	"context"
+	"crop_connect/business/commodities"
+	memoryDriver "crop_connect/driver/memory"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type CommodityRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) commodities.Repository {
+	return &CommodityRepository{
+		db: db,
+	}
+}
+
+func isDeleted(domain commodities.Domain) bool {
+	return domain.DeletedAt != 0
+}
+
+func sortKey(sort string) func(commodities.Domain) interface{} {
+	switch sort {
+	case "name":
+		return func(domain commodities.Domain) interface{} { return domain.Name }
+	case "plantingPeriod":
+		return func(domain commodities.Domain) interface{} { return domain.PlantingPeriod }
+	case "pricePerKg":
+		return func(domain commodities.Domain) interface{} { return domain.PricePerKg }
+	case "isAvailable":
+		return func(domain commodities.Domain) interface{} { return domain.IsAvailable }
+	case "ratingAverage":
+		return func(domain commodities.Domain) interface{} { return domain.RatingAverage }
+	default:
+		return func(domain commodities.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (cr *CommodityRepository) findOne(filter func(commodities.Domain) bool) (commodities.Domain, error) {
+	cr.db.RLock()
+	defer cr.db.RUnlock()
+
+	for _, commodity := range cr.db.Commodities {
+		if filter(commodity) {
+			return commodity, nil
+		}
+	}
+
+	return commodities.Domain{}, mongo.ErrNoDocuments
+}
+
+func (cr *CommodityRepository) find(filter func(commodities.Domain) bool) []commodities.Domain {
+	cr.db.RLock()
+	defer cr.db.RUnlock()
+
+	result := []commodities.Domain{}
+	for _, commodity := range cr.db.Commodities {
+		if filter(commodity) {
+			result = append(result, commodity)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (cr *CommodityRepository) Create(domain *commodities.Domain) (commodities.Domain, error) {
+	defer cr.db.LockWrite(context.Background())()
+
+	cr.db.Commodities = append(cr.db.Commodities, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (cr *CommodityRepository) GetByID(id primitive.ObjectID) (commodities.Domain, error) {
+	return cr.findOne(func(commodity commodities.Domain) bool {
+		return commodity.ID == id && !isDeleted(commodity)
+	})
+}
+
+func (cr *CommodityRepository) GetByIDWithoutDeleted(id primitive.ObjectID) (commodities.Domain, error) {
+	return cr.findOne(func(commodity commodities.Domain) bool {
+		return commodity.ID == id
+	})
+}
+
+func (cr *CommodityRepository) GetByIDAndFarmerID(id primitive.ObjectID, farmerID primitive.ObjectID) (commodities.Domain, error) {
+	return cr.findOne(func(commodity commodities.Domain) bool {
+		return commodity.ID == id && commodity.FarmerID == farmerID && !isDeleted(commodity)
+	})
+}
+
+func (cr *CommodityRepository) GetByName(name string) (commodities.Domain, error) {
+	return cr.findOne(func(commodity commodities.Domain) bool {
+		return commodity.Name == name && !isDeleted(commodity)
+	})
+}
+
+func (cr *CommodityRepository) GetByNameAndFarmerID(name string, farmerID primitive.ObjectID) (commodities.Domain, error) {
+	return cr.findOne(func(commodity commodities.Domain) bool {
+		return commodity.Name == name && commodity.FarmerID == farmerID && !isDeleted(commodity)
+	})
+}
+
+func (cr *CommodityRepository) GetByFarmerID(farmerID primitive.ObjectID) ([]commodities.Domain, error) {
+	return cr.find(func(commodity commodities.Domain) bool {
+		return commodity.FarmerID == farmerID && !isDeleted(commodity)
+	}), nil
+}
+
+func (cr *CommodityRepository) GetByQuery(query commodities.Query) ([]commodities.Domain, int, error) {
+	cr.db.RLock()
+	defer cr.db.RUnlock()
+
+	result := []commodities.Domain{}
+	for _, commodity := range cr.db.Commodities {
+		if isDeleted(commodity) {
+			continue
+		}
+
+		if query.Name != "" && !memoryDriver.Regex(commodity.Name, query.Name, true) {
+			continue
+		}
+
+		if query.MinPrice != 0 && commodity.PricePerKg < query.MinPrice {
+			continue
+		}
+
+		if query.MaxPrice != 0 && commodity.PricePerKg > query.MaxPrice {
+			continue
+		}
+
+		farmer, _ := cr.db.FindUser(commodity.FarmerID)
+		if query.FarmerID != primitive.NilObjectID {
+			if commodity.FarmerID != query.FarmerID {
+				continue
+			}
+		} else if query.Farmer != "" && !memoryDriver.Regex(farmer.Name, query.Farmer, true) {
+			continue
+		}
+
+		if query.RegionID != primitive.NilObjectID {
+			if farmer.RegionID != query.RegionID {
+				continue
+			}
+		} else if query.Province != "" || query.Regency != "" || query.District != "" {
+			region, ok := cr.db.FindRegion(farmer.RegionID)
+			if !ok ||
+				(query.Province != "" && region.Province != query.Province) ||
+				(query.Regency != "" && region.Regency != query.Regency) ||
+				(query.District != "" && region.District != query.District) {
+				continue
+			}
+		}
+
+		result = append(result, commodity)
+	}
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (cr *CommodityRepository) CountTotalCommodity(year int) (int, error) {
+	return len(cr.find(func(commodity commodities.Domain) bool {
+		return memoryDriver.IsInYear(commodity.CreatedAt, year) && !isDeleted(commodity)
+	})), nil
+}
+
+func (cr *CommodityRepository) CountTotalCommodityByFarmer(farmerID primitive.ObjectID) (int, error) {
+	return len(cr.find(func(commodity commodities.Domain) bool {
+		return commodity.FarmerID == farmerID && !isDeleted(commodity)
+	})), nil
+}
+
+func (cr *CommodityRepository) GetByCode(code primitive.ObjectID) (commodities.Domain, error) {
+	return cr.findOne(func(commodity commodities.Domain) bool {
+		return commodity.Code == code && !isDeleted(commodity)
+	})
+}
+
+func (cr *CommodityRepository) GetPerennialsByFarmerID(farmerID primitive.ObjectID) ([]commodities.Domain, error) {
+	return cr.find(func(commodity commodities.Domain) bool {
+		return commodity.FarmerID == farmerID && commodity.IsPerennials && commodity.IsAvailable && !isDeleted(commodity)
+	}), nil
+}
+
+/*
+Update
+*/
+
+func (cr *CommodityRepository) Update(domain *commodities.Domain) (commodities.Domain, error) {
+	defer cr.db.LockWrite(context.Background())()
+
+	for i, commodity := range cr.db.Commodities {
+		if commodity.ID == domain.ID && !isDeleted(commodity) {
+			cr.db.Commodities[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (cr *CommodityRepository) AddRating(ctx context.Context, code primitive.ObjectID, score int) error {
+	defer cr.db.LockWrite(ctx)()
+
+	for i, commodity := range cr.db.Commodities {
+		if commodity.Code == code {
+			cr.db.Commodities[i].RatingAverage, cr.db.Commodities[i].RatingCount = memoryDriver.AddRating(commodity.RatingAverage, commodity.RatingCount, score)
+		}
+	}
+
+	return nil
+}
+
+/*
+Delete
+*/
+
+func (cr *CommodityRepository) Delete(id primitive.ObjectID) error {
+	defer cr.db.LockWrite(context.Background())()
+
+	for i, commodity := range cr.db.Commodities {
+		if commodity.ID == id && !isDeleted(commodity) {
+			cr.db.Commodities[i].DeletedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+	}
+
+	return nil
+}
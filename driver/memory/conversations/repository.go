@@ -0,0 +1,179 @@
+package conversations
+
+import (
+	"context"
+	"crop_connect/business/conversations"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ConversationRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) conversations.Repository {
+	return &ConversationRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(conversations.Domain) interface{} {
+	switch sort {
+	case "lastMessageAt":
+		return func(domain conversations.Domain) interface{} { return domain.LastMessageAt }
+	default:
+		return func(domain conversations.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (cr *ConversationRepository) find(filter func(conversations.Domain) bool) []conversations.Domain {
+	cr.db.RLock()
+	defer cr.db.RUnlock()
+
+	result := []conversations.Domain{}
+	for _, conversation := range cr.db.Conversations {
+		if filter(conversation) {
+			result = append(result, conversation)
+		}
+	}
+
+	return result
+}
+
+func (cr *ConversationRepository) findMessages(filter func(conversations.Message) bool) []conversations.Message {
+	cr.db.RLock()
+	defer cr.db.RUnlock()
+
+	result := []conversations.Message{}
+	for _, message := range cr.db.ConversationMessages {
+		if filter(message) {
+			result = append(result, message)
+		}
+	}
+
+	return result
+}
+
+func isUnread(message conversations.Message, conversationID primitive.ObjectID, readerID primitive.ObjectID) bool {
+	return message.ConversationID == conversationID && message.SenderID != readerID && message.ReadAt == 0
+}
+
+/*
+Create
+*/
+
+func (cr *ConversationRepository) Create(ctx context.Context, domain *conversations.Domain) (conversations.Domain, error) {
+	defer cr.db.LockWrite(ctx)()
+
+	cr.db.Conversations = append(cr.db.Conversations, *domain)
+	return *domain, nil
+}
+
+func (cr *ConversationRepository) CreateMessage(ctx context.Context, message *conversations.Message) (conversations.Message, error) {
+	defer cr.db.LockWrite(ctx)()
+
+	cr.db.ConversationMessages = append(cr.db.ConversationMessages, *message)
+	return *message, nil
+}
+
+/*
+Read
+*/
+
+func (cr *ConversationRepository) GetByID(id primitive.ObjectID) (conversations.Domain, error) {
+	result := cr.find(func(conversation conversations.Domain) bool {
+		return conversation.ID == id
+	})
+
+	if len(result) == 0 {
+		return conversations.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (cr *ConversationRepository) GetByBuyerIDAndSubject(buyerID primitive.ObjectID, subjectType string, subjectID primitive.ObjectID) (conversations.Domain, error) {
+	result := cr.find(func(conversation conversations.Domain) bool {
+		return conversation.BuyerID == buyerID && conversation.SubjectType == subjectType && conversation.SubjectID == subjectID
+	})
+
+	if len(result) == 0 {
+		return conversations.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (cr *ConversationRepository) GetByQuery(query conversations.Query) ([]conversations.Domain, int, error) {
+	result := cr.find(func(conversation conversations.Domain) bool {
+		if query.BuyerID != primitive.NilObjectID && conversation.BuyerID != query.BuyerID {
+			return false
+		}
+
+		if query.FarmerID != primitive.NilObjectID && conversation.FarmerID != query.FarmerID {
+			return false
+		}
+
+		if query.SubjectType != "" && conversation.SubjectType != query.SubjectType {
+			return false
+		}
+
+		return query.SubjectID == primitive.NilObjectID || conversation.SubjectID == query.SubjectID
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (cr *ConversationRepository) GetMessagesByQuery(query conversations.MessageQuery) ([]conversations.Message, int, error) {
+	result := cr.findMessages(func(message conversations.Message) bool {
+		return message.ConversationID == query.ConversationID
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, func(message conversations.Message) interface{} { return message.CreatedAt })
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (cr *ConversationRepository) CountUnreadMessages(conversationID primitive.ObjectID, readerID primitive.ObjectID) (int, error) {
+	return len(cr.findMessages(func(message conversations.Message) bool {
+		return isUnread(message, conversationID, readerID)
+	})), nil
+}
+
+/*
+Update
+*/
+
+func (cr *ConversationRepository) Update(ctx context.Context, domain *conversations.Domain) (conversations.Domain, error) {
+	defer cr.db.LockWrite(ctx)()
+
+	for i, conversation := range cr.db.Conversations {
+		if conversation.ID == domain.ID {
+			cr.db.Conversations[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (cr *ConversationRepository) MarkMessagesAsRead(conversationID primitive.ObjectID, readerID primitive.ObjectID, readAt primitive.DateTime) error {
+	defer cr.db.LockWrite(context.Background())()
+
+	for i, message := range cr.db.ConversationMessages {
+		if isUnread(message, conversationID, readerID) {
+			cr.db.ConversationMessages[i].ReadAt = readAt
+		}
+	}
+
+	return nil
+}
+
+/*
+Delete
+*/
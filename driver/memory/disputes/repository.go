@@ -0,0 +1,151 @@
+package disputes
+
+import (
+	"context"
+	"crop_connect/business/disputes"
+	"crop_connect/constant"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/helper"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DisputeRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) disputes.Repository {
+	return &DisputeRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(disputes.Domain) interface{} {
+	switch sort {
+	case "status":
+		return func(domain disputes.Domain) interface{} { return domain.Status }
+	case "updatedAt":
+		return func(domain disputes.Domain) interface{} { return domain.UpdatedAt }
+	default:
+		return func(domain disputes.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (dr *DisputeRepository) find(filter func(disputes.Domain) bool) []disputes.Domain {
+	dr.db.RLock()
+	defer dr.db.RUnlock()
+
+	result := []disputes.Domain{}
+	for _, dispute := range dr.db.Disputes {
+		if filter(dispute) {
+			result = append(result, dispute)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (dr *DisputeRepository) Create(ctx context.Context, domain *disputes.Domain) (disputes.Domain, error) {
+	defer dr.db.LockWrite(ctx)()
+
+	dr.db.Disputes = append(dr.db.Disputes, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (dr *DisputeRepository) GetByID(id primitive.ObjectID) (disputes.Domain, error) {
+	result := dr.find(func(dispute disputes.Domain) bool {
+		return dispute.ID == id
+	})
+
+	if len(result) == 0 {
+		return disputes.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (dr *DisputeRepository) GetOpenByTransactionID(transactionID primitive.ObjectID) (disputes.Domain, error) {
+	result := dr.find(func(dispute disputes.Domain) bool {
+		return dispute.TransactionID == transactionID && dispute.Status == constant.DisputeStatusOpen
+	})
+
+	if len(result) == 0 {
+		return disputes.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (dr *DisputeRepository) GetByQuery(query disputes.Query) ([]disputes.Domain, int, error) {
+	result := dr.find(func(dispute disputes.Domain) bool {
+		if query.BuyerID != primitive.NilObjectID && dispute.BuyerID != query.BuyerID {
+			return false
+		}
+
+		if query.FarmerID != primitive.NilObjectID && dispute.FarmerID != query.FarmerID {
+			return false
+		}
+
+		if query.ValidatorID != primitive.NilObjectID && dispute.ValidatorID != query.ValidatorID {
+			return false
+		}
+
+		return query.Status == "" || dispute.Status == query.Status
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+/*
+Update
+*/
+
+func (dr *DisputeRepository) AddMessage(ctx context.Context, id primitive.ObjectID, message *disputes.Message) error {
+	defer dr.db.LockWrite(ctx)()
+
+	for i, dispute := range dr.db.Disputes {
+		if dispute.ID == id {
+			// copy the messages so a snapshot taken by the unit of work keeps its own slice
+			dr.db.Disputes[i].Messages = append(append([]disputes.Message{}, dispute.Messages...), *message)
+			dr.db.Disputes[i].UpdatedAt = message.CreatedAt
+			return nil
+		}
+	}
+
+	return mongo.ErrNoDocuments
+}
+
+func (dr *DisputeRepository) Update(ctx context.Context, domain *disputes.Domain) (disputes.Domain, error) {
+	defer dr.db.LockWrite(ctx)()
+
+	for i, dispute := range dr.db.Disputes {
+		if dispute.ID == domain.ID {
+			if dispute.Version != domain.Version {
+				break
+			}
+
+			domain.Version++
+			domain.Messages = dispute.Messages
+			dr.db.Disputes[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return disputes.Domain{}, helper.NewConflictError("sengketa", domain.ID)
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,97 @@
+package email_verifications
+
+import (
+	"context"
+	emailVerification "crop_connect/business/email_verifications"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type EmailVerificationRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) emailVerification.Repository {
+	return &EmailVerificationRepository{
+		db: db,
+	}
+}
+
+/*
+Create
+*/
+
+func (evr *EmailVerificationRepository) Create(domain *emailVerification.Domain) (emailVerification.Domain, error) {
+	defer evr.db.LockWrite(context.Background())()
+
+	evr.db.EmailVerifications = append(evr.db.EmailVerifications, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (evr *EmailVerificationRepository) GetByToken(token string) (emailVerification.Domain, error) {
+	evr.db.RLock()
+	defer evr.db.RUnlock()
+
+	for _, emailVerification := range evr.db.EmailVerifications {
+		if emailVerification.Token == token {
+			return emailVerification, nil
+		}
+	}
+
+	return emailVerification.Domain{}, mongo.ErrNoDocuments
+}
+
+/*
+Update
+*/
+
+func (evr *EmailVerificationRepository) Update(domain *emailVerification.Domain) (emailVerification.Domain, error) {
+	defer evr.db.LockWrite(context.Background())()
+
+	for i, emailVerification := range evr.db.EmailVerifications {
+		if emailVerification.ID == domain.ID {
+			evr.db.EmailVerifications[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+/*
+Delete
+*/
+
+func (evr *EmailVerificationRepository) HardDelete(id primitive.ObjectID) error {
+	defer evr.db.LockWrite(context.Background())()
+
+	for i, emailVerification := range evr.db.EmailVerifications {
+		if emailVerification.ID == id {
+			evr.db.EmailVerifications = append(evr.db.EmailVerifications[:i], evr.db.EmailVerifications[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (evr *EmailVerificationRepository) HardDeleteExpired(now primitive.DateTime) (int, error) {
+	defer evr.db.LockWrite(context.Background())()
+
+	remaining := []emailVerification.Domain{}
+	for _, emailVerification := range evr.db.EmailVerifications {
+		if emailVerification.ExpiredAt >= now {
+			remaining = append(remaining, emailVerification)
+		}
+	}
+
+	totalDeleted := len(evr.db.EmailVerifications) - len(remaining)
+	evr.db.EmailVerifications = remaining
+
+	return totalDeleted, nil
+}
@@ -0,0 +1,97 @@
+package forgot_password
+
+import (
+	"context"
+	forgotPassword "crop_connect/business/forgot_password"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ForgotPasswordRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) forgotPassword.Repository {
+	return &ForgotPasswordRepository{
+		db: db,
+	}
+}
+
+/*
+Create
+*/
+
+func (fpr *ForgotPasswordRepository) Create(domain *forgotPassword.Domain) (forgotPassword.Domain, error) {
+	defer fpr.db.LockWrite(context.Background())()
+
+	fpr.db.ForgotPasswords = append(fpr.db.ForgotPasswords, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (fpr *ForgotPasswordRepository) GetByToken(token string) (forgotPassword.Domain, error) {
+	fpr.db.RLock()
+	defer fpr.db.RUnlock()
+
+	for _, forgotPassword := range fpr.db.ForgotPasswords {
+		if forgotPassword.Token == token {
+			return forgotPassword, nil
+		}
+	}
+
+	return forgotPassword.Domain{}, mongo.ErrNoDocuments
+}
+
+/*
+Update
+*/
+
+func (fpr *ForgotPasswordRepository) Update(domain *forgotPassword.Domain) (forgotPassword.Domain, error) {
+	defer fpr.db.LockWrite(context.Background())()
+
+	for i, forgotPassword := range fpr.db.ForgotPasswords {
+		if forgotPassword.ID == domain.ID {
+			fpr.db.ForgotPasswords[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+/*
+Delete
+*/
+
+func (fpr *ForgotPasswordRepository) HardDelete(id primitive.ObjectID) error {
+	defer fpr.db.LockWrite(context.Background())()
+
+	for i, forgotPassword := range fpr.db.ForgotPasswords {
+		if forgotPassword.ID == id {
+			fpr.db.ForgotPasswords = append(fpr.db.ForgotPasswords[:i], fpr.db.ForgotPasswords[i+1:]...)
+			break
+		}
+	}
+
+	return nil
+}
+
+func (fpr *ForgotPasswordRepository) HardDeleteExpired(now primitive.DateTime) (int, error) {
+	defer fpr.db.LockWrite(context.Background())()
+
+	remaining := []forgotPassword.Domain{}
+	for _, forgotPassword := range fpr.db.ForgotPasswords {
+		if forgotPassword.ExpiredAt >= now {
+			remaining = append(remaining, forgotPassword)
+		}
+	}
+
+	totalDeleted := len(fpr.db.ForgotPasswords) - len(remaining)
+	fpr.db.ForgotPasswords = remaining
+
+	return totalDeleted, nil
+}
@@ -0,0 +1,152 @@
+package harvests
+
+import (
+	"context"
+	"crop_connect/business/harvests"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type HarvestRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) harvests.Repository {
+	return &HarvestRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(harvests.Domain) interface{} {
+	switch sort {
+	case "status":
+		return func(domain harvests.Domain) interface{} { return domain.Status }
+	case "totalHarvest":
+		return func(domain harvests.Domain) interface{} { return domain.TotalHarvest }
+	default:
+		return func(domain harvests.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (hr *HarvestRepository) find(filter func(harvests.Domain) bool) []harvests.Domain {
+	hr.db.RLock()
+	defer hr.db.RUnlock()
+
+	result := []harvests.Domain{}
+	for _, harvest := range hr.db.Harvests {
+		if filter(harvest) {
+			result = append(result, harvest)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (hr *HarvestRepository) Create(domain *harvests.Domain) (harvests.Domain, error) {
+	defer hr.db.LockWrite(context.Background())()
+
+	hr.db.Harvests = append(hr.db.Harvests, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (hr *HarvestRepository) GetByID(id primitive.ObjectID) (harvests.Domain, error) {
+	result := hr.find(func(harvest harvests.Domain) bool {
+		return harvest.ID == id
+	})
+	if len(result) == 0 {
+		return harvests.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (hr *HarvestRepository) GetByBatchIDAndStatus(batchID primitive.ObjectID, status string) (harvests.Domain, error) {
+	result := hr.find(func(harvest harvests.Domain) bool {
+		return harvest.BatchID == batchID && (status == "" || harvest.Status == status)
+	})
+	if len(result) == 0 {
+		return harvests.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (hr *HarvestRepository) GetByQuery(query harvests.Query) ([]harvests.Domain, int, error) {
+	result := hr.find(func(harvest harvests.Domain) bool {
+		if query.Status != "" && harvest.Status != query.Status {
+			return false
+		}
+
+		if query.BatchID != primitive.NilObjectID {
+			if harvest.BatchID != query.BatchID {
+				return false
+			}
+		} else if query.Batch != "" {
+			batch, ok := hr.db.FindBatch(harvest.BatchID)
+			if !ok || !memoryDriver.Regex(batch.Name, query.Batch, true) {
+				return false
+			}
+		}
+
+		commodity, _ := hr.db.FindCommodityByBatchID(harvest.BatchID)
+		if query.CommodityID != primitive.NilObjectID {
+			if commodity.ID != query.CommodityID {
+				return false
+			}
+		} else if query.Commodity != "" && !memoryDriver.Regex(commodity.Name, query.Commodity, true) {
+			return false
+		}
+
+		if query.FarmerID != primitive.NilObjectID && commodity.FarmerID != query.FarmerID {
+			return false
+		}
+
+		return true
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (hr *HarvestRepository) CountByYear(year int) (float64, error) {
+	total := 0.0
+	for _, harvest := range hr.find(func(harvest harvests.Domain) bool {
+		return memoryDriver.IsInYear(harvest.CreatedAt, year)
+	}) {
+		total += harvest.TotalHarvest
+	}
+
+	return total, nil
+}
+
+/*
+Update
+*/
+
+func (hr *HarvestRepository) Update(ctx context.Context, domain *harvests.Domain) (harvests.Domain, error) {
+	defer hr.db.LockWrite(ctx)()
+
+	for i, harvest := range hr.db.Harvests {
+		if harvest.ID == domain.ID {
+			hr.db.Harvests[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+/*
+Delete
+*/
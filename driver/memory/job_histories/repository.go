@@ -0,0 +1,67 @@
+package job_histories
+
+import (
+	"context"
+	jobHistories "crop_connect/business/job_histories"
+	memoryDriver "crop_connect/driver/memory"
+)
+
+type JobHistoryRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) jobHistories.Repository {
+	return &JobHistoryRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(jobHistories.Domain) interface{} {
+	switch sort {
+	case "name":
+		return func(domain jobHistories.Domain) interface{} { return domain.Name }
+	case "finishedAt":
+		return func(domain jobHistories.Domain) interface{} { return domain.FinishedAt }
+	default:
+		return func(domain jobHistories.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+/*
+Create
+*/
+
+func (jhr *JobHistoryRepository) Create(ctx context.Context, domain *jobHistories.Domain) (jobHistories.Domain, error) {
+	defer jhr.db.LockWrite(ctx)()
+
+	jhr.db.JobHistories = append(jhr.db.JobHistories, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (jhr *JobHistoryRepository) GetByQuery(query jobHistories.Query) ([]jobHistories.Domain, int, error) {
+	jhr.db.RLock()
+	result := []jobHistories.Domain{}
+	for _, jobHistory := range jhr.db.JobHistories {
+		if (query.Name == "" || jobHistory.Name == query.Name) && (query.Status == "" || jobHistory.Status == query.Status) {
+			result = append(result, jobHistory)
+		}
+	}
+	jhr.db.RUnlock()
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
@@ -0,0 +1,93 @@
+package jobs
+
+import (
+	"context"
+	"crop_connect/business/jobs"
+	"crop_connect/constant"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type JobRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) jobs.Repository {
+	return &JobRepository{
+		db: db,
+	}
+}
+
+/*
+Create
+*/
+
+func (jr *JobRepository) Create(ctx context.Context, domain *jobs.Domain) (jobs.Domain, error) {
+	defer jr.db.LockWrite(ctx)()
+
+	jr.db.Jobs = append(jr.db.Jobs, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (jr *JobRepository) ClaimNext(now primitive.DateTime, lockedUntil primitive.DateTime) (jobs.Domain, error) {
+	defer jr.db.LockWrite(context.Background())()
+
+	claimed := -1
+	for i, job := range jr.db.Jobs {
+		isDue := job.Status == constant.JobStatusPending && job.RunAt <= now
+		isExpired := job.Status == constant.JobStatusProcessing && job.LockedUntil < now
+		if (isDue || isExpired) && (claimed == -1 || job.RunAt < jr.db.Jobs[claimed].RunAt) {
+			claimed = i
+		}
+	}
+
+	if claimed == -1 {
+		return jobs.Domain{}, mongo.ErrNoDocuments
+	}
+
+	jr.db.Jobs[claimed].Status = constant.JobStatusProcessing
+	jr.db.Jobs[claimed].LockedUntil = lockedUntil
+	jr.db.Jobs[claimed].UpdatedAt = now
+	jr.db.Jobs[claimed].Attempts++
+
+	return jr.db.Jobs[claimed], nil
+}
+
+/*
+Update
+*/
+
+func (jr *JobRepository) Update(domain *jobs.Domain) (jobs.Domain, error) {
+	defer jr.db.LockWrite(context.Background())()
+
+	for i, job := range jr.db.Jobs {
+		if job.ID == domain.ID {
+			jr.db.Jobs[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return jobs.Domain{}, mongo.ErrNoDocuments
+}
+
+func (jr *JobRepository) ExtendLock(id primitive.ObjectID, lockedUntil primitive.DateTime) error {
+	defer jr.db.LockWrite(context.Background())()
+
+	for i, job := range jr.db.Jobs {
+		if job.ID == id && job.Status == constant.JobStatusProcessing {
+			jr.db.Jobs[i].LockedUntil = lockedUntil
+		}
+	}
+
+	return nil
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,356 @@
+package memory_driver
+
+import (
+	"context"
+	apiKeys "crop_connect/business/api_keys"
+	auditEvents "crop_connect/business/audit_events"
+	"crop_connect/business/batchs"
+	"crop_connect/business/commodities"
+	"crop_connect/business/conversations"
+	"crop_connect/business/disputes"
+	emailVerifications "crop_connect/business/email_verifications"
+	forgotPassword "crop_connect/business/forgot_password"
+	"crop_connect/business/harvests"
+	jobHistories "crop_connect/business/job_histories"
+	"crop_connect/business/jobs"
+	"crop_connect/business/notifications"
+	"crop_connect/business/payments"
+	"crop_connect/business/proposals"
+	"crop_connect/business/ratings"
+	"crop_connect/business/regions"
+	revokedTokens "crop_connect/business/revoked_tokens"
+	"crop_connect/business/sessions"
+	"crop_connect/business/shipments"
+	"crop_connect/business/transactions"
+	treatmentRecords "crop_connect/business/treatment_records"
+	"crop_connect/business/users"
+	"crop_connect/business/webhooks"
+	"crop_connect/dto"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Database keeps every collection in memory so the use cases can run without MongoDB.
+// Repositories must hold the read lock while reading the collections and take LockWrite while writing them.
+type Database struct {
+	sync.RWMutex
+	UnitOfWork           sync.Mutex
+	Users                []users.Domain
+	Commodities          []commodities.Domain
+	Proposals            []proposals.Domain
+	Transactions         []transactions.Domain
+	Batchs               []batchs.Domain
+	TreatmentRecords     []treatmentRecords.Domain
+	Harvests             []harvests.Domain
+	Regions              []regions.Domain
+	ForgotPasswords      []forgotPassword.Domain
+	Payments             []payments.Domain
+	Shipments            []shipments.Domain
+	Notifications        []notifications.Domain
+	Jobs                 []jobs.Domain
+	JobHistories         []jobHistories.Domain
+	Sessions             []sessions.Domain
+	RevokedTokens        []revokedTokens.Domain
+	EmailVerifications   []emailVerifications.Domain
+	AuditEvents          []auditEvents.Domain
+	Disputes             []disputes.Domain
+	Ratings              []ratings.Domain
+	Conversations        []conversations.Domain
+	ConversationMessages []conversations.Message
+	Webhooks             []webhooks.Domain
+	WebhookDeliveries    []webhooks.Delivery
+	APIKeys              []apiKeys.Domain
+}
+
+type unitOfWorkKey struct{}
+
+// WithUnitOfWork marks the context given to the function of a unit of work, the writes made with it already hold the UnitOfWork lock.
+func WithUnitOfWork(ctx context.Context) context.Context {
+	return context.WithValue(ctx, unitOfWorkKey{}, true)
+}
+
+// LockWrite takes the write lock and returns the function that releases it.
+// A write outside a unit of work first waits for the running unit of work, so restoring the snapshot of a failed unit cannot drop it.
+func (db *Database) LockWrite(ctx context.Context) func() {
+	isInUnitOfWork, _ := ctx.Value(unitOfWorkKey{}).(bool)
+	if !isInUnitOfWork {
+		db.UnitOfWork.Lock()
+	}
+
+	db.Lock()
+	return func() {
+		db.Unlock()
+		if !isInUnitOfWork {
+			db.UnitOfWork.Unlock()
+		}
+	}
+}
+
+func Init() *Database {
+	return &Database{}
+}
+
+func Close(db *Database) error {
+	db.Restore(&Database{})
+	return nil
+}
+
+// Snapshot copies every collection so a failed unit of work can be rolled back.
+func (db *Database) Snapshot() *Database {
+	db.RLock()
+	defer db.RUnlock()
+
+	return &Database{
+		Users:                append([]users.Domain{}, db.Users...),
+		Commodities:          append([]commodities.Domain{}, db.Commodities...),
+		Proposals:            append([]proposals.Domain{}, db.Proposals...),
+		Transactions:         append([]transactions.Domain{}, db.Transactions...),
+		Batchs:               append([]batchs.Domain{}, db.Batchs...),
+		TreatmentRecords:     append([]treatmentRecords.Domain{}, db.TreatmentRecords...),
+		Harvests:             append([]harvests.Domain{}, db.Harvests...),
+		Regions:              append([]regions.Domain{}, db.Regions...),
+		ForgotPasswords:      append([]forgotPassword.Domain{}, db.ForgotPasswords...),
+		Payments:             append([]payments.Domain{}, db.Payments...),
+		Shipments:            append([]shipments.Domain{}, db.Shipments...),
+		Notifications:        append([]notifications.Domain{}, db.Notifications...),
+		Jobs:                 append([]jobs.Domain{}, db.Jobs...),
+		JobHistories:         append([]jobHistories.Domain{}, db.JobHistories...),
+		Sessions:             append([]sessions.Domain{}, db.Sessions...),
+		RevokedTokens:        append([]revokedTokens.Domain{}, db.RevokedTokens...),
+		EmailVerifications:   append([]emailVerifications.Domain{}, db.EmailVerifications...),
+		AuditEvents:          append([]auditEvents.Domain{}, db.AuditEvents...),
+		Disputes:             append([]disputes.Domain{}, db.Disputes...),
+		Ratings:              append([]ratings.Domain{}, db.Ratings...),
+		Conversations:        append([]conversations.Domain{}, db.Conversations...),
+		ConversationMessages: append([]conversations.Message{}, db.ConversationMessages...),
+		Webhooks:             append([]webhooks.Domain{}, db.Webhooks...),
+		WebhookDeliveries:    append([]webhooks.Delivery{}, db.WebhookDeliveries...),
+		APIKeys:              append([]apiKeys.Domain{}, db.APIKeys...),
+	}
+}
+
+func (db *Database) Restore(snapshot *Database) {
+	db.Lock()
+	defer db.Unlock()
+
+	db.Users = snapshot.Users
+	db.Commodities = snapshot.Commodities
+	db.Proposals = snapshot.Proposals
+	db.Transactions = snapshot.Transactions
+	db.Batchs = snapshot.Batchs
+	db.TreatmentRecords = snapshot.TreatmentRecords
+	db.Harvests = snapshot.Harvests
+	db.Regions = snapshot.Regions
+	db.ForgotPasswords = snapshot.ForgotPasswords
+	db.Payments = snapshot.Payments
+	db.Shipments = snapshot.Shipments
+	db.Notifications = snapshot.Notifications
+	db.Jobs = snapshot.Jobs
+	db.JobHistories = snapshot.JobHistories
+	db.Sessions = snapshot.Sessions
+	db.RevokedTokens = snapshot.RevokedTokens
+	db.EmailVerifications = snapshot.EmailVerifications
+	db.AuditEvents = snapshot.AuditEvents
+	db.Disputes = snapshot.Disputes
+	db.Ratings = snapshot.Ratings
+	db.Conversations = snapshot.Conversations
+	db.ConversationMessages = snapshot.ConversationMessages
+	db.Webhooks = snapshot.Webhooks
+	db.WebhookDeliveries = snapshot.WebhookDeliveries
+	db.APIKeys = snapshot.APIKeys
+}
+
+/*
+Lookup, the caller must hold the lock
+*/
+
+func (db *Database) FindUser(id primitive.ObjectID) (users.Domain, bool) {
+	for _, user := range db.Users {
+		if user.ID == id {
+			return user, true
+		}
+	}
+
+	return users.Domain{}, false
+}
+
+func (db *Database) FindRegion(id primitive.ObjectID) (regions.Domain, bool) {
+	for _, region := range db.Regions {
+		if region.ID == id {
+			return region, true
+		}
+	}
+
+	return regions.Domain{}, false
+}
+
+func (db *Database) FindCommodity(id primitive.ObjectID) (commodities.Domain, bool) {
+	for _, commodity := range db.Commodities {
+		if commodity.ID == id {
+			return commodity, true
+		}
+	}
+
+	return commodities.Domain{}, false
+}
+
+func (db *Database) FindProposal(id primitive.ObjectID) (proposals.Domain, bool) {
+	for _, proposal := range db.Proposals {
+		if proposal.ID == id {
+			return proposal, true
+		}
+	}
+
+	return proposals.Domain{}, false
+}
+
+func (db *Database) FindBatch(id primitive.ObjectID) (batchs.Domain, bool) {
+	for _, batch := range db.Batchs {
+		if batch.ID == id {
+			return batch, true
+		}
+	}
+
+	return batchs.Domain{}, false
+}
+
+func (db *Database) FindHarvestByBatchID(batchID primitive.ObjectID) (harvests.Domain, bool) {
+	for _, harvest := range db.Harvests {
+		if harvest.BatchID == batchID {
+			return harvest, true
+		}
+	}
+
+	return harvests.Domain{}, false
+}
+
+func (db *Database) FindCommodityByProposalID(proposalID primitive.ObjectID) (commodities.Domain, bool) {
+	proposal, ok := db.FindProposal(proposalID)
+	if !ok {
+		return commodities.Domain{}, false
+	}
+
+	return db.FindCommodity(proposal.CommodityID)
+}
+
+func (db *Database) FindCommodityByBatchID(batchID primitive.ObjectID) (commodities.Domain, bool) {
+	batch, ok := db.FindBatch(batchID)
+	if !ok {
+		return commodities.Domain{}, false
+	}
+
+	return db.FindCommodityByProposalID(batch.ProposalID)
+}
+
+/*
+Query helper
+*/
+
+// Regex behaves like the $regex operator, falling back to a substring match when the pattern is invalid.
+func Regex(value string, pattern string, caseInsensitive bool) bool {
+	if caseInsensitive {
+		pattern = "(?i)" + pattern
+	}
+
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		if caseInsensitive {
+			return strings.Contains(strings.ToLower(value), strings.ToLower(strings.TrimPrefix(pattern, "(?i)")))
+		}
+
+		return strings.Contains(value, pattern)
+	}
+
+	return re.MatchString(value)
+}
+
+func Compare(a interface{}, b interface{}) int {
+	switch valueA := a.(type) {
+	case string:
+		return strings.Compare(valueA, b.(string))
+	case int:
+		return compareOrdered(float64(valueA), float64(b.(int)))
+	case int64:
+		return compareOrdered(float64(valueA), float64(b.(int64)))
+	case float64:
+		return compareOrdered(valueA, b.(float64))
+	case primitive.DateTime:
+		return compareOrdered(float64(valueA), float64(b.(primitive.DateTime)))
+	case bool:
+		if valueA == b.(bool) {
+			return 0
+		} else if !valueA {
+			return -1
+		}
+		return 1
+	case primitive.ObjectID:
+		return strings.Compare(valueA.Hex(), b.(primitive.ObjectID).Hex())
+	}
+
+	return 0
+}
+
+func compareOrdered(a float64, b float64) int {
+	if a < b {
+		return -1
+	} else if a > b {
+		return 1
+	}
+	return 0
+}
+
+// Sort orders the items by the value returned from key, order follows the mongo convention (1 asc, -1 desc).
+func Sort[T any](items []T, order int, key func(T) interface{}) {
+	sort.SliceStable(items, func(i, j int) bool {
+		if order < 0 {
+			return Compare(key(items[i]), key(items[j])) > 0
+		}
+		return Compare(key(items[i]), key(items[j])) < 0
+	})
+}
+
+func Paginate[T any](items []T, skip int64, limit int64) []T {
+	if skip >= int64(len(items)) {
+		return []T{}
+	}
+
+	end := int64(len(items))
+	if limit > 0 && skip+limit < end {
+		end = skip + limit
+	}
+
+	return items[skip:end]
+}
+
+// AddRating folds a new score into an average taken over count scores.
+func AddRating(average float64, count int, score int) (float64, int) {
+	return (average*float64(count) + float64(score)) / float64(count+1), count + 1
+}
+
+func IsBetween(date primitive.DateTime, start time.Time, end time.Time) bool {
+	return date >= primitive.NewDateTimeFromTime(start) && date <= primitive.NewDateTimeFromTime(end)
+}
+
+func IsInYear(date primitive.DateTime, year int) bool {
+	return IsBetween(date, time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC), time.Date(year+1, 1, 1, 0, 0, 0, 0, time.UTC))
+}
+
+// ToStatisticByYear mirrors the $group by $month stage, months without data are left out.
+func ToStatisticByYear(totalByMonth map[int]int) []dto.StatisticByYear {
+	result := []dto.StatisticByYear{}
+	for month := 1; month <= 12; month++ {
+		if total, ok := totalByMonth[month]; ok {
+			result = append(result, dto.StatisticByYear{
+				Month: month,
+				Total: total,
+			})
+		}
+	}
+
+	return result
+}
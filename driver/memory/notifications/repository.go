@@ -0,0 +1,127 @@
+package notifications
+
+import (
+	"context"
+	"crop_connect/business/notifications"
+	memoryDriver "crop_connect/driver/memory"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type NotificationRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) notifications.Repository {
+	return &NotificationRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(notifications.Domain) interface{} {
+	switch sort {
+	case "type":
+		return func(domain notifications.Domain) interface{} { return domain.Type }
+	default:
+		return func(domain notifications.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (nr *NotificationRepository) find(filter func(notifications.Domain) bool) []notifications.Domain {
+	nr.db.RLock()
+	defer nr.db.RUnlock()
+
+	result := []notifications.Domain{}
+	for _, notification := range nr.db.Notifications {
+		if filter(notification) {
+			result = append(result, notification)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (nr *NotificationRepository) Create(ctx context.Context, domain *notifications.Domain) (notifications.Domain, error) {
+	defer nr.db.LockWrite(ctx)()
+
+	nr.db.Notifications = append(nr.db.Notifications, *domain)
+	return *domain, nil
+}
+
+func (nr *NotificationRepository) CreateMany(ctx context.Context, domains []notifications.Domain) error {
+	defer nr.db.LockWrite(ctx)()
+
+	nr.db.Notifications = append(nr.db.Notifications, domains...)
+	return nil
+}
+
+/*
+Read
+*/
+
+func (nr *NotificationRepository) GetByQuery(query notifications.Query) ([]notifications.Domain, int, error) {
+	result := nr.find(func(notification notifications.Domain) bool {
+		if notification.UserID != query.UserID {
+			return false
+		}
+
+		return query.IsRead == nil || notification.IsRead == *query.IsRead
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (nr *NotificationRepository) CountUnreadByUserID(userID primitive.ObjectID) (int, error) {
+	result := nr.find(func(notification notifications.Domain) bool {
+		return notification.UserID == userID && !notification.IsRead
+	})
+
+	return len(result), nil
+}
+
+/*
+Update
+*/
+
+func (nr *NotificationRepository) MarkAsRead(id primitive.ObjectID, userID primitive.ObjectID) error {
+	defer nr.db.LockWrite(context.Background())()
+
+	for i, notification := range nr.db.Notifications {
+		if notification.ID == id && notification.UserID == userID {
+			if !notification.IsRead {
+				nr.db.Notifications[i].IsRead = true
+				nr.db.Notifications[i].ReadAt = primitive.NewDateTimeFromTime(time.Now())
+			}
+
+			return nil
+		}
+	}
+
+	return mongo.ErrNoDocuments
+}
+
+func (nr *NotificationRepository) MarkAllAsRead(userID primitive.ObjectID) error {
+	defer nr.db.LockWrite(context.Background())()
+
+	for i, notification := range nr.db.Notifications {
+		if notification.UserID == userID && !notification.IsRead {
+			nr.db.Notifications[i].IsRead = true
+			nr.db.Notifications[i].ReadAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+	}
+
+	return nil
+}
+
+/*
+Delete
+*/
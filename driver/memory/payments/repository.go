@@ -0,0 +1,111 @@
+package payments
+
+import (
+	"context"
+	"crop_connect/business/payments"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type PaymentRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) payments.Repository {
+	return &PaymentRepository{
+		db: db,
+	}
+}
+
+func (pr *PaymentRepository) findOne(filter func(payments.Domain) bool) (payments.Domain, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	for _, payment := range pr.db.Payments {
+		if filter(payment) {
+			return payment, nil
+		}
+	}
+
+	return payments.Domain{}, mongo.ErrNoDocuments
+}
+
+/*
+Create
+*/
+
+func (pr *PaymentRepository) Create(domain *payments.Domain) (payments.Domain, error) {
+	defer pr.db.LockWrite(context.Background())()
+
+	pr.db.Payments = append(pr.db.Payments, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (pr *PaymentRepository) GetByID(id primitive.ObjectID) (payments.Domain, error) {
+	return pr.findOne(func(payment payments.Domain) bool {
+		return payment.ID == id
+	})
+}
+
+func (pr *PaymentRepository) GetByExternalID(externalID string) (payments.Domain, error) {
+	return pr.findOne(func(payment payments.Domain) bool {
+		return payment.ExternalID == externalID
+	})
+}
+
+func (pr *PaymentRepository) GetByTransactionID(transactionID primitive.ObjectID) ([]payments.Domain, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	result := []payments.Domain{}
+	for _, payment := range pr.db.Payments {
+		if payment.TransactionID == transactionID {
+			result = append(result, payment)
+		}
+	}
+
+	memoryDriver.Sort(result, -1, func(payment payments.Domain) interface{} {
+		return payment.CreatedAt
+	})
+
+	return result, nil
+}
+
+/*
+Update
+*/
+
+func (pr *PaymentRepository) Update(ctx context.Context, domain *payments.Domain) (payments.Domain, error) {
+	defer pr.db.LockWrite(ctx)()
+
+	for i, payment := range pr.db.Payments {
+		if payment.ID == domain.ID {
+			pr.db.Payments[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (pr *PaymentRepository) UpdateByStatus(ctx context.Context, domain *payments.Domain, status string) (payments.Domain, error) {
+	defer pr.db.LockWrite(ctx)()
+
+	for i, payment := range pr.db.Payments {
+		if payment.ID == domain.ID && payment.Status == status {
+			pr.db.Payments[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return payments.Domain{}, mongo.ErrNoDocuments
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,277 @@
+package proposals
+
+import (
+	"context"
+	"crop_connect/business/proposals"
+	"crop_connect/constant"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/dto"
+	"crop_connect/helper"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ProposalRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) proposals.Repository {
+	return &ProposalRepository{
+		db: db,
+	}
+}
+
+func isDeleted(domain proposals.Domain) bool {
+	return domain.DeletedAt != 0
+}
+
+func sortKey(sort string) func(proposals.Domain) interface{} {
+	switch sort {
+	case "name":
+		return func(domain proposals.Domain) interface{} { return domain.Name }
+	case "status":
+		return func(domain proposals.Domain) interface{} { return domain.Status }
+	case "plantingArea":
+		return func(domain proposals.Domain) interface{} { return domain.PlantingArea }
+	case "estimatedTotalHarvest":
+		return func(domain proposals.Domain) interface{} { return domain.EstimatedTotalHarvest }
+	case "isAvailable":
+		return func(domain proposals.Domain) interface{} { return domain.IsAvailable }
+	default:
+		return func(domain proposals.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (pr *ProposalRepository) findOne(filter func(proposals.Domain) bool) (proposals.Domain, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	for _, proposal := range pr.db.Proposals {
+		if filter(proposal) {
+			return proposal, nil
+		}
+	}
+
+	return proposals.Domain{}, mongo.ErrNoDocuments
+}
+
+func (pr *ProposalRepository) find(filter func(proposals.Domain) bool) []proposals.Domain {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	result := []proposals.Domain{}
+	for _, proposal := range pr.db.Proposals {
+		if filter(proposal) {
+			result = append(result, proposal)
+		}
+	}
+
+	return result
+}
+
+// farmerID must be called while holding the lock.
+func (pr *ProposalRepository) farmerID(proposal proposals.Domain) primitive.ObjectID {
+	commodity, _ := pr.db.FindCommodity(proposal.CommodityID)
+	return commodity.FarmerID
+}
+
+/*
+Create
+*/
+
+func (pr *ProposalRepository) Create(domain *proposals.Domain) (proposals.Domain, error) {
+	defer pr.db.LockWrite(context.Background())()
+
+	pr.db.Proposals = append(pr.db.Proposals, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (pr *ProposalRepository) GetByID(id primitive.ObjectID) (proposals.Domain, error) {
+	return pr.findOne(func(proposal proposals.Domain) bool {
+		return proposal.ID == id && !isDeleted(proposal)
+	})
+}
+
+func (pr *ProposalRepository) GetByIDWithoutDeleted(id primitive.ObjectID) (proposals.Domain, error) {
+	return pr.findOne(func(proposal proposals.Domain) bool {
+		return proposal.ID == id
+	})
+}
+
+func (pr *ProposalRepository) GetByCommodityID(commodityID primitive.ObjectID) ([]proposals.Domain, error) {
+	return pr.find(func(proposal proposals.Domain) bool {
+		return proposal.CommodityID == commodityID && !isDeleted(proposal)
+	}), nil
+}
+
+func (pr *ProposalRepository) GetByCommodityIDAndAvailability(commodityID primitive.ObjectID, status string) ([]proposals.Domain, error) {
+	return pr.find(func(proposal proposals.Domain) bool {
+		return proposal.CommodityID == commodityID && proposal.Status == status && !isDeleted(proposal)
+	}), nil
+}
+
+func (pr *ProposalRepository) GetByCommodityIDAndName(commodityID primitive.ObjectID, name string) (proposals.Domain, error) {
+	return pr.findOne(func(proposal proposals.Domain) bool {
+		return proposal.CommodityID == commodityID && proposal.Name == name && !isDeleted(proposal)
+	})
+}
+
+func (pr *ProposalRepository) GetByIDAccepted(id primitive.ObjectID) (proposals.Domain, error) {
+	return pr.findOne(func(proposal proposals.Domain) bool {
+		return proposal.ID == id && proposal.Status == constant.ProposalStatusApproved && !isDeleted(proposal)
+	})
+}
+
+func (pr *ProposalRepository) StatisticByYear(year int) ([]dto.StatisticByYear, error) {
+	totalByMonth := map[int]int{}
+	for _, proposal := range pr.find(func(proposal proposals.Domain) bool {
+		return proposal.Status == constant.ProposalStatusApproved && memoryDriver.IsInYear(proposal.CreatedAt, year) && !isDeleted(proposal)
+	}) {
+		totalByMonth[int(proposal.CreatedAt.Time().UTC().Month())]++
+	}
+
+	return memoryDriver.ToStatisticByYear(totalByMonth), nil
+}
+
+func (pr *ProposalRepository) CountTotalProposalByFarmer(farmerID primitive.ObjectID) (int, error) {
+	return len(pr.find(func(proposal proposals.Domain) bool {
+		return !isDeleted(proposal) && pr.farmerID(proposal) == farmerID
+	})), nil
+}
+
+func (pr *ProposalRepository) CountPendingByValidatorID(validatorID primitive.ObjectID) (int, error) {
+	return len(pr.find(func(proposal proposals.Domain) bool {
+		return !isDeleted(proposal) && proposal.Status == constant.ProposalStatusPending && proposal.ValidatorID == validatorID
+	})), nil
+}
+
+func (pr *ProposalRepository) GetByQuery(query proposals.Query) ([]proposals.Domain, int, error) {
+	pr.db.RLock()
+	defer pr.db.RUnlock()
+
+	result := []proposals.Domain{}
+	for _, proposal := range pr.db.Proposals {
+		if isDeleted(proposal) {
+			continue
+		}
+
+		commodity, _ := pr.db.FindCommodity(proposal.CommodityID)
+		if query.CommodityID != primitive.NilObjectID {
+			if proposal.CommodityID != query.CommodityID {
+				continue
+			}
+		} else if query.Commodity != "" && !memoryDriver.Regex(commodity.Name, query.Commodity, true) {
+			continue
+		}
+
+		if query.FarmerID != primitive.NilObjectID && commodity.FarmerID != query.FarmerID {
+			continue
+		}
+
+		if query.ValidatorID != primitive.NilObjectID && proposal.ValidatorID != query.ValidatorID {
+			continue
+		}
+
+		if query.Name != "" && !memoryDriver.Regex(proposal.Name, query.Name, true) {
+			continue
+		}
+
+		if query.Status != "" && proposal.Status != query.Status {
+			continue
+		}
+
+		result = append(result, proposal)
+	}
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (pr *ProposalRepository) GetForPerennials(commodityID primitive.ObjectID, farmerID primitive.ObjectID) ([]proposals.Domain, error) {
+	return pr.find(func(proposal proposals.Domain) bool {
+		return proposal.CommodityID == commodityID &&
+			proposal.Status == constant.ProposalStatusApproved &&
+			proposal.IsAvailable &&
+			!isDeleted(proposal) &&
+			pr.farmerID(proposal) == farmerID
+	}), nil
+}
+
+/*
+Update
+*/
+
+func (pr *ProposalRepository) Update(ctx context.Context, domain *proposals.Domain) (proposals.Domain, error) {
+	defer pr.db.LockWrite(ctx)()
+
+	for i, proposal := range pr.db.Proposals {
+		if proposal.ID == domain.ID && !isDeleted(proposal) {
+			if proposal.Version != domain.Version {
+				break
+			}
+
+			domain.Version++
+			pr.db.Proposals[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return proposals.Domain{}, helper.NewConflictError("proposal", domain.ID)
+}
+
+func (pr *ProposalRepository) UnsetRejectReason(ctx context.Context, domain *proposals.Domain) (proposals.Domain, error) {
+	defer pr.db.LockWrite(ctx)()
+
+	for i, proposal := range pr.db.Proposals {
+		if proposal.ID == domain.ID && !isDeleted(proposal) {
+			if proposal.Version != domain.Version {
+				break
+			}
+
+			pr.db.Proposals[i].RejectReason = ""
+			pr.db.Proposals[i].Version++
+
+			domain.RejectReason = ""
+			domain.Version++
+			return *domain, nil
+		}
+	}
+
+	return proposals.Domain{}, helper.NewConflictError("proposal", domain.ID)
+}
+
+/*
+Delete
+*/
+
+func (pr *ProposalRepository) Delete(id primitive.ObjectID) error {
+	defer pr.db.LockWrite(context.Background())()
+
+	for i, proposal := range pr.db.Proposals {
+		if proposal.ID == id && !isDeleted(proposal) {
+			pr.db.Proposals[i].DeletedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+	}
+
+	return nil
+}
+
+func (pr *ProposalRepository) DeleteByCommodityID(commodityID primitive.ObjectID) error {
+	defer pr.db.LockWrite(context.Background())()
+
+	for i, proposal := range pr.db.Proposals {
+		if proposal.CommodityID == commodityID && !isDeleted(proposal) {
+			pr.db.Proposals[i].DeletedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,95 @@
+package ratings
+
+import (
+	"context"
+	"crop_connect/business/ratings"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RatingRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) ratings.Repository {
+	return &RatingRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(ratings.Domain) interface{} {
+	switch sort {
+	case "farmerRating":
+		return func(domain ratings.Domain) interface{} { return domain.FarmerRating }
+	case "commodityRating":
+		return func(domain ratings.Domain) interface{} { return domain.CommodityRating }
+	default:
+		return func(domain ratings.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (rr *RatingRepository) find(filter func(ratings.Domain) bool) []ratings.Domain {
+	rr.db.RLock()
+	defer rr.db.RUnlock()
+
+	result := []ratings.Domain{}
+	for _, rating := range rr.db.Ratings {
+		if filter(rating) {
+			result = append(result, rating)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (rr *RatingRepository) Create(ctx context.Context, domain *ratings.Domain) (ratings.Domain, error) {
+	defer rr.db.LockWrite(ctx)()
+
+	rr.db.Ratings = append(rr.db.Ratings, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (rr *RatingRepository) GetByTransactionID(transactionID primitive.ObjectID) (ratings.Domain, error) {
+	result := rr.find(func(rating ratings.Domain) bool {
+		return rating.TransactionID == transactionID
+	})
+
+	if len(result) == 0 {
+		return ratings.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (rr *RatingRepository) GetByQuery(query ratings.Query) ([]ratings.Domain, int, error) {
+	result := rr.find(func(rating ratings.Domain) bool {
+		if query.FarmerID != primitive.NilObjectID && rating.FarmerID != query.FarmerID {
+			return false
+		}
+
+		return query.CommodityCode == primitive.NilObjectID || rating.CommodityCode == query.CommodityCode
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
@@ -0,0 +1,133 @@
+package regions
+
+import (
+	"context"
+	"crop_connect/business/regions"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RegionRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) regions.Repository {
+	return &RegionRepository{
+		db: db,
+	}
+}
+
+func distinct(values []string, value string) []string {
+	for _, v := range values {
+		if v == value {
+			return values
+		}
+	}
+
+	return append(values, value)
+}
+
+/*
+Create
+*/
+
+func (rr *RegionRepository) Create(domain *regions.Domain) (regions.Domain, error) {
+	defer rr.db.LockWrite(context.Background())()
+
+	rr.db.Regions = append(rr.db.Regions, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (rr *RegionRepository) GetByID(id primitive.ObjectID) (regions.Domain, error) {
+	rr.db.RLock()
+	defer rr.db.RUnlock()
+
+	region, ok := rr.db.FindRegion(id)
+	if !ok {
+		return regions.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return region, nil
+}
+
+func (rr *RegionRepository) GetByQuery(query regions.Query) ([]regions.Domain, error) {
+	rr.db.RLock()
+	defer rr.db.RUnlock()
+
+	result := []regions.Domain{}
+	for _, region := range rr.db.Regions {
+		if (query.Country != "" && region.Country != query.Country) ||
+			(query.Province != "" && region.Province != query.Province) ||
+			(query.Regency != "" && region.Regency != query.Regency) ||
+			(query.District != "" && region.District != query.District) ||
+			(query.Subdistrict != "" && region.Subdistrict != query.Subdistrict) {
+			continue
+		}
+
+		result = append(result, region)
+	}
+
+	return result, nil
+}
+
+func (rr *RegionRepository) GetProvince(country string) ([]string, error) {
+	rr.db.RLock()
+	defer rr.db.RUnlock()
+
+	result := []string{}
+	for _, region := range rr.db.Regions {
+		if region.Country == country {
+			result = distinct(result, region.Province)
+		}
+	}
+
+	return result, nil
+}
+
+func (rr *RegionRepository) GetRegency(country string, province string) ([]string, error) {
+	rr.db.RLock()
+	defer rr.db.RUnlock()
+
+	result := []string{}
+	for _, region := range rr.db.Regions {
+		if region.Country == country && region.Province == province {
+			result = distinct(result, region.Regency)
+		}
+	}
+
+	return result, nil
+}
+
+func (rr *RegionRepository) GetDistrict(country string, province string, regency string) ([]string, error) {
+	rr.db.RLock()
+	defer rr.db.RUnlock()
+
+	result := []string{}
+	for _, region := range rr.db.Regions {
+		if region.Country == country && region.Province == province && region.Regency == regency {
+			result = distinct(result, region.District)
+		}
+	}
+
+	return result, nil
+}
+
+func (rr *RegionRepository) GetSubdistrict(country string, province string, regency string, district string) ([]regions.Domain, error) {
+	rr.db.RLock()
+	defer rr.db.RUnlock()
+
+	result := []regions.Domain{}
+	for _, region := range rr.db.Regions {
+		if region.Country == country && region.Province == province && region.Regency == regency && region.District == district {
+			result = append(result, region)
+		}
+	}
+
+	return result, nil
+}
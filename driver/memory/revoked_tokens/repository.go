@@ -0,0 +1,99 @@
+package revoked_tokens
+
+import (
+	"context"
+	revokedTokens "crop_connect/business/revoked_tokens"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RevokedTokenRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) revokedTokens.Repository {
+	return &RevokedTokenRepository{
+		db: db,
+	}
+}
+
+/*
+Create
+*/
+
+func (rtr *RevokedTokenRepository) Create(ctx context.Context, domain *revokedTokens.Domain) error {
+	defer rtr.db.LockWrite(ctx)()
+
+	for _, revokedToken := range rtr.db.RevokedTokens {
+		if revokedToken.ID == domain.ID {
+			return mongo.WriteException{WriteErrors: mongo.WriteErrors{{Code: 11000, Message: "duplicate key"}}}
+		}
+	}
+
+	rtr.db.RevokedTokens = append(rtr.db.RevokedTokens, *domain)
+	return nil
+}
+
+func (rtr *RevokedTokenRepository) CreateMany(ctx context.Context, domains []revokedTokens.Domain) error {
+	defer rtr.db.LockWrite(ctx)()
+
+	for _, domain := range domains {
+		isExist := false
+		for i, revokedToken := range rtr.db.RevokedTokens {
+			if revokedToken.ID == domain.ID {
+				rtr.db.RevokedTokens[i] = domain
+				isExist = true
+				break
+			}
+		}
+
+		if !isExist {
+			rtr.db.RevokedTokens = append(rtr.db.RevokedTokens, domain)
+		}
+	}
+
+	return nil
+}
+
+/*
+Read
+*/
+
+func (rtr *RevokedTokenRepository) IsRevoked(id string) (bool, error) {
+	rtr.db.RLock()
+	defer rtr.db.RUnlock()
+
+	for _, revokedToken := range rtr.db.RevokedTokens {
+		if revokedToken.ID == id {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
+
+func (rtr *RevokedTokenRepository) DeleteExpired(now primitive.DateTime) (int, error) {
+	defer rtr.db.LockWrite(context.Background())()
+
+	remaining := []revokedTokens.Domain{}
+	for _, revokedToken := range rtr.db.RevokedTokens {
+		if revokedToken.ExpiredAt >= now {
+			remaining = append(remaining, revokedToken)
+		}
+	}
+
+	totalDeleted := len(rtr.db.RevokedTokens) - len(remaining)
+	rtr.db.RevokedTokens = remaining
+
+	return totalDeleted, nil
+}
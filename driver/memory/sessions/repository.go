@@ -0,0 +1,114 @@
+package sessions
+
+import (
+	"context"
+	"crop_connect/business/sessions"
+	memoryDriver "crop_connect/driver/memory"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SessionRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) sessions.Repository {
+	return &SessionRepository{
+		db: db,
+	}
+}
+
+/*
+Create
+*/
+
+func (sr *SessionRepository) Create(ctx context.Context, domain *sessions.Domain) (sessions.Domain, error) {
+	defer sr.db.LockWrite(ctx)()
+
+	sr.db.Sessions = append(sr.db.Sessions, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (sr *SessionRepository) GetByID(id primitive.ObjectID) (sessions.Domain, error) {
+	sr.db.RLock()
+	defer sr.db.RUnlock()
+
+	for _, session := range sr.db.Sessions {
+		if session.ID == id {
+			return session, nil
+		}
+	}
+
+	return sessions.Domain{}, mongo.ErrNoDocuments
+}
+
+func (sr *SessionRepository) GetActiveByUserID(userID primitive.ObjectID) ([]sessions.Domain, error) {
+	sr.db.RLock()
+	defer sr.db.RUnlock()
+
+	now := primitive.NewDateTimeFromTime(time.Now())
+	result := []sessions.Domain{}
+	for _, session := range sr.db.Sessions {
+		if session.UserID == userID && session.RevokedAt == 0 && session.ExpiredAt > now {
+			result = append(result, session)
+		}
+	}
+
+	return result, nil
+}
+
+/*
+Update
+*/
+
+func (sr *SessionRepository) Update(ctx context.Context, domain *sessions.Domain) (sessions.Domain, error) {
+	defer sr.db.LockWrite(ctx)()
+
+	for i, session := range sr.db.Sessions {
+		if session.ID == domain.ID {
+			sr.db.Sessions[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return sessions.Domain{}, mongo.ErrNoDocuments
+}
+
+func (sr *SessionRepository) UpdateByRefreshTokenID(ctx context.Context, domain *sessions.Domain, refreshTokenID string) (sessions.Domain, error) {
+	defer sr.db.LockWrite(ctx)()
+
+	for i, session := range sr.db.Sessions {
+		if session.ID == domain.ID && session.RefreshTokenID == refreshTokenID && session.RevokedAt == 0 {
+			sr.db.Sessions[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return sessions.Domain{}, mongo.ErrNoDocuments
+}
+
+/*
+Delete
+*/
+
+func (sr *SessionRepository) DeleteExpired(now primitive.DateTime) (int, error) {
+	defer sr.db.LockWrite(context.Background())()
+
+	remaining := []sessions.Domain{}
+	for _, session := range sr.db.Sessions {
+		if session.ExpiredAt >= now {
+			remaining = append(remaining, session)
+		}
+	}
+
+	totalDeleted := len(sr.db.Sessions) - len(remaining)
+	sr.db.Sessions = remaining
+
+	return totalDeleted, nil
+}
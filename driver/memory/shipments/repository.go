@@ -0,0 +1,134 @@
+package shipments
+
+import (
+	"context"
+	"crop_connect/business/shipments"
+	memoryDriver "crop_connect/driver/memory"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ShipmentRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) shipments.Repository {
+	return &ShipmentRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(shipments.Domain) interface{} {
+	switch sort {
+	case "status":
+		return func(domain shipments.Domain) interface{} { return domain.Status }
+	case "pickupDate":
+		return func(domain shipments.Domain) interface{} { return domain.PickupDate }
+	default:
+		return func(domain shipments.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (sr *ShipmentRepository) find(filter func(shipments.Domain) bool) []shipments.Domain {
+	sr.db.RLock()
+	defer sr.db.RUnlock()
+
+	result := []shipments.Domain{}
+	for _, shipment := range sr.db.Shipments {
+		if filter(shipment) {
+			result = append(result, shipment)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (sr *ShipmentRepository) Create(ctx context.Context, domain *shipments.Domain) (shipments.Domain, error) {
+	defer sr.db.LockWrite(ctx)()
+
+	sr.db.Shipments = append(sr.db.Shipments, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (sr *ShipmentRepository) GetByID(id primitive.ObjectID) (shipments.Domain, error) {
+	result := sr.find(func(shipment shipments.Domain) bool {
+		return shipment.ID == id
+	})
+	if len(result) == 0 {
+		return shipments.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (sr *ShipmentRepository) GetByTransactionID(transactionID primitive.ObjectID) (shipments.Domain, error) {
+	result := sr.find(func(shipment shipments.Domain) bool {
+		return shipment.TransactionID == transactionID
+	})
+	if len(result) == 0 {
+		return shipments.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (sr *ShipmentRepository) GetByQuery(query shipments.Query) ([]shipments.Domain, int, error) {
+	result := sr.find(func(shipment shipments.Domain) bool {
+		if query.FarmerID != primitive.NilObjectID && shipment.FarmerID != query.FarmerID {
+			return false
+		}
+
+		if query.BuyerID != primitive.NilObjectID && shipment.BuyerID != query.BuyerID {
+			return false
+		}
+
+		return query.Status == "" || shipment.Status == query.Status
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+/*
+Update
+*/
+
+func (sr *ShipmentRepository) Update(domain *shipments.Domain) (shipments.Domain, error) {
+	defer sr.db.LockWrite(context.Background())()
+
+	for i, shipment := range sr.db.Shipments {
+		if shipment.ID == domain.ID {
+			sr.db.Shipments[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (sr *ShipmentRepository) UpdateByStatus(domain *shipments.Domain, status string) (shipments.Domain, error) {
+	defer sr.db.LockWrite(context.Background())()
+
+	for i, shipment := range sr.db.Shipments {
+		if shipment.ID == domain.ID && shipment.Status == status {
+			sr.db.Shipments[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return shipments.Domain{}, mongo.ErrNoDocuments
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,384 @@
+package transactions
+
+import (
+	"context"
+	"crop_connect/business/transactions"
+	"crop_connect/constant"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/helper"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TransactionRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) transactions.Repository {
+	return &TransactionRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(transactions.Domain) interface{} {
+	switch sort {
+	case "status":
+		return func(domain transactions.Domain) interface{} { return domain.Status }
+	case "totalPrice":
+		return func(domain transactions.Domain) interface{} { return domain.TotalPrice }
+	default:
+		return func(domain transactions.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+// isAccepted counts a paid or partially refunded transaction as an accepted deal in the statistics.
+func isAccepted(transaction transactions.Domain) bool {
+	return transaction.Status == constant.TransactionStatusAccepted || transaction.Status == constant.TransactionStatusPaid || transaction.Status == constant.TransactionStatusPartiallyRefunded
+}
+
+// exceedQuantity reports whether the transaction asks for more than the remaining quantity, transactions made before quantities were tracked always do.
+func exceedQuantity(transaction transactions.Domain, remainingQuantity float64) bool {
+	return transaction.Quantity == 0 || transaction.Quantity > remainingQuantity
+}
+
+func (tr *TransactionRepository) findOne(filter func(transactions.Domain) bool) (transactions.Domain, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	for _, transaction := range tr.db.Transactions {
+		if filter(transaction) {
+			return transaction, nil
+		}
+	}
+
+	return transactions.Domain{}, mongo.ErrNoDocuments
+}
+
+/*
+Create
+*/
+
+func (tr *TransactionRepository) Create(ctx context.Context, domain *transactions.Domain) (transactions.Domain, error) {
+	defer tr.db.LockWrite(ctx)()
+
+	tr.db.Transactions = append(tr.db.Transactions, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (tr *TransactionRepository) GetByID(id primitive.ObjectID) (transactions.Domain, error) {
+	return tr.findOne(func(transaction transactions.Domain) bool {
+		return transaction.ID == id
+	})
+}
+
+func (tr *TransactionRepository) GetByBuyerIDProposalIDAndStatus(buyerID primitive.ObjectID, proposalID primitive.ObjectID, status string) (transactions.Domain, error) {
+	return tr.findOne(func(transaction transactions.Domain) bool {
+		return transaction.BuyerID == buyerID && transaction.ProposalID == proposalID && transaction.Status == status
+	})
+}
+
+func (tr *TransactionRepository) GetByQuery(query transactions.Query) ([]transactions.Domain, int, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	result := []transactions.Domain{}
+	for _, transaction := range tr.db.Transactions {
+		if query.BuyerID != primitive.NilObjectID && transaction.BuyerID != query.BuyerID {
+			continue
+		}
+
+		if query.Status != "" && transaction.Status != query.Status {
+			continue
+		}
+
+		if query.StartDate != 0 && transaction.CreatedAt < query.StartDate {
+			continue
+		}
+
+		if query.EndDate != 0 && transaction.CreatedAt > query.EndDate {
+			continue
+		}
+
+		proposal, _ := tr.db.FindProposal(transaction.ProposalID)
+		commodity, _ := tr.db.FindCommodity(proposal.CommodityID)
+		if query.Commodity != "" && !memoryDriver.Regex(commodity.Name, query.Commodity, true) {
+			continue
+		}
+
+		if query.FarmerID != primitive.NilObjectID && commodity.FarmerID != query.FarmerID {
+			continue
+		}
+
+		if query.Proposal != "" && !memoryDriver.Regex(proposal.Name, query.Proposal, true) {
+			continue
+		}
+
+		if query.Batch != "" {
+			batch, ok := tr.db.FindBatch(transaction.BatchID)
+			if !ok || !memoryDriver.Regex(batch.Name, query.Batch, true) {
+				continue
+			}
+		}
+
+		result = append(result, transaction)
+	}
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (tr *TransactionRepository) GetByIDAndBuyerID(id primitive.ObjectID, buyerID primitive.ObjectID) (transactions.Domain, error) {
+	return tr.findOne(func(transaction transactions.Domain) bool {
+		return transaction.ID == id && (buyerID == primitive.NilObjectID || transaction.BuyerID == buyerID)
+	})
+}
+
+func (tr *TransactionRepository) StatisticByYear(farmerID primitive.ObjectID, year int) ([]transactions.Statistic, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	results := []transactions.Statistic{}
+	for month := 1; month <= 12; month++ {
+		start := time.Date(year, time.Month(month), 1, 0, 0, 0, 0, time.UTC)
+		statistic := transactions.Statistic{
+			Month: month,
+		}
+
+		if start.Unix() > time.Now().Unix() {
+			results = append(results, statistic)
+			continue
+		}
+
+		uniqueBuyer := map[primitive.ObjectID]bool{}
+		for _, transaction := range tr.db.Transactions {
+			if !memoryDriver.IsBetween(transaction.CreatedAt, start, start.AddDate(0, 1, 0)) {
+				continue
+			}
+
+			if farmerID != primitive.NilObjectID {
+				commodity, ok := tr.db.FindCommodityByProposalID(transaction.ProposalID)
+				if !ok || commodity.FarmerID != farmerID {
+					continue
+				}
+			}
+
+			statistic.TotalTransaction++
+			uniqueBuyer[transaction.BuyerID] = true
+
+			if isAccepted(transaction) {
+				statistic.TotalAccepted++
+				statistic.TotalIncome += transaction.TotalPrice
+			}
+
+			if harvest, ok := tr.db.FindHarvestByBatchID(transaction.BatchID); ok && transaction.BatchID != primitive.NilObjectID && harvest.Status == constant.HarvestStatusApproved {
+				statistic.TotalWeight += harvest.TotalHarvest
+			}
+		}
+
+		statistic.TotalUniqueBuyer = len(uniqueBuyer)
+		results = append(results, statistic)
+	}
+
+	return results, nil
+}
+
+func (tr *TransactionRepository) StatisticTopProvince(year int, limit int) ([]transactions.TotalTransactionByProvince, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	results := []transactions.TotalTransactionByProvince{}
+	indexByProvince := map[string]int{}
+	for _, transaction := range tr.db.Transactions {
+		if !memoryDriver.IsInYear(transaction.CreatedAt, year) {
+			continue
+		}
+
+		region, _ := tr.db.FindRegion(transaction.RegionID)
+		index, ok := indexByProvince[region.Province]
+		if !ok {
+			index = len(results)
+			indexByProvince[region.Province] = index
+			results = append(results, transactions.TotalTransactionByProvince{
+				Province: region.Province,
+			})
+		}
+
+		results[index].TotalTransaction++
+		if isAccepted(transaction) {
+			results[index].TotalAccepted++
+		}
+	}
+
+	memoryDriver.Sort(results, -1, func(statistic transactions.TotalTransactionByProvince) interface{} {
+		return statistic.TotalTransaction
+	})
+
+	return memoryDriver.Paginate(results, 0, int64(limit)), nil
+}
+
+func (tr *TransactionRepository) StatisticTopCommodity(farmerID primitive.ObjectID, year int, limit int) ([]transactions.ModelStatisticTopCommodity, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	results := []transactions.ModelStatisticTopCommodity{}
+	indexByCode := map[primitive.ObjectID]int{}
+	for _, transaction := range tr.db.Transactions {
+		if !memoryDriver.IsInYear(transaction.CreatedAt, year) {
+			continue
+		}
+
+		commodity, ok := tr.db.FindCommodityByProposalID(transaction.ProposalID)
+		if !ok || commodity.DeletedAt != 0 {
+			continue
+		}
+
+		if farmerID != primitive.NilObjectID && commodity.FarmerID != farmerID {
+			continue
+		}
+
+		index, ok := indexByCode[commodity.Code]
+		if !ok {
+			index = len(results)
+			indexByCode[commodity.Code] = index
+			results = append(results, transactions.ModelStatisticTopCommodity{
+				CommodityCode: commodity.Code,
+			})
+		}
+
+		results[index].Total++
+	}
+
+	memoryDriver.Sort(results, -1, func(statistic transactions.ModelStatisticTopCommodity) interface{} {
+		return statistic.Total
+	})
+
+	return memoryDriver.Paginate(results, 0, int64(limit)), nil
+}
+
+func (tr *TransactionRepository) CountByCommodityCode(Code primitive.ObjectID) (int, float64, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	totalTransaction := 0
+	totalWeight := 0.0
+	for _, transaction := range tr.db.Transactions {
+		if !isAccepted(transaction) {
+			continue
+		}
+
+		commodity, ok := tr.db.FindCommodityByProposalID(transaction.ProposalID)
+		if !ok || commodity.Code != Code {
+			continue
+		}
+
+		harvest, ok := tr.db.FindHarvestByBatchID(transaction.BatchID)
+		if !ok || transaction.BatchID == primitive.NilObjectID || harvest.Status != constant.HarvestStatusApproved {
+			continue
+		}
+
+		totalTransaction++
+		totalWeight += harvest.TotalHarvest
+	}
+
+	return totalTransaction, totalWeight, nil
+}
+
+func (tr *TransactionRepository) GetByBuyerIDBatchIDAndStatus(buyerID primitive.ObjectID, batchID primitive.ObjectID, status string) (transactions.Domain, error) {
+	return tr.findOne(func(transaction transactions.Domain) bool {
+		return transaction.BuyerID == buyerID && transaction.BatchID == batchID && transaction.Status == status
+	})
+}
+
+func (tr *TransactionRepository) GetAcceptedByBatchID(batchID primitive.ObjectID) ([]transactions.Domain, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	result := []transactions.Domain{}
+	for _, transaction := range tr.db.Transactions {
+		if transaction.BatchID == batchID && isAccepted(transaction) {
+			result = append(result, transaction)
+		}
+	}
+
+	return result, nil
+}
+
+func (tr *TransactionRepository) GetPendingCreatedBefore(date primitive.DateTime) ([]transactions.Domain, error) {
+	tr.db.RLock()
+	defer tr.db.RUnlock()
+
+	result := []transactions.Domain{}
+	for _, transaction := range tr.db.Transactions {
+		if transaction.Status == constant.TransactionStatusPending && transaction.CreatedAt < date {
+			result = append(result, transaction)
+		}
+	}
+
+	return result, nil
+}
+
+/*
+Update
+*/
+
+func (tr *TransactionRepository) Update(ctx context.Context, domain *transactions.Domain) (transactions.Domain, error) {
+	defer tr.db.LockWrite(ctx)()
+
+	for i, transaction := range tr.db.Transactions {
+		if transaction.ID == domain.ID {
+			if transaction.Version != domain.Version {
+				break
+			}
+
+			domain.Version++
+			tr.db.Transactions[i] = *domain
+			return *domain, nil
+		}
+	}
+
+	return transactions.Domain{}, helper.NewConflictError("transaksi", domain.ID)
+}
+
+func (tr *TransactionRepository) RejectPendingByProposalID(ctx context.Context, proposalID primitive.ObjectID, remainingQuantity float64) ([]transactions.Domain, error) {
+	defer tr.db.LockWrite(ctx)()
+
+	var rejected []transactions.Domain
+	for i, transaction := range tr.db.Transactions {
+		if transaction.ProposalID == proposalID && transaction.Status == constant.TransactionStatusPending && exceedQuantity(transaction, remainingQuantity) {
+			tr.db.Transactions[i].Status = constant.TransactionStatusRejected
+			tr.db.Transactions[i].UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+			tr.db.Transactions[i].Version++
+			rejected = append(rejected, tr.db.Transactions[i])
+		}
+	}
+
+	return rejected, nil
+}
+
+func (tr *TransactionRepository) RejectPendingByBatchID(ctx context.Context, batchID primitive.ObjectID, remainingQuantity float64) ([]transactions.Domain, error) {
+	defer tr.db.LockWrite(ctx)()
+
+	var rejected []transactions.Domain
+	for i, transaction := range tr.db.Transactions {
+		if transaction.BatchID == batchID && transaction.Status == constant.TransactionStatusPending && exceedQuantity(transaction, remainingQuantity) {
+			tr.db.Transactions[i].Status = constant.TransactionStatusRejected
+			tr.db.Transactions[i].UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+			tr.db.Transactions[i].Version++
+			rejected = append(rejected, tr.db.Transactions[i])
+		}
+	}
+
+	return rejected, nil
+}
+
+/*
+Delete
+*/
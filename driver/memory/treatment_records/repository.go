@@ -0,0 +1,201 @@
+package treatment_records
+
+import (
+	"context"
+	treatmentRecord "crop_connect/business/treatment_records"
+	"crop_connect/constant"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/dto"
+	"crop_connect/util"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type TreatmentRecordRepository struct {
+	db *memoryDriver.Database
+}
+
+var openStatuses = []string{constant.TreatmentRecordStatusWaitingResponse, constant.TreatmentRecordStatusPending, constant.TreatmentRecordStatusRevision}
+
+func NewRepository(db *memoryDriver.Database) treatmentRecord.Repository {
+	return &TreatmentRecordRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(treatmentRecord.Domain) interface{} {
+	switch sort {
+	case "number":
+		return func(domain treatmentRecord.Domain) interface{} { return domain.Number }
+	case "date":
+		return func(domain treatmentRecord.Domain) interface{} { return domain.Date }
+	case "status":
+		return func(domain treatmentRecord.Domain) interface{} { return domain.Status }
+	default:
+		return func(domain treatmentRecord.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+func (trr *TreatmentRecordRepository) find(filter func(treatmentRecord.Domain) bool) []treatmentRecord.Domain {
+	trr.db.RLock()
+	defer trr.db.RUnlock()
+
+	result := []treatmentRecord.Domain{}
+	for _, treatmentRecord := range trr.db.TreatmentRecords {
+		if filter(treatmentRecord) {
+			result = append(result, treatmentRecord)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (trr *TreatmentRecordRepository) Create(domain *treatmentRecord.Domain) (treatmentRecord.Domain, error) {
+	defer trr.db.LockWrite(context.Background())()
+
+	trr.db.TreatmentRecords = append(trr.db.TreatmentRecords, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (trr *TreatmentRecordRepository) GetNewestByBatchIDAndStatus(batchID primitive.ObjectID, status string) (treatmentRecord.Domain, error) {
+	result := trr.find(func(domain treatmentRecord.Domain) bool {
+		return domain.BatchID == batchID && (status == "" || domain.Status == status)
+	})
+	if len(result) == 0 {
+		return treatmentRecord.Domain{}, mongo.ErrNoDocuments
+	}
+
+	memoryDriver.Sort(result, -1, sortKey("createdAt"))
+	return result[0], nil
+}
+
+func (trr *TreatmentRecordRepository) CountByBatchID(batchID primitive.ObjectID) (int, error) {
+	return len(trr.find(func(domain treatmentRecord.Domain) bool {
+		return domain.BatchID == batchID
+	})), nil
+}
+
+func (trr *TreatmentRecordRepository) GetByID(id primitive.ObjectID) (treatmentRecord.Domain, error) {
+	result := trr.find(func(domain treatmentRecord.Domain) bool {
+		return domain.ID == id
+	})
+	if len(result) == 0 {
+		return treatmentRecord.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (trr *TreatmentRecordRepository) GetByBatchID(batchID primitive.ObjectID) ([]treatmentRecord.Domain, error) {
+	return trr.find(func(domain treatmentRecord.Domain) bool {
+		return domain.BatchID == batchID
+	}), nil
+}
+
+func (trr *TreatmentRecordRepository) GetByQuery(query treatmentRecord.Query) ([]treatmentRecord.Domain, int, error) {
+	result := trr.find(func(domain treatmentRecord.Domain) bool {
+		if query.Status != "" && domain.Status != query.Status {
+			return false
+		}
+
+		if query.Number != 0 && domain.Number != query.Number {
+			return false
+		}
+
+		if query.BatchID != primitive.NilObjectID {
+			if domain.BatchID != query.BatchID {
+				return false
+			}
+		} else if query.Batch != "" {
+			batch, ok := trr.db.FindBatch(domain.BatchID)
+			if !ok || !memoryDriver.Regex(batch.Name, query.Batch, true) {
+				return false
+			}
+		}
+
+		commodity, _ := trr.db.FindCommodityByBatchID(domain.BatchID)
+		if query.Commodity != "" && !memoryDriver.Regex(commodity.Name, query.Commodity, true) {
+			return false
+		}
+
+		if query.FarmerID != primitive.NilObjectID {
+			if commodity.FarmerID != query.FarmerID {
+				return false
+			}
+		} else if query.Farmer != "" {
+			farmer, ok := trr.db.FindUser(commodity.FarmerID)
+			if !ok || !memoryDriver.Regex(farmer.Name, query.Farmer, true) {
+				return false
+			}
+		}
+
+		return true
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (trr *TreatmentRecordRepository) CountByYear(year int) (int, error) {
+	return len(trr.find(func(domain treatmentRecord.Domain) bool {
+		return memoryDriver.IsInYear(domain.CreatedAt, year)
+	})), nil
+}
+
+func (trr *TreatmentRecordRepository) StatisticByYear(year int) ([]dto.StatisticByYear, error) {
+	totalByMonth := map[int]int{}
+	for _, domain := range trr.find(func(domain treatmentRecord.Domain) bool {
+		return memoryDriver.IsInYear(domain.CreatedAt, year)
+	}) {
+		totalByMonth[int(domain.CreatedAt.Time().UTC().Month())]++
+	}
+
+	return memoryDriver.ToStatisticByYear(totalByMonth), nil
+}
+
+/*
+Update
+*/
+
+func (trr *TreatmentRecordRepository) Update(domain *treatmentRecord.Domain) (treatmentRecord.Domain, error) {
+	defer trr.db.LockWrite(context.Background())()
+
+	for i, treatmentRecord := range trr.db.TreatmentRecords {
+		if treatmentRecord.ID == domain.ID {
+			trr.db.TreatmentRecords[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (trr *TreatmentRecordRepository) CloseOpenByBatchID(ctx context.Context, batchID primitive.ObjectID) ([]treatmentRecord.Domain, error) {
+	defer trr.db.LockWrite(ctx)()
+
+	var closed []treatmentRecord.Domain
+	for i, treatmentRecord := range trr.db.TreatmentRecords {
+		if treatmentRecord.BatchID == batchID && util.CheckStringOnArray(openStatuses, treatmentRecord.Status) {
+			closed = append(closed, treatmentRecord)
+			trr.db.TreatmentRecords[i].Status = constant.TreatmentRecordStatusClosed
+			trr.db.TreatmentRecords[i].UpdatedAt = primitive.NewDateTimeFromTime(time.Now())
+		}
+	}
+
+	return closed, nil
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,32 @@
+package unit_of_work
+
+import (
+	"context"
+	unitOfWork "crop_connect/business/unit_of_work"
+	memoryDriver "crop_connect/driver/memory"
+)
+
+type UnitOfWork struct {
+	db *memoryDriver.Database
+}
+
+func NewUnitOfWork(db *memoryDriver.Database) unitOfWork.UnitOfWork {
+	return &UnitOfWork{
+		db: db,
+	}
+}
+
+// Execute runs one unit of work at a time and restores the snapshot taken before fn when it fails.
+// Writes outside the unit wait for it in LockWrite, so the snapshot only rolls back what fn wrote.
+func (uow *UnitOfWork) Execute(fn func(ctx context.Context) error) error {
+	uow.db.UnitOfWork.Lock()
+	defer uow.db.UnitOfWork.Unlock()
+
+	snapshot := uow.db.Snapshot()
+	if err := fn(memoryDriver.WithUnitOfWork(context.Background())); err != nil {
+		uow.db.Restore(snapshot)
+		return err
+	}
+
+	return nil
+}
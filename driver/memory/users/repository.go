@@ -0,0 +1,278 @@
+package users
+
+import (
+	"context"
+	"crop_connect/business/users"
+	"crop_connect/constant"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/dto"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type UserRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) users.Repository {
+	return &UserRepository{
+		db: db,
+	}
+}
+
+func sortKey(sort string) func(users.Domain) interface{} {
+	switch sort {
+	case "name":
+		return func(domain users.Domain) interface{} { return domain.Name }
+	case "email":
+		return func(domain users.Domain) interface{} { return domain.Email }
+	case "role":
+		return func(domain users.Domain) interface{} { return domain.Role }
+	default:
+		return func(domain users.Domain) interface{} { return domain.CreatedAt }
+	}
+}
+
+/*
+Create
+*/
+
+func (ur *UserRepository) Create(domain *users.Domain) (users.Domain, error) {
+	defer ur.db.LockWrite(context.Background())()
+
+	ur.db.Users = append(ur.db.Users, *domain)
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (ur *UserRepository) GetByID(id primitive.ObjectID) (users.Domain, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	user, ok := ur.db.FindUser(id)
+	if !ok {
+		return users.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return user, nil
+}
+
+func (ur *UserRepository) GetByEmail(email string) (users.Domain, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	for _, user := range ur.db.Users {
+		if user.Email == email {
+			return user, nil
+		}
+	}
+
+	return users.Domain{}, mongo.ErrNoDocuments
+}
+
+func (ur *UserRepository) GetByNameAndRole(name string, role string) ([]users.Domain, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	result := []users.Domain{}
+	for _, user := range ur.db.Users {
+		if user.Role == role && memoryDriver.Regex(user.Name, name, false) {
+			result = append(result, user)
+		}
+	}
+
+	return result, nil
+}
+
+func (ur *UserRepository) GetByQuery(query users.Query) ([]users.Domain, int, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	result := []users.Domain{}
+	for _, user := range ur.db.Users {
+		if query.Name != "" && !memoryDriver.Regex(user.Name, query.Name, true) {
+			continue
+		}
+
+		if query.Email != "" && !memoryDriver.Regex(user.Email, query.Email, true) {
+			continue
+		}
+
+		if query.PhoneNumber != "" && !memoryDriver.Regex(user.PhoneNumber, query.PhoneNumber, false) {
+			continue
+		}
+
+		if query.Role != "" && user.Role != query.Role {
+			continue
+		}
+
+		if query.RegionID != primitive.NilObjectID {
+			if user.RegionID != query.RegionID {
+				continue
+			}
+		} else if query.Province != "" || query.Regency != "" || query.District != "" {
+			region, ok := ur.db.FindRegion(user.RegionID)
+			if !ok ||
+				(query.Province != "" && region.Province != query.Province) ||
+				(query.Regency != "" && region.Regency != query.Regency) ||
+				(query.District != "" && region.District != query.District) {
+				continue
+			}
+		}
+
+		result = append(result, user)
+	}
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, sortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (ur *UserRepository) GetFarmerByID(id primitive.ObjectID) (users.Domain, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	user, ok := ur.db.FindUser(id)
+	if !ok || user.Role != "farmer" {
+		return users.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return user, nil
+}
+
+func (ur *UserRepository) GetActiveValidatorsByArea(province string, regency string) ([]users.Domain, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	result := []users.Domain{}
+	for _, user := range ur.db.Users {
+		if user.Role != constant.RoleValidator || user.Status != constant.UserStatusActive {
+			continue
+		}
+
+		for _, area := range user.Areas {
+			if area.Province == province && (area.Regency == "" || area.Regency == regency) {
+				result = append(result, user)
+				break
+			}
+		}
+	}
+
+	memoryDriver.Sort(result, 1, sortKey("createdAt"))
+
+	return result, nil
+}
+
+func (ur *UserRepository) StatisticNewUserByYear(year int) ([]dto.StatisticByYear, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	totalByMonth := map[int]int{}
+	for _, user := range ur.db.Users {
+		if memoryDriver.IsInYear(user.CreatedAt, year) {
+			totalByMonth[int(user.CreatedAt.Time().UTC().Month())]++
+		}
+	}
+
+	return memoryDriver.ToStatisticByYear(totalByMonth), nil
+}
+
+func (ur *UserRepository) CountTotalValidatorByYear(year int) (int, error) {
+	ur.db.RLock()
+	defer ur.db.RUnlock()
+
+	total := 0
+	for _, user := range ur.db.Users {
+		if user.Role == "validator" && memoryDriver.IsInYear(user.CreatedAt, year) {
+			total++
+		}
+	}
+
+	return total, nil
+}
+
+/*
+Update
+*/
+
+func (ur *UserRepository) Update(domain *users.Domain) (users.Domain, error) {
+	defer ur.db.LockWrite(context.Background())()
+
+	for i, user := range ur.db.Users {
+		if user.ID == domain.ID {
+			ur.db.Users[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (ur *UserRepository) UpdateTwoFactorStep(id primitive.ObjectID, step int64) error {
+	defer ur.db.LockWrite(context.Background())()
+
+	for i, user := range ur.db.Users {
+		if user.ID == id && user.TwoFactor.LastStep < step {
+			ur.db.Users[i].TwoFactor.LastStep = step
+			ur.db.Users[i].TwoFactor.Failures = 0
+			ur.db.Users[i].TwoFactor.LockedUntil = 0
+			return nil
+		}
+	}
+
+	return mongo.ErrNoDocuments
+}
+
+func (ur *UserRepository) RemoveRecoveryCode(id primitive.ObjectID, hash string) error {
+	defer ur.db.LockWrite(context.Background())()
+
+	for i, user := range ur.db.Users {
+		if user.ID != id {
+			continue
+		}
+
+		for j, recoveryCode := range user.TwoFactor.RecoveryCodes {
+			if recoveryCode == hash {
+				ur.db.Users[i].TwoFactor.RecoveryCodes = append(user.TwoFactor.RecoveryCodes[:j:j], user.TwoFactor.RecoveryCodes[j+1:]...)
+				ur.db.Users[i].TwoFactor.Failures = 0
+				ur.db.Users[i].TwoFactor.LockedUntil = 0
+				return nil
+			}
+		}
+	}
+
+	return mongo.ErrNoDocuments
+}
+
+func (ur *UserRepository) UpdateTwoFactorFailures(id primitive.ObjectID, failures int, lockedUntil primitive.DateTime) error {
+	defer ur.db.LockWrite(context.Background())()
+
+	for i, user := range ur.db.Users {
+		if user.ID == id {
+			ur.db.Users[i].TwoFactor.Failures = failures
+			ur.db.Users[i].TwoFactor.LockedUntil = lockedUntil
+		}
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) AddRating(ctx context.Context, id primitive.ObjectID, score int) error {
+	defer ur.db.LockWrite(ctx)()
+
+	for i, user := range ur.db.Users {
+		if user.ID == id {
+			ur.db.Users[i].RatingAverage, ur.db.Users[i].RatingCount = memoryDriver.AddRating(user.RatingAverage, user.RatingCount, score)
+		}
+	}
+
+	return nil
+}
+
+/*
+Delete
+*/
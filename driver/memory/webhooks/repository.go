@@ -0,0 +1,190 @@
+package webhooks
+
+import (
+	"context"
+	"crop_connect/business/webhooks"
+	memoryDriver "crop_connect/driver/memory"
+	"crop_connect/util"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WebhookRepository struct {
+	db *memoryDriver.Database
+}
+
+func NewRepository(db *memoryDriver.Database) webhooks.Repository {
+	return &WebhookRepository{
+		db: db,
+	}
+}
+
+func deliverySortKey(sort string) func(webhooks.Delivery) interface{} {
+	switch sort {
+	case "updatedAt":
+		return func(delivery webhooks.Delivery) interface{} { return delivery.UpdatedAt }
+	default:
+		return func(delivery webhooks.Delivery) interface{} { return delivery.CreatedAt }
+	}
+}
+
+func (wr *WebhookRepository) find(filter func(webhooks.Domain) bool) []webhooks.Domain {
+	wr.db.RLock()
+	defer wr.db.RUnlock()
+
+	result := []webhooks.Domain{}
+	for _, webhook := range wr.db.Webhooks {
+		if filter(webhook) {
+			result = append(result, webhook)
+		}
+	}
+
+	return result
+}
+
+func (wr *WebhookRepository) findDeliveries(filter func(webhooks.Delivery) bool) []webhooks.Delivery {
+	wr.db.RLock()
+	defer wr.db.RUnlock()
+
+	result := []webhooks.Delivery{}
+	for _, delivery := range wr.db.WebhookDeliveries {
+		if filter(delivery) {
+			result = append(result, delivery)
+		}
+	}
+
+	return result
+}
+
+/*
+Create
+*/
+
+func (wr *WebhookRepository) Create(domain *webhooks.Domain) (webhooks.Domain, error) {
+	defer wr.db.LockWrite(context.Background())()
+
+	wr.db.Webhooks = append(wr.db.Webhooks, *domain)
+	return *domain, nil
+}
+
+func (wr *WebhookRepository) CreateDelivery(ctx context.Context, delivery *webhooks.Delivery) (webhooks.Delivery, error) {
+	defer wr.db.LockWrite(ctx)()
+
+	wr.db.WebhookDeliveries = append(wr.db.WebhookDeliveries, *delivery)
+	return *delivery, nil
+}
+
+/*
+Read
+*/
+
+func (wr *WebhookRepository) GetByID(id primitive.ObjectID) (webhooks.Domain, error) {
+	result := wr.find(func(webhook webhooks.Domain) bool {
+		return webhook.ID == id
+	})
+
+	if len(result) == 0 {
+		return webhooks.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (wr *WebhookRepository) GetActiveByEvent(event string) ([]webhooks.Domain, error) {
+	return wr.find(func(webhook webhooks.Domain) bool {
+		return webhook.IsActive && util.CheckStringOnArray(webhook.Events, event)
+	}), nil
+}
+
+func (wr *WebhookRepository) GetByQuery(query webhooks.Query) ([]webhooks.Domain, int, error) {
+	result := wr.find(func(webhook webhooks.Domain) bool {
+		if query.Event != "" && !util.CheckStringOnArray(webhook.Events, query.Event) {
+			return false
+		}
+
+		return query.IsActive == nil || webhook.IsActive == *query.IsActive
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, func(webhook webhooks.Domain) interface{} { return webhook.CreatedAt })
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+func (wr *WebhookRepository) GetDeliveryByID(id primitive.ObjectID) (webhooks.Delivery, error) {
+	result := wr.findDeliveries(func(delivery webhooks.Delivery) bool {
+		return delivery.ID == id
+	})
+
+	if len(result) == 0 {
+		return webhooks.Delivery{}, mongo.ErrNoDocuments
+	}
+
+	return result[0], nil
+}
+
+func (wr *WebhookRepository) GetDeliveriesByQuery(query webhooks.DeliveryQuery) ([]webhooks.Delivery, int, error) {
+	result := wr.findDeliveries(func(delivery webhooks.Delivery) bool {
+		if query.WebhookID != primitive.NilObjectID && delivery.WebhookID != query.WebhookID {
+			return false
+		}
+
+		if query.Event != "" && delivery.Event != query.Event {
+			return false
+		}
+
+		return query.Status == "" || delivery.Status == query.Status
+	})
+
+	total := len(result)
+	memoryDriver.Sort(result, query.Order, deliverySortKey(query.Sort))
+
+	return memoryDriver.Paginate(result, query.Skip, query.Limit), total, nil
+}
+
+/*
+Update
+*/
+
+func (wr *WebhookRepository) Update(domain *webhooks.Domain) (webhooks.Domain, error) {
+	defer wr.db.LockWrite(context.Background())()
+
+	for i, webhook := range wr.db.Webhooks {
+		if webhook.ID == domain.ID {
+			wr.db.Webhooks[i] = *domain
+		}
+	}
+
+	return *domain, nil
+}
+
+func (wr *WebhookRepository) UpdateDelivery(delivery *webhooks.Delivery) (webhooks.Delivery, error) {
+	defer wr.db.LockWrite(context.Background())()
+
+	for i, current := range wr.db.WebhookDeliveries {
+		if current.ID == delivery.ID {
+			wr.db.WebhookDeliveries[i] = *delivery
+		}
+	}
+
+	return *delivery, nil
+}
+
+/*
+Delete
+*/
+
+func (wr *WebhookRepository) Delete(id primitive.ObjectID) error {
+	defer wr.db.LockWrite(context.Background())()
+
+	webhookList := []webhooks.Domain{}
+	for _, webhook := range wr.db.Webhooks {
+		if webhook.ID != id {
+			webhookList = append(webhookList, webhook)
+		}
+	}
+
+	wr.db.Webhooks = webhookList
+	return nil
+}
@@ -0,0 +1,64 @@
+package api_keys
+
+import (
+	apiKeys "crop_connect/business/api_keys"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	UserID     primitive.ObjectID `bson:"userID"`
+	Name       string             `bson:"name"`
+	Prefix     string             `bson:"prefix"`
+	KeyHash    string             `bson:"keyHash"`
+	Scopes     []string           `bson:"scopes"`
+	ExpiresAt  primitive.DateTime `bson:"expiresAt"`
+	LastUsedAt primitive.DateTime `bson:"lastUsedAt,omitempty"`
+	RevokedAt  primitive.DateTime `bson:"revokedAt,omitempty"`
+	CreatedBy  primitive.ObjectID `bson:"createdBy"`
+	CreatedAt  primitive.DateTime `bson:"createdAt"`
+	UpdatedAt  primitive.DateTime `bson:"updatedAt,omitempty"`
+}
+
+func FromDomain(domain *apiKeys.Domain) *Model {
+	return &Model{
+		ID:         domain.ID,
+		UserID:     domain.UserID,
+		Name:       domain.Name,
+		Prefix:     domain.Prefix,
+		KeyHash:    domain.KeyHash,
+		Scopes:     domain.Scopes,
+		ExpiresAt:  domain.ExpiresAt,
+		LastUsedAt: domain.LastUsedAt,
+		RevokedAt:  domain.RevokedAt,
+		CreatedBy:  domain.CreatedBy,
+		CreatedAt:  domain.CreatedAt,
+		UpdatedAt:  domain.UpdatedAt,
+	}
+}
+
+func (model *Model) ToDomain() apiKeys.Domain {
+	return apiKeys.Domain{
+		ID:         model.ID,
+		UserID:     model.UserID,
+		Name:       model.Name,
+		Prefix:     model.Prefix,
+		KeyHash:    model.KeyHash,
+		Scopes:     model.Scopes,
+		ExpiresAt:  model.ExpiresAt,
+		LastUsedAt: model.LastUsedAt,
+		RevokedAt:  model.RevokedAt,
+		CreatedBy:  model.CreatedBy,
+		CreatedAt:  model.CreatedAt,
+		UpdatedAt:  model.UpdatedAt,
+	}
+}
+
+func ToDomainArray(model []Model) []apiKeys.Domain {
+	var domain []apiKeys.Domain
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
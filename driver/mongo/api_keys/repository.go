@@ -0,0 +1,153 @@
+package api_keys
+
+import (
+	"context"
+	apiKeys "crop_connect/business/api_keys"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type APIKeyRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) apiKeys.Repository {
+	return &APIKeyRepository{
+		collection: db.Collection("api_keys"),
+	}
+}
+
+/*
+Create
+*/
+
+func (akr *APIKeyRepository) Create(domain *apiKeys.Domain) (apiKeys.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := akr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return apiKeys.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (akr *APIKeyRepository) GetByID(id primitive.ObjectID) (apiKeys.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := akr.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (akr *APIKeyRepository) GetByKeyHash(keyHash string) (apiKeys.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := akr.collection.FindOne(ctx, bson.M{
+		"keyHash": keyHash,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (akr *APIKeyRepository) GetByQuery(query apiKeys.Query) ([]apiKeys.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.UserID != primitive.NilObjectID {
+		filter["userID"] = query.UserID
+	}
+
+	if query.IsRevoked != nil {
+		filter["revokedAt"] = bson.M{"$exists": *query.IsRevoked}
+	}
+
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{query.Sort: query.Order},
+	}, bson.M{
+		"$skip": query.Skip,
+	}, bson.M{
+		"$limit": query.Limit,
+	})
+
+	cursor, err := akr.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := akr.collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []Model
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ToDomainArray(result), countResult.Total, nil
+}
+
+/*
+Update
+*/
+
+func (akr *APIKeyRepository) Update(domain *apiKeys.Domain) (apiKeys.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := akr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return apiKeys.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (akr *APIKeyRepository) UpdateLastUsedAt(id primitive.ObjectID, lastUsedAt primitive.DateTime) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := akr.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.M{
+		"$set": bson.M{"lastUsedAt": lastUsedAt},
+	})
+
+	return err
+}
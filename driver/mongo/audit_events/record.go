@@ -0,0 +1,55 @@
+package audit_events
+
+import (
+	auditEvents "crop_connect/business/audit_events"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	EntityType string             `bson:"entityType"`
+	EntityID   primitive.ObjectID `bson:"entityID"`
+	ActorID    primitive.ObjectID `bson:"actorID,omitempty"`
+	ActorRole  string             `bson:"actorRole"`
+	OldStatus  string             `bson:"oldStatus,omitempty"`
+	NewStatus  string             `bson:"newStatus"`
+	Reason     string             `bson:"reason,omitempty"`
+	CreatedAt  primitive.DateTime `bson:"createdAt"`
+}
+
+func FromDomain(domain *auditEvents.Domain) *Model {
+	return &Model{
+		ID:         domain.ID,
+		EntityType: domain.EntityType,
+		EntityID:   domain.EntityID,
+		ActorID:    domain.ActorID,
+		ActorRole:  domain.ActorRole,
+		OldStatus:  domain.OldStatus,
+		NewStatus:  domain.NewStatus,
+		Reason:     domain.Reason,
+		CreatedAt:  domain.CreatedAt,
+	}
+}
+
+func (model *Model) ToDomain() auditEvents.Domain {
+	return auditEvents.Domain{
+		ID:         model.ID,
+		EntityType: model.EntityType,
+		EntityID:   model.EntityID,
+		ActorID:    model.ActorID,
+		ActorRole:  model.ActorRole,
+		OldStatus:  model.OldStatus,
+		NewStatus:  model.NewStatus,
+		Reason:     model.Reason,
+		CreatedAt:  model.CreatedAt,
+	}
+}
+
+func ToDomainArray(models []Model) []auditEvents.Domain {
+	var domains []auditEvents.Domain
+	for _, model := range models {
+		domains = append(domains, model.ToDomain())
+	}
+	return domains
+}
@@ -0,0 +1,146 @@
+package audit_events
+
+import (
+	"context"
+	auditEvents "crop_connect/business/audit_events"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type AuditEventRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) auditEvents.Repository {
+	return &AuditEventRepository{
+		collection: db.Collection("auditEvents"),
+	}
+}
+
+/*
+Create
+*/
+
+func (aer *AuditEventRepository) Create(ctx context.Context, domain *auditEvents.Domain) (auditEvents.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := aer.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return auditEvents.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (aer *AuditEventRepository) CreateMany(ctx context.Context, domains []auditEvents.Domain) error {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	var models []interface{}
+	for i := range domains {
+		models = append(models, FromDomain(&domains[i]))
+	}
+
+	_, err := aer.collection.InsertMany(ctx, models)
+	return err
+}
+
+/*
+Read
+*/
+
+func (aer *AuditEventRepository) GetByQuery(query auditEvents.Query) ([]auditEvents.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.EntityType != "" {
+		filter["entityType"] = query.EntityType
+	}
+
+	if query.EntityID != primitive.NilObjectID {
+		filter["entityID"] = query.EntityID
+	}
+
+	if query.ActorID != primitive.NilObjectID {
+		filter["actorID"] = query.ActorID
+	}
+
+	if query.ActorRole != "" {
+		filter["actorRole"] = query.ActorRole
+	}
+
+	if query.NewStatus != "" {
+		filter["newStatus"] = query.NewStatus
+	}
+
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{query.Sort: query.Order},
+	}, bson.M{
+		"$skip": query.Skip,
+	}, bson.M{
+		"$limit": query.Limit,
+	})
+
+	cursor, err := aer.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := aer.collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []Model
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ToDomainArray(result), countResult.Total, nil
+}
+
+func (aer *AuditEventRepository) GetByEntity(entityType string, entityID primitive.ObjectID) ([]auditEvents.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := aer.collection.Find(ctx, bson.M{
+		"entityType": entityType,
+		"entityID":   entityID,
+	}, options.Find().SetSort(bson.M{"createdAt": 1}))
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	return ToDomainArray(result), nil
+}
@@ -13,9 +13,13 @@ type Model struct {
 	EstimatedHarvestDate primitive.DateTime `bson:"estimatedHarvestDate"`
 	Status               string             `bson:"status"`
 	CancelReason         string             `bson:"cancelReason,omitempty"`
+	RemainingQuantity    float64            `bson:"remainingQuantity"`
+	IsQuantityTracked    bool               `bson:"isQuantityTracked,omitempty"`
 	IsAvailable          bool               `bson:"isAvailable"`
+	IsOverdue            bool               `bson:"isOverdue,omitempty"`
 	CreatedAt            primitive.DateTime `bson:"createdAt"`
 	UpdatedAt            primitive.DateTime `bson:"updatedAt,omitempty"`
+	Version              int                `bson:"version"`
 }
 
 func FromDomain(domain *batchs.Domain) *Model {
@@ -26,9 +30,13 @@ func FromDomain(domain *batchs.Domain) *Model {
 		EstimatedHarvestDate: domain.EstimatedHarvestDate,
 		Status:               domain.Status,
 		CancelReason:         domain.CancelReason,
+		RemainingQuantity:    domain.RemainingQuantity,
+		IsQuantityTracked:    domain.IsQuantityTracked,
 		IsAvailable:          domain.IsAvailable,
+		IsOverdue:            domain.IsOverdue,
 		CreatedAt:            domain.CreatedAt,
 		UpdatedAt:            domain.UpdatedAt,
+		Version:              domain.Version,
 	}
 }
 
@@ -40,9 +48,13 @@ func (model *Model) ToDomain() batchs.Domain {
 		EstimatedHarvestDate: model.EstimatedHarvestDate,
 		Status:               model.Status,
 		CancelReason:         model.CancelReason,
+		RemainingQuantity:    model.RemainingQuantity,
+		IsQuantityTracked:    model.IsQuantityTracked,
 		IsAvailable:          model.IsAvailable,
+		IsOverdue:            model.IsOverdue,
 		CreatedAt:            model.CreatedAt,
 		UpdatedAt:            model.UpdatedAt,
+		Version:              model.Version,
 	}
 }
 
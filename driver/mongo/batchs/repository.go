@@ -3,7 +3,10 @@ package batchs
 import (
 	"context"
 	"crop_connect/business/batchs"
+	"crop_connect/constant"
+	mongoDriver "crop_connect/driver/mongo"
 	"crop_connect/dto"
+	"crop_connect/helper"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -44,8 +47,8 @@ var (
 Create
 */
 
-func (br *BatchRepository) Create(domain *batchs.Domain) (batchs.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+func (br *BatchRepository) Create(ctx context.Context, domain *batchs.Domain) (batchs.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
 	_, err := br.collection.InsertOne(ctx, FromDomain(domain))
@@ -420,24 +423,71 @@ func (br *BatchRepository) GetForHarvestByFarmerID(farmerID primitive.ObjectID)
 	return ToDomainArray(result), nil
 }
 
+func (br *BatchRepository) GetPlantingByProposalID(proposalID primitive.ObjectID) (batchs.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := br.collection.FindOne(ctx, bson.M{
+		"proposalID": proposalID,
+		"status":     constant.BatchStatusPlanting,
+	}).Decode(&result)
+	if err != nil {
+		return batchs.Domain{}, err
+	}
+
+	return result.ToDomain(), nil
+}
+
+func (br *BatchRepository) GetOverdue(now primitive.DateTime) ([]batchs.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := br.collection.Find(ctx, bson.M{
+		"status":               constant.BatchStatusPlanting,
+		"estimatedHarvestDate": bson.M{"$lt": now},
+		"isOverdue":            bson.M{"$ne": true},
+	})
+	if err != nil {
+		return []batchs.Domain{}, err
+	}
+
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return []batchs.Domain{}, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
 /*
 Update
 */
 
-func (br *BatchRepository) Update(domain *batchs.Domain) (batchs.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+func (br *BatchRepository) Update(ctx context.Context, domain *batchs.Domain) (batchs.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
-	_, err := br.collection.UpdateOne(ctx, bson.M{
-		"_id": domain.ID,
+	model := FromDomain(domain)
+	model.Version = domain.Version + 1
+
+	result, err := br.collection.UpdateOne(ctx, bson.M{
+		"_id":     domain.ID,
+		"version": mongoDriver.VersionFilter(domain.Version),
 	}, bson.M{
-		"$set": FromDomain(domain),
+		"$set": model,
 	})
 
 	if err != nil {
 		return batchs.Domain{}, err
 	}
 
+	if result.MatchedCount == 0 {
+		return batchs.Domain{}, helper.NewConflictError("batch", domain.ID)
+	}
+
+	domain.Version = model.Version
 	return *domain, nil
 }
 
@@ -18,6 +18,8 @@ type Model struct {
 	PricePerKg     int                `bson:"pricePerKg"`
 	IsPerennials   bool               `bson:"isPerennials"`
 	IsAvailable    bool               `bson:"isAvailable"`
+	RatingAverage  float64            `bson:"ratingAverage"`
+	RatingCount    int                `bson:"ratingCount"`
 	CreatedAt      primitive.DateTime `bson:"createdAt"`
 	UpdatedAt      primitive.DateTime `bson:"updatedAt,omitempty"`
 	DeletedAt      primitive.DateTime `bson:"deletedAt,omitempty"`
@@ -36,6 +38,8 @@ func FromDomain(domain *commodities.Domain) *Model {
 		PricePerKg:     domain.PricePerKg,
 		IsPerennials:   domain.IsPerennials,
 		IsAvailable:    domain.IsAvailable,
+		RatingAverage:  domain.RatingAverage,
+		RatingCount:    domain.RatingCount,
 		CreatedAt:      domain.CreatedAt,
 		UpdatedAt:      domain.UpdatedAt,
 		DeletedAt:      domain.DeletedAt,
@@ -55,6 +59,8 @@ func (model *Model) ToDomain() commodities.Domain {
 		PricePerKg:     model.PricePerKg,
 		IsPerennials:   model.IsPerennials,
 		IsAvailable:    model.IsAvailable,
+		RatingAverage:  model.RatingAverage,
+		RatingCount:    model.RatingCount,
 		CreatedAt:      model.CreatedAt,
 		UpdatedAt:      model.UpdatedAt,
 		DeletedAt:      model.DeletedAt,
@@ -3,6 +3,7 @@ package commodities
 import (
 	"context"
 	"crop_connect/business/commodities"
+	mongoDriver "crop_connect/driver/mongo"
 	"crop_connect/dto"
 	"time"
 
@@ -450,6 +451,18 @@ func (cr *CommodityRepository) Update(domain *commodities.Domain) (commodities.D
 	return *domain, err
 }
 
+// AddRating updates every version of the commodity so the rating survives later edits.
+func (cr *CommodityRepository) AddRating(ctx context.Context, code primitive.ObjectID, score int) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := cr.collection.UpdateMany(ctx, bson.M{
+		"code": code,
+	}, mongoDriver.AddRatingPipeline(score))
+
+	return err
+}
+
 /*
 Delete
 */
@@ -0,0 +1,94 @@
+package conversations
+
+import (
+	"crop_connect/business/conversations"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID            primitive.ObjectID `bson:"_id"`
+	BuyerID       primitive.ObjectID `bson:"buyerID"`
+	FarmerID      primitive.ObjectID `bson:"farmerID"`
+	SubjectType   string             `bson:"subjectType"`
+	SubjectID     primitive.ObjectID `bson:"subjectID"`
+	LastMessage   string             `bson:"lastMessage"`
+	LastMessageAt primitive.DateTime `bson:"lastMessageAt"`
+	CreatedAt     primitive.DateTime `bson:"createdAt"`
+}
+
+type MessageModel struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	ConversationID primitive.ObjectID `bson:"conversationID"`
+	SenderID       primitive.ObjectID `bson:"senderID"`
+	Message        string             `bson:"message"`
+	Images         []string           `bson:"images,omitempty"`
+	ReadAt         primitive.DateTime `bson:"readAt,omitempty"`
+	CreatedAt      primitive.DateTime `bson:"createdAt"`
+}
+
+func FromDomain(domain *conversations.Domain) *Model {
+	return &Model{
+		ID:            domain.ID,
+		BuyerID:       domain.BuyerID,
+		FarmerID:      domain.FarmerID,
+		SubjectType:   domain.SubjectType,
+		SubjectID:     domain.SubjectID,
+		LastMessage:   domain.LastMessage,
+		LastMessageAt: domain.LastMessageAt,
+		CreatedAt:     domain.CreatedAt,
+	}
+}
+
+func (model *Model) ToDomain() conversations.Domain {
+	return conversations.Domain{
+		ID:            model.ID,
+		BuyerID:       model.BuyerID,
+		FarmerID:      model.FarmerID,
+		SubjectType:   model.SubjectType,
+		SubjectID:     model.SubjectID,
+		LastMessage:   model.LastMessage,
+		LastMessageAt: model.LastMessageAt,
+		CreatedAt:     model.CreatedAt,
+	}
+}
+
+func ToDomainArray(model []Model) []conversations.Domain {
+	var domain []conversations.Domain
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
+
+func FromMessageDomain(domain *conversations.Message) *MessageModel {
+	return &MessageModel{
+		ID:             domain.ID,
+		ConversationID: domain.ConversationID,
+		SenderID:       domain.SenderID,
+		Message:        domain.Message,
+		Images:         domain.Images,
+		ReadAt:         domain.ReadAt,
+		CreatedAt:      domain.CreatedAt,
+	}
+}
+
+func (model *MessageModel) ToDomain() conversations.Message {
+	return conversations.Message{
+		ID:             model.ID,
+		ConversationID: model.ConversationID,
+		SenderID:       model.SenderID,
+		Message:        model.Message,
+		Images:         model.Images,
+		ReadAt:         model.ReadAt,
+		CreatedAt:      model.CreatedAt,
+	}
+}
+
+func ToMessageDomainArray(model []MessageModel) []conversations.Message {
+	var domain []conversations.Message
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
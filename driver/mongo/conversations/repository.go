@@ -0,0 +1,223 @@
+package conversations
+
+import (
+	"context"
+	"crop_connect/business/conversations"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ConversationRepository struct {
+	collection        *mongo.Collection
+	messageCollection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) conversations.Repository {
+	return &ConversationRepository{
+		collection:        db.Collection("conversations"),
+		messageCollection: db.Collection("conversation_messages"),
+	}
+}
+
+/*
+Util
+*/
+
+func aggregate[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, sort string, order int, skip int64, limit int64) ([]T, int, error) {
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{sort: order},
+	}, bson.M{
+		"$skip": skip,
+	}, bson.M{
+		"$limit": limit,
+	})
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []T
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return result, countResult.Total, nil
+}
+
+/*
+Create
+*/
+
+func (cr *ConversationRepository) Create(ctx context.Context, domain *conversations.Domain) (conversations.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := cr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return conversations.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (cr *ConversationRepository) CreateMessage(ctx context.Context, message *conversations.Message) (conversations.Message, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := cr.messageCollection.InsertOne(ctx, FromMessageDomain(message))
+	if err != nil {
+		return conversations.Message{}, err
+	}
+
+	return *message, nil
+}
+
+/*
+Read
+*/
+
+func (cr *ConversationRepository) GetByID(id primitive.ObjectID) (conversations.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := cr.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (cr *ConversationRepository) GetByBuyerIDAndSubject(buyerID primitive.ObjectID, subjectType string, subjectID primitive.ObjectID) (conversations.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := cr.collection.FindOne(ctx, bson.M{
+		"buyerID":     buyerID,
+		"subjectType": subjectType,
+		"subjectID":   subjectID,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (cr *ConversationRepository) GetByQuery(query conversations.Query) ([]conversations.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.BuyerID != primitive.NilObjectID {
+		filter["buyerID"] = query.BuyerID
+	}
+
+	if query.FarmerID != primitive.NilObjectID {
+		filter["farmerID"] = query.FarmerID
+	}
+
+	if query.SubjectType != "" {
+		filter["subjectType"] = query.SubjectType
+	}
+
+	if query.SubjectID != primitive.NilObjectID {
+		filter["subjectID"] = query.SubjectID
+	}
+
+	result, total, err := aggregate[Model](ctx, cr.collection, filter, query.Sort, query.Order, query.Skip, query.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ToDomainArray(result), total, nil
+}
+
+func (cr *ConversationRepository) GetMessagesByQuery(query conversations.MessageQuery) ([]conversations.Message, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, total, err := aggregate[MessageModel](ctx, cr.messageCollection, bson.M{
+		"conversationID": query.ConversationID,
+	}, query.Sort, query.Order, query.Skip, query.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ToMessageDomainArray(result), total, nil
+}
+
+func (cr *ConversationRepository) CountUnreadMessages(conversationID primitive.ObjectID, readerID primitive.ObjectID) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	total, err := cr.messageCollection.CountDocuments(ctx, bson.M{
+		"conversationID": conversationID,
+		"senderID":       bson.M{"$ne": readerID},
+		"readAt":         bson.M{"$exists": false},
+	})
+
+	return int(total), err
+}
+
+/*
+Update
+*/
+
+func (cr *ConversationRepository) Update(ctx context.Context, domain *conversations.Domain) (conversations.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := cr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return conversations.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (cr *ConversationRepository) MarkMessagesAsRead(conversationID primitive.ObjectID, readerID primitive.ObjectID, readAt primitive.DateTime) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := cr.messageCollection.UpdateMany(ctx, bson.M{
+		"conversationID": conversationID,
+		"senderID":       bson.M{"$ne": readerID},
+		"readAt":         bson.M{"$exists": false},
+	}, bson.M{
+		"$set": bson.M{"readAt": readAt},
+	})
+
+	return err
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,126 @@
+package disputes
+
+import (
+	"crop_connect/business/disputes"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Message struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	SenderID   primitive.ObjectID `bson:"senderID"`
+	SenderRole string             `bson:"senderRole"`
+	Message    string             `bson:"message"`
+	Images     []string           `bson:"images,omitempty"`
+	CreatedAt  primitive.DateTime `bson:"createdAt"`
+}
+
+type Model struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	TransactionID  primitive.ObjectID `bson:"transactionID"`
+	ShipmentID     primitive.ObjectID `bson:"shipmentID"`
+	BuyerID        primitive.ObjectID `bson:"buyerID"`
+	FarmerID       primitive.ObjectID `bson:"farmerID"`
+	ValidatorID    primitive.ObjectID `bson:"validatorID,omitempty"`
+	Reason         string             `bson:"reason"`
+	Description    string             `bson:"description"`
+	Evidence       []string           `bson:"evidence"`
+	Messages       []Message          `bson:"messages"`
+	Status         string             `bson:"status"`
+	Outcome        string             `bson:"outcome,omitempty"`
+	RefundAmount   float64            `bson:"refundAmount,omitempty"`
+	ResolutionNote string             `bson:"resolutionNote,omitempty"`
+	ResolverID     primitive.ObjectID `bson:"resolverID,omitempty"`
+	Version        int                `bson:"version"`
+	CreatedAt      primitive.DateTime `bson:"createdAt"`
+	UpdatedAt      primitive.DateTime `bson:"updatedAt,omitempty"`
+	ResolvedAt     primitive.DateTime `bson:"resolvedAt,omitempty"`
+}
+
+func MessageFromDomain(domain *disputes.Message) Message {
+	return Message{
+		ID:         domain.ID,
+		SenderID:   domain.SenderID,
+		SenderRole: domain.SenderRole,
+		Message:    domain.Message,
+		Images:     domain.Images,
+		CreatedAt:  domain.CreatedAt,
+	}
+}
+
+func (message *Message) ToDomain() disputes.Message {
+	return disputes.Message{
+		ID:         message.ID,
+		SenderID:   message.SenderID,
+		SenderRole: message.SenderRole,
+		Message:    message.Message,
+		Images:     message.Images,
+		CreatedAt:  message.CreatedAt,
+	}
+}
+
+func FromDomain(domain *disputes.Domain) *Model {
+	messages := []Message{}
+	for _, message := range domain.Messages {
+		messages = append(messages, MessageFromDomain(&message))
+	}
+
+	return &Model{
+		ID:             domain.ID,
+		TransactionID:  domain.TransactionID,
+		ShipmentID:     domain.ShipmentID,
+		BuyerID:        domain.BuyerID,
+		FarmerID:       domain.FarmerID,
+		ValidatorID:    domain.ValidatorID,
+		Reason:         domain.Reason,
+		Description:    domain.Description,
+		Evidence:       domain.Evidence,
+		Messages:       messages,
+		Status:         domain.Status,
+		Outcome:        domain.Outcome,
+		RefundAmount:   domain.RefundAmount,
+		ResolutionNote: domain.ResolutionNote,
+		ResolverID:     domain.ResolverID,
+		Version:        domain.Version,
+		CreatedAt:      domain.CreatedAt,
+		UpdatedAt:      domain.UpdatedAt,
+		ResolvedAt:     domain.ResolvedAt,
+	}
+}
+
+func (model *Model) ToDomain() disputes.Domain {
+	messages := []disputes.Message{}
+	for _, message := range model.Messages {
+		messages = append(messages, message.ToDomain())
+	}
+
+	return disputes.Domain{
+		ID:             model.ID,
+		TransactionID:  model.TransactionID,
+		ShipmentID:     model.ShipmentID,
+		BuyerID:        model.BuyerID,
+		FarmerID:       model.FarmerID,
+		ValidatorID:    model.ValidatorID,
+		Reason:         model.Reason,
+		Description:    model.Description,
+		Evidence:       model.Evidence,
+		Messages:       messages,
+		Status:         model.Status,
+		Outcome:        model.Outcome,
+		RefundAmount:   model.RefundAmount,
+		ResolutionNote: model.ResolutionNote,
+		ResolverID:     model.ResolverID,
+		Version:        model.Version,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+		ResolvedAt:     model.ResolvedAt,
+	}
+}
+
+func ToDomainArray(model []Model) []disputes.Domain {
+	var domain []disputes.Domain
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
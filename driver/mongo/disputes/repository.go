@@ -0,0 +1,190 @@
+package disputes
+
+import (
+	"context"
+	"crop_connect/business/disputes"
+	"crop_connect/constant"
+	mongoDriver "crop_connect/driver/mongo"
+	"crop_connect/dto"
+	"crop_connect/helper"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type DisputeRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) disputes.Repository {
+	return &DisputeRepository{
+		collection: db.Collection("disputes"),
+	}
+}
+
+/*
+Create
+*/
+
+func (dr *DisputeRepository) Create(ctx context.Context, domain *disputes.Domain) (disputes.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := dr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return disputes.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (dr *DisputeRepository) GetByID(id primitive.ObjectID) (disputes.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := dr.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (dr *DisputeRepository) GetOpenByTransactionID(transactionID primitive.ObjectID) (disputes.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := dr.collection.FindOne(ctx, bson.M{
+		"transactionID": transactionID,
+		"status":        constant.DisputeStatusOpen,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (dr *DisputeRepository) GetByQuery(query disputes.Query) ([]disputes.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.BuyerID != primitive.NilObjectID {
+		filter["buyerID"] = query.BuyerID
+	}
+
+	if query.FarmerID != primitive.NilObjectID {
+		filter["farmerID"] = query.FarmerID
+	}
+
+	if query.ValidatorID != primitive.NilObjectID {
+		filter["validatorID"] = query.ValidatorID
+	}
+
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{query.Sort: query.Order},
+	}, bson.M{
+		"$skip": query.Skip,
+	}, bson.M{
+		"$limit": query.Limit,
+	})
+
+	cursor, err := dr.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := dr.collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []Model
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ToDomainArray(result), countResult.Total, nil
+}
+
+/*
+Update
+*/
+
+func (dr *DisputeRepository) AddMessage(ctx context.Context, id primitive.ObjectID, message *disputes.Message) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := dr.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.M{
+		"$push": bson.M{"messages": MessageFromDomain(message)},
+		"$set":  bson.M{"updatedAt": message.CreatedAt},
+	})
+
+	return err
+}
+
+func (dr *DisputeRepository) Update(ctx context.Context, domain *disputes.Domain) (disputes.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	model := FromDomain(domain)
+	model.Version = domain.Version + 1
+
+	result, err := dr.collection.UpdateOne(ctx, bson.M{
+		"_id":     domain.ID,
+		"version": mongoDriver.VersionFilter(domain.Version),
+	}, bson.M{
+		// messages are pushed separately, so only the resolution is written to keep messages sent meanwhile
+		"$set": bson.M{
+			"status":         model.Status,
+			"outcome":        model.Outcome,
+			"refundAmount":   model.RefundAmount,
+			"resolutionNote": model.ResolutionNote,
+			"resolverID":     model.ResolverID,
+			"version":        model.Version,
+			"updatedAt":      model.UpdatedAt,
+			"resolvedAt":     model.ResolvedAt,
+		},
+	})
+
+	if err != nil {
+		return disputes.Domain{}, err
+	}
+
+	if result.MatchedCount == 0 {
+		return disputes.Domain{}, helper.NewConflictError("sengketa", domain.ID)
+	}
+
+	domain.Version = model.Version
+	return *domain, nil
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,44 @@
+package email_verifications
+
+import (
+	emailVerification "crop_connect/business/email_verifications"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID        primitive.ObjectID `bson:"_id"`
+	UserID    primitive.ObjectID `bson:"userID"`
+	Email     string             `bson:"email"`
+	Token     string             `bson:"token"`
+	IsUsed    bool               `bson:"isUsed"`
+	CreatedAt primitive.DateTime `bson:"createdAt"`
+	UpdatedAt primitive.DateTime `bson:"updatedAt,omitempty"`
+	ExpiredAt primitive.DateTime `bson:"expiredAt"`
+}
+
+func FromDomain(domain *emailVerification.Domain) *Model {
+	return &Model{
+		ID:        domain.ID,
+		UserID:    domain.UserID,
+		Email:     domain.Email,
+		Token:     domain.Token,
+		IsUsed:    domain.IsUsed,
+		CreatedAt: domain.CreatedAt,
+		UpdatedAt: domain.UpdatedAt,
+		ExpiredAt: domain.ExpiredAt,
+	}
+}
+
+func (m *Model) ToDomain() emailVerification.Domain {
+	return emailVerification.Domain{
+		ID:        m.ID,
+		UserID:    m.UserID,
+		Email:     m.Email,
+		Token:     m.Token,
+		IsUsed:    m.IsUsed,
+		CreatedAt: m.CreatedAt,
+		UpdatedAt: m.UpdatedAt,
+		ExpiredAt: m.ExpiredAt,
+	}
+}
@@ -0,0 +1,106 @@
+package email_verifications
+
+import (
+	"context"
+	emailVerification "crop_connect/business/email_verifications"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type EmailVerificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) emailVerification.Repository {
+	return &EmailVerificationRepository{
+		collection: db.Collection("emailVerifications"),
+	}
+}
+
+/*
+Create
+*/
+
+func (evr *EmailVerificationRepository) Create(domain *emailVerification.Domain) (emailVerification.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := evr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return emailVerification.Domain{}, err
+	}
+
+	return *domain, err
+}
+
+/*
+Read
+*/
+
+func (evr *EmailVerificationRepository) GetByToken(token string) (emailVerification.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := evr.collection.FindOne(ctx, bson.M{
+		"token": token,
+	}).Decode(&result)
+	if err != nil {
+		return emailVerification.Domain{}, err
+	}
+
+	return result.ToDomain(), nil
+}
+
+/*
+Update
+*/
+
+func (evr *EmailVerificationRepository) Update(domain *emailVerification.Domain) (emailVerification.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := evr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return emailVerification.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Delete
+*/
+
+func (evr *EmailVerificationRepository) HardDelete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := evr.collection.DeleteOne(ctx, bson.M{"_id": id})
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+func (evr *EmailVerificationRepository) HardDeleteExpired(now primitive.DateTime) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := evr.collection.DeleteMany(ctx, bson.M{
+		"expiredAt": bson.M{"$lt": now},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
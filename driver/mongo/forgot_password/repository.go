@@ -90,3 +90,17 @@ func (fpr *ForgotPasswordRepository) HardDelete(id primitive.ObjectID) error {
 
 	return nil
 }
+
+func (fpr *ForgotPasswordRepository) HardDeleteExpired(now primitive.DateTime) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := fpr.collection.DeleteMany(ctx, bson.M{
+		"expiredAt": bson.M{"$lt": now},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
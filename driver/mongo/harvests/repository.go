@@ -265,8 +265,8 @@ func (hr *HarvestRepository) CountByYear(year int) (float64, error) {
 Update
 */
 
-func (hr *HarvestRepository) Update(domain *harvests.Domain) (harvests.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+func (hr *HarvestRepository) Update(ctx context.Context, domain *harvests.Domain) (harvests.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
 	_, err := hr.collection.UpdateOne(ctx, bson.M{
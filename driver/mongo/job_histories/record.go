@@ -0,0 +1,49 @@
+package job_histories
+
+import (
+	jobHistories "crop_connect/business/job_histories"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID         primitive.ObjectID `bson:"_id"`
+	Name       string             `bson:"name"`
+	Status     string             `bson:"status"`
+	Affected   int                `bson:"affected"`
+	Error      string             `bson:"error,omitempty"`
+	CreatedAt  primitive.DateTime `bson:"createdAt"`
+	FinishedAt primitive.DateTime `bson:"finishedAt"`
+}
+
+func FromDomain(domain *jobHistories.Domain) *Model {
+	return &Model{
+		ID:         domain.ID,
+		Name:       domain.Name,
+		Status:     domain.Status,
+		Affected:   domain.Affected,
+		Error:      domain.Error,
+		CreatedAt:  domain.CreatedAt,
+		FinishedAt: domain.FinishedAt,
+	}
+}
+
+func (model *Model) ToDomain() jobHistories.Domain {
+	return jobHistories.Domain{
+		ID:         model.ID,
+		Name:       model.Name,
+		Status:     model.Status,
+		Affected:   model.Affected,
+		Error:      model.Error,
+		CreatedAt:  model.CreatedAt,
+		FinishedAt: model.FinishedAt,
+	}
+}
+
+func ToDomainArray(models []Model) []jobHistories.Domain {
+	var domains []jobHistories.Domain
+	for _, model := range models {
+		domains = append(domains, model.ToDomain())
+	}
+	return domains
+}
@@ -0,0 +1,103 @@
+package job_histories
+
+import (
+	"context"
+	jobHistories "crop_connect/business/job_histories"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type JobHistoryRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) jobHistories.Repository {
+	return &JobHistoryRepository{
+		collection: db.Collection("jobHistories"),
+	}
+}
+
+/*
+Create
+*/
+
+func (jhr *JobHistoryRepository) Create(ctx context.Context, domain *jobHistories.Domain) (jobHistories.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := jhr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return jobHistories.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (jhr *JobHistoryRepository) GetByQuery(query jobHistories.Query) ([]jobHistories.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.Name != "" {
+		filter["name"] = query.Name
+	}
+
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{query.Sort: query.Order},
+	}, bson.M{
+		"$skip": query.Skip,
+	}, bson.M{
+		"$limit": query.Limit,
+	})
+
+	cursor, err := jhr.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := jhr.collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []Model
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ToDomainArray(result), countResult.Total, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
@@ -0,0 +1,56 @@
+package jobs
+
+import (
+	"crop_connect/business/jobs"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	Type        string             `bson:"type"`
+	Payload     string             `bson:"payload"`
+	Status      string             `bson:"status"`
+	Attempts    int                `bson:"attempts"`
+	MaxAttempts int                `bson:"maxAttempts"`
+	LastError   string             `bson:"lastError,omitempty"`
+	RunAt       primitive.DateTime `bson:"runAt"`
+	LockedUntil primitive.DateTime `bson:"lockedUntil,omitempty"`
+	CreatedAt   primitive.DateTime `bson:"createdAt"`
+	UpdatedAt   primitive.DateTime `bson:"updatedAt,omitempty"`
+	FinishedAt  primitive.DateTime `bson:"finishedAt,omitempty"`
+}
+
+func FromDomain(domain *jobs.Domain) *Model {
+	return &Model{
+		ID:          domain.ID,
+		Type:        domain.Type,
+		Payload:     domain.Payload,
+		Status:      domain.Status,
+		Attempts:    domain.Attempts,
+		MaxAttempts: domain.MaxAttempts,
+		LastError:   domain.LastError,
+		RunAt:       domain.RunAt,
+		LockedUntil: domain.LockedUntil,
+		CreatedAt:   domain.CreatedAt,
+		UpdatedAt:   domain.UpdatedAt,
+		FinishedAt:  domain.FinishedAt,
+	}
+}
+
+func (model *Model) ToDomain() jobs.Domain {
+	return jobs.Domain{
+		ID:          model.ID,
+		Type:        model.Type,
+		Payload:     model.Payload,
+		Status:      model.Status,
+		Attempts:    model.Attempts,
+		MaxAttempts: model.MaxAttempts,
+		LastError:   model.LastError,
+		RunAt:       model.RunAt,
+		LockedUntil: model.LockedUntil,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+		FinishedAt:  model.FinishedAt,
+	}
+}
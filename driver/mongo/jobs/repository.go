@@ -0,0 +1,114 @@
+package jobs
+
+import (
+	"context"
+	"crop_connect/business/jobs"
+	"crop_connect/constant"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type JobRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) jobs.Repository {
+	return &JobRepository{
+		collection: db.Collection("jobs"),
+	}
+}
+
+/*
+Create
+*/
+
+func (jr *JobRepository) Create(ctx context.Context, domain *jobs.Domain) (jobs.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := jr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return jobs.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (jr *JobRepository) ClaimNext(now primitive.DateTime, lockedUntil primitive.DateTime) (jobs.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := jr.collection.FindOneAndUpdate(ctx, bson.M{
+		"$or": []bson.M{
+			{
+				"status": constant.JobStatusPending,
+				"runAt":  bson.M{"$lte": now},
+			},
+			{
+				"status":      constant.JobStatusProcessing,
+				"lockedUntil": bson.M{"$lt": now},
+			},
+		},
+	}, bson.M{
+		"$set": bson.M{
+			"status":      constant.JobStatusProcessing,
+			"lockedUntil": lockedUntil,
+			"updatedAt":   now,
+		},
+		"$inc": bson.M{
+			"attempts": 1,
+		},
+	}, options.FindOneAndUpdate().SetSort(bson.M{"runAt": 1}).SetReturnDocument(options.After)).Decode(&result)
+	if err != nil {
+		return jobs.Domain{}, err
+	}
+
+	return result.ToDomain(), nil
+}
+
+/*
+Update
+*/
+
+func (jr *JobRepository) Update(domain *jobs.Domain) (jobs.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := jr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return jobs.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (jr *JobRepository) ExtendLock(id primitive.ObjectID, lockedUntil primitive.DateTime) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := jr.collection.UpdateOne(ctx, bson.M{
+		"_id":    id,
+		"status": constant.JobStatusProcessing,
+	}, bson.M{
+		"$set": bson.M{"lockedUntil": lockedUntil},
+	})
+
+	return err
+}
+
+/*
+Delete
+*/
@@ -57,3 +57,28 @@ func CheckCollectionExist(db *mongo.Database, collectionName string) (bool, erro
 
 	return len(cursor) > 0, nil
 }
+
+// VersionFilter matches documents on the given version, documents written before the version field existed are treated as version 0.
+func VersionFilter(version int) interface{} {
+	if version == 0 {
+		return bson.M{"$in": bson.A{0, nil}}
+	}
+
+	return version
+}
+
+// AddRatingPipeline folds a new score into ratingAverage and ratingCount in a single update, so concurrent ratings are not lost.
+func AddRatingPipeline(score int) bson.A {
+	count := bson.M{"$ifNull": bson.A{"$ratingCount", 0}}
+	average := bson.M{"$ifNull": bson.A{"$ratingAverage", 0}}
+
+	return bson.A{
+		bson.M{"$set": bson.M{
+			"ratingAverage": bson.M{"$divide": bson.A{
+				bson.M{"$add": bson.A{bson.M{"$multiply": bson.A{average, count}}, score}},
+				bson.M{"$add": bson.A{count, 1}},
+			}},
+			"ratingCount": bson.M{"$add": bson.A{count, 1}},
+		}},
+	}
+}
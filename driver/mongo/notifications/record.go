@@ -0,0 +1,55 @@
+package notifications
+
+import (
+	"crop_connect/business/notifications"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	UserID      primitive.ObjectID `bson:"userID"`
+	Type        string             `bson:"type"`
+	Title       string             `bson:"title"`
+	Message     string             `bson:"message"`
+	ReferenceID primitive.ObjectID `bson:"referenceID,omitempty"`
+	IsRead      bool               `bson:"isRead"`
+	ReadAt      primitive.DateTime `bson:"readAt,omitempty"`
+	CreatedAt   primitive.DateTime `bson:"createdAt"`
+}
+
+func FromDomain(domain *notifications.Domain) *Model {
+	return &Model{
+		ID:          domain.ID,
+		UserID:      domain.UserID,
+		Type:        domain.Type,
+		Title:       domain.Title,
+		Message:     domain.Message,
+		ReferenceID: domain.ReferenceID,
+		IsRead:      domain.IsRead,
+		ReadAt:      domain.ReadAt,
+		CreatedAt:   domain.CreatedAt,
+	}
+}
+
+func (model *Model) ToDomain() notifications.Domain {
+	return notifications.Domain{
+		ID:          model.ID,
+		UserID:      model.UserID,
+		Type:        model.Type,
+		Title:       model.Title,
+		Message:     model.Message,
+		ReferenceID: model.ReferenceID,
+		IsRead:      model.IsRead,
+		ReadAt:      model.ReadAt,
+		CreatedAt:   model.CreatedAt,
+	}
+}
+
+func ToDomainArray(models []Model) []notifications.Domain {
+	var domains []notifications.Domain
+	for _, model := range models {
+		domains = append(domains, model.ToDomain())
+	}
+	return domains
+}
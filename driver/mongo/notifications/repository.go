@@ -0,0 +1,172 @@
+package notifications
+
+import (
+	"context"
+	"crop_connect/business/notifications"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type NotificationRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) notifications.Repository {
+	return &NotificationRepository{
+		collection: db.Collection("notifications"),
+	}
+}
+
+/*
+Create
+*/
+
+func (nr *NotificationRepository) Create(ctx context.Context, domain *notifications.Domain) (notifications.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := nr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return notifications.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (nr *NotificationRepository) CreateMany(ctx context.Context, domains []notifications.Domain) error {
+	if len(domains) == 0 {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	var models []interface{}
+	for i := range domains {
+		models = append(models, FromDomain(&domains[i]))
+	}
+
+	_, err := nr.collection.InsertMany(ctx, models)
+	return err
+}
+
+/*
+Read
+*/
+
+func (nr *NotificationRepository) GetByQuery(query notifications.Query) ([]notifications.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{
+		"userID": query.UserID,
+	}
+
+	if query.IsRead != nil {
+		filter["isRead"] = *query.IsRead
+	}
+
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{query.Sort: query.Order},
+	}, bson.M{
+		"$skip": query.Skip,
+	}, bson.M{
+		"$limit": query.Limit,
+	})
+
+	cursor, err := nr.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := nr.collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []Model
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ToDomainArray(result), countResult.Total, nil
+}
+
+func (nr *NotificationRepository) CountUnreadByUserID(userID primitive.ObjectID) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	total, err := nr.collection.CountDocuments(ctx, bson.M{
+		"userID": userID,
+		"isRead": false,
+	})
+
+	return int(total), err
+}
+
+/*
+Update
+*/
+
+func (nr *NotificationRepository) MarkAsRead(id primitive.ObjectID, userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := nr.collection.UpdateOne(ctx, bson.M{
+		"_id":    id,
+		"userID": userID,
+	}, bson.M{
+		"$set": bson.M{
+			"isRead": true,
+			"readAt": primitive.NewDateTimeFromTime(time.Now()),
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (nr *NotificationRepository) MarkAllAsRead(userID primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := nr.collection.UpdateMany(ctx, bson.M{
+		"userID": userID,
+		"isRead": false,
+	}, bson.M{
+		"$set": bson.M{
+			"isRead": true,
+			"readAt": primitive.NewDateTimeFromTime(time.Now()),
+		},
+	})
+
+	return err
+}
+
+/*
+Delete
+*/
@@ -0,0 +1,73 @@
+package payments
+
+import (
+	"crop_connect/business/payments"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	TransactionID  primitive.ObjectID `bson:"transactionID"`
+	BuyerID        primitive.ObjectID `bson:"buyerID"`
+	Gateway        string             `bson:"gateway"`
+	ExternalID     string             `bson:"externalID"`
+	PaymentURL     string             `bson:"paymentURL,omitempty"`
+	Amount         float64            `bson:"amount"`
+	RefundedAmount float64            `bson:"refundedAmount,omitempty"`
+	IsTopUp        bool               `bson:"isTopUp,omitempty"`
+	Status         string             `bson:"status"`
+	ExpiredAt      primitive.DateTime `bson:"expiredAt"`
+	PaidAt         primitive.DateTime `bson:"paidAt,omitempty"`
+	RefundedAt     primitive.DateTime `bson:"refundedAt,omitempty"`
+	CreatedAt      primitive.DateTime `bson:"createdAt"`
+	UpdatedAt      primitive.DateTime `bson:"updatedAt,omitempty"`
+}
+
+func FromDomain(domain *payments.Domain) *Model {
+	return &Model{
+		ID:             domain.ID,
+		TransactionID:  domain.TransactionID,
+		BuyerID:        domain.BuyerID,
+		Gateway:        domain.Gateway,
+		ExternalID:     domain.ExternalID,
+		PaymentURL:     domain.PaymentURL,
+		Amount:         domain.Amount,
+		RefundedAmount: domain.RefundedAmount,
+		IsTopUp:        domain.IsTopUp,
+		Status:         domain.Status,
+		ExpiredAt:      domain.ExpiredAt,
+		PaidAt:         domain.PaidAt,
+		RefundedAt:     domain.RefundedAt,
+		CreatedAt:      domain.CreatedAt,
+		UpdatedAt:      domain.UpdatedAt,
+	}
+}
+
+func (model *Model) ToDomain() payments.Domain {
+	return payments.Domain{
+		ID:             model.ID,
+		TransactionID:  model.TransactionID,
+		BuyerID:        model.BuyerID,
+		Gateway:        model.Gateway,
+		ExternalID:     model.ExternalID,
+		PaymentURL:     model.PaymentURL,
+		Amount:         model.Amount,
+		RefundedAmount: model.RefundedAmount,
+		IsTopUp:        model.IsTopUp,
+		Status:         model.Status,
+		ExpiredAt:      model.ExpiredAt,
+		PaidAt:         model.PaidAt,
+		RefundedAt:     model.RefundedAt,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+	}
+}
+
+func ToDomainArray(model []Model) []payments.Domain {
+	var domain []payments.Domain
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
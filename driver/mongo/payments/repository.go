@@ -0,0 +1,134 @@
+package payments
+
+import (
+	"context"
+	"crop_connect/business/payments"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type PaymentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) payments.Repository {
+	return &PaymentRepository{
+		collection: db.Collection("payments"),
+	}
+}
+
+/*
+Create
+*/
+
+func (pr *PaymentRepository) Create(domain *payments.Domain) (payments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := pr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return payments.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (pr *PaymentRepository) GetByID(id primitive.ObjectID) (payments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := pr.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (pr *PaymentRepository) GetByExternalID(externalID string) (payments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := pr.collection.FindOne(ctx, bson.M{
+		"externalID": externalID,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (pr *PaymentRepository) GetByTransactionID(transactionID primitive.ObjectID) ([]payments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := pr.collection.Find(ctx, bson.M{
+		"transactionID": transactionID,
+	}, &options.FindOptions{
+		Sort: bson.M{"createdAt": -1},
+	})
+	if err != nil {
+		return []payments.Domain{}, err
+	}
+
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return []payments.Domain{}, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
+/*
+Update
+*/
+
+func (pr *PaymentRepository) Update(ctx context.Context, domain *payments.Domain) (payments.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := pr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return payments.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+// UpdateByStatus only saves the payment while it still has status, mongo.ErrNoDocuments means another request moved it first.
+func (pr *PaymentRepository) UpdateByStatus(ctx context.Context, domain *payments.Domain, status string) (payments.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	result, err := pr.collection.UpdateOne(ctx, bson.M{
+		"_id":    domain.ID,
+		"status": status,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return payments.Domain{}, err
+	}
+
+	if result.MatchedCount == 0 {
+		return payments.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return *domain, nil
+}
+
+/*
+Delete
+*/
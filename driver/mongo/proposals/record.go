@@ -19,10 +19,13 @@ type Model struct {
 	EstimatedTotalHarvest float64            `bson:"estimatedTotalHarvest"`
 	PlantingArea          float64            `bson:"plantingArea"`
 	Address               string             `bson:"address"`
+	RemainingQuantity     float64            `bson:"remainingQuantity"`
+	IsQuantityTracked     bool               `bson:"isQuantityTracked,omitempty"`
 	IsAvailable           bool               `bson:"isAvailable"`
 	CreatedAt             primitive.DateTime `bson:"createdAt"`
 	UpdatedAt             primitive.DateTime `bson:"updatedAt,omitempty"`
 	DeletedAt             primitive.DateTime `bson:"deletedAt,omitempty"`
+	Version               int                `bson:"version"`
 }
 
 func FromDomain(domain *proposals.Domain) *Model {
@@ -39,10 +42,13 @@ func FromDomain(domain *proposals.Domain) *Model {
 		EstimatedTotalHarvest: domain.EstimatedTotalHarvest,
 		PlantingArea:          domain.PlantingArea,
 		Address:               domain.Address,
+		RemainingQuantity:     domain.RemainingQuantity,
+		IsQuantityTracked:     domain.IsQuantityTracked,
 		IsAvailable:           domain.IsAvailable,
 		CreatedAt:             domain.CreatedAt,
 		UpdatedAt:             domain.UpdatedAt,
 		DeletedAt:             domain.DeletedAt,
+		Version:               domain.Version,
 	}
 }
 
@@ -59,10 +65,13 @@ func (model *Model) ToDomain() proposals.Domain {
 		EstimatedTotalHarvest: model.EstimatedTotalHarvest,
 		PlantingArea:          model.PlantingArea,
 		Address:               model.Address,
+		RemainingQuantity:     model.RemainingQuantity,
+		IsQuantityTracked:     model.IsQuantityTracked,
 		IsAvailable:           model.IsAvailable,
 		CreatedAt:             model.CreatedAt,
 		UpdatedAt:             model.UpdatedAt,
 		DeletedAt:             model.DeletedAt,
+		Version:               model.Version,
 	}
 }
 
@@ -4,7 +4,9 @@ import (
 	"context"
 	"crop_connect/business/proposals"
 	"crop_connect/constant"
+	mongoDriver "crop_connect/driver/mongo"
 	"crop_connect/dto"
+	"crop_connect/helper"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -234,6 +236,22 @@ func (pr *ProposalRepository) CountTotalProposalByFarmer(farmerID primitive.Obje
 	return result.Total, nil
 }
 
+func (pr *ProposalRepository) CountPendingByValidatorID(validatorID primitive.ObjectID) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	total, err := pr.collection.CountDocuments(ctx, bson.M{
+		"validatorID": validatorID,
+		"status":      constant.ProposalStatusPending,
+		"deletedAt":   bson.M{"$exists": false},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(total), nil
+}
+
 func (pr *ProposalRepository) GetByQuery(query proposals.Query) ([]proposals.Domain, int, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
@@ -271,6 +289,14 @@ func (pr *ProposalRepository) GetByQuery(query proposals.Query) ([]proposals.Dom
 		})
 	}
 
+	if query.ValidatorID != primitive.NilObjectID {
+		pipeline = append(pipeline, bson.M{
+			"$match": bson.M{
+				"validatorID": query.ValidatorID,
+			},
+		})
+	}
+
 	if query.Name != "" {
 		pipeline = append(pipeline, bson.M{
 			"$match": bson.M{
@@ -374,43 +400,56 @@ func (pr *ProposalRepository) GetForPerennials(commodityID primitive.ObjectID, f
 Update
 */
 
-func (pr *ProposalRepository) Update(domain *proposals.Domain) (proposals.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+func (pr *ProposalRepository) Update(ctx context.Context, domain *proposals.Domain) (proposals.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
-	_, err := pr.collection.UpdateOne(ctx, bson.M{
+	model := FromDomain(domain)
+	model.Version = domain.Version + 1
+
+	result, err := pr.collection.UpdateOne(ctx, bson.M{
 		"_id":       domain.ID,
 		"deletedAt": bson.M{"$exists": false},
+		"version":   mongoDriver.VersionFilter(domain.Version),
 	}, bson.M{
-		"$set": FromDomain(domain),
+		"$set": model,
 	})
 	if err != nil {
 		return proposals.Domain{}, err
 	}
 
+	if result.MatchedCount == 0 {
+		return proposals.Domain{}, helper.NewConflictError("proposal", domain.ID)
+	}
+
+	domain.Version = model.Version
 	return *domain, nil
 }
 
-func (pr *ProposalRepository) UnsetRejectReason(id primitive.ObjectID) (proposals.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+// UnsetRejectReason clears the reject reason of the proposal read at domain.Version, $set leaves the field alone because it is omitted when empty.
+func (pr *ProposalRepository) UnsetRejectReason(ctx context.Context, domain *proposals.Domain) (proposals.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
-	_, err := pr.collection.UpdateOne(ctx, bson.M{
-		"_id":       id,
+	result, err := pr.collection.UpdateOne(ctx, bson.M{
+		"_id":       domain.ID,
 		"deletedAt": bson.M{"$exists": false},
+		"version":   mongoDriver.VersionFilter(domain.Version),
 	}, bson.M{
 		"$unset": bson.M{"rejectReason": ""},
+		"$inc":   bson.M{"version": 1},
 	})
 	if err != nil {
 		return proposals.Domain{}, err
 	}
 
-	updatedProposal, err := pr.GetByID(id)
-	if err != nil {
-		return proposals.Domain{}, err
+	if result.MatchedCount == 0 {
+		return proposals.Domain{}, helper.NewConflictError("proposal", domain.ID)
 	}
 
-	return updatedProposal, nil
+	domain.RejectReason = ""
+	domain.Version++
+	return *domain, nil
 }
 
 /*
@@ -0,0 +1,58 @@
+package ratings
+
+import (
+	"crop_connect/business/ratings"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	TransactionID   primitive.ObjectID `bson:"transactionID"`
+	BuyerID         primitive.ObjectID `bson:"buyerID"`
+	FarmerID        primitive.ObjectID `bson:"farmerID"`
+	CommodityID     primitive.ObjectID `bson:"commodityID"`
+	CommodityCode   primitive.ObjectID `bson:"commodityCode"`
+	FarmerRating    int                `bson:"farmerRating"`
+	CommodityRating int                `bson:"commodityRating"`
+	Review          string             `bson:"review,omitempty"`
+	CreatedAt       primitive.DateTime `bson:"createdAt"`
+}
+
+func FromDomain(domain *ratings.Domain) *Model {
+	return &Model{
+		ID:              domain.ID,
+		TransactionID:   domain.TransactionID,
+		BuyerID:         domain.BuyerID,
+		FarmerID:        domain.FarmerID,
+		CommodityID:     domain.CommodityID,
+		CommodityCode:   domain.CommodityCode,
+		FarmerRating:    domain.FarmerRating,
+		CommodityRating: domain.CommodityRating,
+		Review:          domain.Review,
+		CreatedAt:       domain.CreatedAt,
+	}
+}
+
+func (model *Model) ToDomain() ratings.Domain {
+	return ratings.Domain{
+		ID:              model.ID,
+		TransactionID:   model.TransactionID,
+		BuyerID:         model.BuyerID,
+		FarmerID:        model.FarmerID,
+		CommodityID:     model.CommodityID,
+		CommodityCode:   model.CommodityCode,
+		FarmerRating:    model.FarmerRating,
+		CommodityRating: model.CommodityRating,
+		Review:          model.Review,
+		CreatedAt:       model.CreatedAt,
+	}
+}
+
+func ToDomainArray(model []Model) []ratings.Domain {
+	var domain []ratings.Domain
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
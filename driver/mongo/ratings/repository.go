@@ -0,0 +1,116 @@
+package ratings
+
+import (
+	"context"
+	"crop_connect/business/ratings"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type RatingRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) ratings.Repository {
+	return &RatingRepository{
+		collection: db.Collection("ratings"),
+	}
+}
+
+/*
+Create
+*/
+
+func (rr *RatingRepository) Create(ctx context.Context, domain *ratings.Domain) (ratings.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := rr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return ratings.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (rr *RatingRepository) GetByTransactionID(transactionID primitive.ObjectID) (ratings.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := rr.collection.FindOne(ctx, bson.M{
+		"transactionID": transactionID,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (rr *RatingRepository) GetByQuery(query ratings.Query) ([]ratings.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.FarmerID != primitive.NilObjectID {
+		filter["farmerID"] = query.FarmerID
+	}
+
+	if query.CommodityCode != primitive.NilObjectID {
+		filter["commodityCode"] = query.CommodityCode
+	}
+
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{query.Sort: query.Order},
+	}, bson.M{
+		"$skip": query.Skip,
+	}, bson.M{
+		"$limit": query.Limit,
+	})
+
+	cursor, err := rr.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := rr.collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []Model
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ToDomainArray(result), countResult.Total, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
@@ -0,0 +1,32 @@
+package revoked_tokens
+
+import (
+	revokedTokens "crop_connect/business/revoked_tokens"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID        string             `bson:"_id"`
+	UserID    primitive.ObjectID `bson:"userID"`
+	ExpiredAt primitive.DateTime `bson:"expiredAt"`
+	CreatedAt primitive.DateTime `bson:"createdAt"`
+}
+
+func FromDomain(domain *revokedTokens.Domain) *Model {
+	return &Model{
+		ID:        domain.ID,
+		UserID:    domain.UserID,
+		ExpiredAt: domain.ExpiredAt,
+		CreatedAt: domain.CreatedAt,
+	}
+}
+
+func (model *Model) ToDomain() revokedTokens.Domain {
+	return revokedTokens.Domain{
+		ID:        model.ID,
+		UserID:    model.UserID,
+		ExpiredAt: model.ExpiredAt,
+		CreatedAt: model.CreatedAt,
+	}
+}
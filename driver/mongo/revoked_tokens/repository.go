@@ -0,0 +1,94 @@
+package revoked_tokens
+
+import (
+	"context"
+	revokedTokens "crop_connect/business/revoked_tokens"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
+)
+
+type RevokedTokenRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) revokedTokens.Repository {
+	return &RevokedTokenRepository{
+		collection: db.Collection("revokedTokens"),
+	}
+}
+
+/*
+Create
+*/
+
+// Create fails with a duplicate key error when the token is already revoked, which makes revoking a single use token a claim.
+func (rtr *RevokedTokenRepository) Create(ctx context.Context, domain *revokedTokens.Domain) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := rtr.collection.InsertOne(ctx, FromDomain(domain))
+	return err
+}
+
+func (rtr *RevokedTokenRepository) CreateMany(ctx context.Context, domains []revokedTokens.Domain) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	// the same token can be revoked twice, e.g. a logout racing a password change, so the write is an upsert
+	for i := range domains {
+		_, err := rtr.collection.UpdateOne(ctx, bson.M{
+			"_id": domains[i].ID,
+		}, bson.M{
+			"$set": FromDomain(&domains[i]),
+		}, options.Update().SetUpsert(true))
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+/*
+Read
+*/
+
+func (rtr *RevokedTokenRepository) IsRevoked(id string) (bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	total, err := rtr.collection.CountDocuments(ctx, bson.M{
+		"_id": id,
+	})
+	if err != nil {
+		return false, err
+	}
+
+	return total > 0, nil
+}
+
+/*
+Update
+*/
+
+/*
+Delete
+*/
+
+func (rtr *RevokedTokenRepository) DeleteExpired(now primitive.DateTime) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := rtr.collection.DeleteMany(ctx, bson.M{
+		"expiredAt": bson.M{"$lt": now},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
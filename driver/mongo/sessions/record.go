@@ -0,0 +1,55 @@
+package sessions
+
+import (
+	"crop_connect/business/sessions"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID                   primitive.ObjectID `bson:"_id"`
+	UserID               primitive.ObjectID `bson:"userID"`
+	RefreshTokenID       string             `bson:"refreshTokenID"`
+	AccessTokenID        string             `bson:"accessTokenID"`
+	AccessTokenExpiredAt primitive.DateTime `bson:"accessTokenExpiredAt"`
+	ExpiredAt            primitive.DateTime `bson:"expiredAt"`
+	RevokedAt            primitive.DateTime `bson:"revokedAt,omitempty"`
+	CreatedAt            primitive.DateTime `bson:"createdAt"`
+	UpdatedAt            primitive.DateTime `bson:"updatedAt,omitempty"`
+}
+
+func FromDomain(domain *sessions.Domain) *Model {
+	return &Model{
+		ID:                   domain.ID,
+		UserID:               domain.UserID,
+		RefreshTokenID:       domain.RefreshTokenID,
+		AccessTokenID:        domain.AccessTokenID,
+		AccessTokenExpiredAt: domain.AccessTokenExpiredAt,
+		ExpiredAt:            domain.ExpiredAt,
+		RevokedAt:            domain.RevokedAt,
+		CreatedAt:            domain.CreatedAt,
+		UpdatedAt:            domain.UpdatedAt,
+	}
+}
+
+func (model *Model) ToDomain() sessions.Domain {
+	return sessions.Domain{
+		ID:                   model.ID,
+		UserID:               model.UserID,
+		RefreshTokenID:       model.RefreshTokenID,
+		AccessTokenID:        model.AccessTokenID,
+		AccessTokenExpiredAt: model.AccessTokenExpiredAt,
+		ExpiredAt:            model.ExpiredAt,
+		RevokedAt:            model.RevokedAt,
+		CreatedAt:            model.CreatedAt,
+		UpdatedAt:            model.UpdatedAt,
+	}
+}
+
+func ToDomainArray(models []Model) []sessions.Domain {
+	var domains []sessions.Domain
+	for _, model := range models {
+		domains = append(domains, model.ToDomain())
+	}
+	return domains
+}
@@ -0,0 +1,139 @@
+package sessions
+
+import (
+	"context"
+	"crop_connect/business/sessions"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type SessionRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) sessions.Repository {
+	return &SessionRepository{
+		collection: db.Collection("sessions"),
+	}
+}
+
+/*
+Create
+*/
+
+func (sr *SessionRepository) Create(ctx context.Context, domain *sessions.Domain) (sessions.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := sr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return sessions.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (sr *SessionRepository) GetByID(id primitive.ObjectID) (sessions.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := sr.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+	if err != nil {
+		return sessions.Domain{}, err
+	}
+
+	return result.ToDomain(), nil
+}
+
+func (sr *SessionRepository) GetActiveByUserID(userID primitive.ObjectID) ([]sessions.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := sr.collection.Find(ctx, bson.M{
+		"userID":    userID,
+		"revokedAt": bson.M{"$exists": false},
+		"expiredAt": bson.M{"$gt": primitive.NewDateTimeFromTime(time.Now())},
+	})
+	if err != nil {
+		return []sessions.Domain{}, err
+	}
+
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return []sessions.Domain{}, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
+/*
+Update
+*/
+
+func (sr *SessionRepository) Update(ctx context.Context, domain *sessions.Domain) (sessions.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := sr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return sessions.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+// UpdateByRefreshTokenID only rotates a session that is not revoked and still holds refreshTokenID, mongo.ErrNoDocuments means it was rotated or revoked first.
+func (sr *SessionRepository) UpdateByRefreshTokenID(ctx context.Context, domain *sessions.Domain, refreshTokenID string) (sessions.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	result, err := sr.collection.UpdateOne(ctx, bson.M{
+		"_id":            domain.ID,
+		"refreshTokenID": refreshTokenID,
+		"revokedAt":      bson.M{"$exists": false},
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return sessions.Domain{}, err
+	}
+
+	if result.MatchedCount == 0 {
+		return sessions.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return *domain, nil
+}
+
+/*
+Delete
+*/
+
+func (sr *SessionRepository) DeleteExpired(now primitive.DateTime) (int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := sr.collection.DeleteMany(ctx, bson.M{
+		"expiredAt": bson.M{"$lt": now},
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	return int(result.DeletedCount), nil
+}
@@ -0,0 +1,76 @@
+package shipments
+
+import (
+	"crop_connect/business/shipments"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID              primitive.ObjectID `bson:"_id"`
+	TransactionID   primitive.ObjectID `bson:"transactionID"`
+	BatchID         primitive.ObjectID `bson:"batchID"`
+	HarvestID       primitive.ObjectID `bson:"harvestID"`
+	FarmerID        primitive.ObjectID `bson:"farmerID"`
+	BuyerID         primitive.ObjectID `bson:"buyerID"`
+	Status          string             `bson:"status"`
+	PickupDate      primitive.DateTime `bson:"pickupDate,omitempty"`
+	Courier         string             `bson:"courier,omitempty"`
+	TrackingNumber  string             `bson:"trackingNumber,omitempty"`
+	DeliveredWeight float64            `bson:"deliveredWeight,omitempty"`
+	DispatchedAt    primitive.DateTime `bson:"dispatchedAt,omitempty"`
+	ReceivedAt      primitive.DateTime `bson:"receivedAt,omitempty"`
+	ReceiptNote     string             `bson:"receiptNote,omitempty"`
+	CreatedAt       primitive.DateTime `bson:"createdAt"`
+	UpdatedAt       primitive.DateTime `bson:"updatedAt,omitempty"`
+}
+
+func FromDomain(domain *shipments.Domain) *Model {
+	return &Model{
+		ID:              domain.ID,
+		TransactionID:   domain.TransactionID,
+		BatchID:         domain.BatchID,
+		HarvestID:       domain.HarvestID,
+		FarmerID:        domain.FarmerID,
+		BuyerID:         domain.BuyerID,
+		Status:          domain.Status,
+		PickupDate:      domain.PickupDate,
+		Courier:         domain.Courier,
+		TrackingNumber:  domain.TrackingNumber,
+		DeliveredWeight: domain.DeliveredWeight,
+		DispatchedAt:    domain.DispatchedAt,
+		ReceivedAt:      domain.ReceivedAt,
+		ReceiptNote:     domain.ReceiptNote,
+		CreatedAt:       domain.CreatedAt,
+		UpdatedAt:       domain.UpdatedAt,
+	}
+}
+
+func (model *Model) ToDomain() shipments.Domain {
+	return shipments.Domain{
+		ID:              model.ID,
+		TransactionID:   model.TransactionID,
+		BatchID:         model.BatchID,
+		HarvestID:       model.HarvestID,
+		FarmerID:        model.FarmerID,
+		BuyerID:         model.BuyerID,
+		Status:          model.Status,
+		PickupDate:      model.PickupDate,
+		Courier:         model.Courier,
+		TrackingNumber:  model.TrackingNumber,
+		DeliveredWeight: model.DeliveredWeight,
+		DispatchedAt:    model.DispatchedAt,
+		ReceivedAt:      model.ReceivedAt,
+		ReceiptNote:     model.ReceiptNote,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
+	}
+}
+
+func ToDomainArray(model []Model) []shipments.Domain {
+	var domain []shipments.Domain
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
@@ -0,0 +1,170 @@
+package shipments
+
+import (
+	"context"
+	"crop_connect/business/shipments"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type ShipmentRepository struct {
+	collection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) shipments.Repository {
+	return &ShipmentRepository{
+		collection: db.Collection("shipments"),
+	}
+}
+
+/*
+Create
+*/
+
+func (sr *ShipmentRepository) Create(ctx context.Context, domain *shipments.Domain) (shipments.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := sr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return shipments.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+/*
+Read
+*/
+
+func (sr *ShipmentRepository) GetByID(id primitive.ObjectID) (shipments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := sr.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (sr *ShipmentRepository) GetByTransactionID(transactionID primitive.ObjectID) (shipments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := sr.collection.FindOne(ctx, bson.M{
+		"transactionID": transactionID,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (sr *ShipmentRepository) GetByQuery(query shipments.Query) ([]shipments.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.FarmerID != primitive.NilObjectID {
+		filter["farmerID"] = query.FarmerID
+	}
+
+	if query.BuyerID != primitive.NilObjectID {
+		filter["buyerID"] = query.BuyerID
+	}
+
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{query.Sort: query.Order},
+	}, bson.M{
+		"$skip": query.Skip,
+	}, bson.M{
+		"$limit": query.Limit,
+	})
+
+	cursor, err := sr.collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := sr.collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []Model
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return ToDomainArray(result), countResult.Total, nil
+}
+
+/*
+Update
+*/
+
+func (sr *ShipmentRepository) Update(domain *shipments.Domain) (shipments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := sr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return shipments.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+// UpdateByStatus only saves the shipment while it still has status, mongo.ErrNoDocuments means another request moved it first.
+func (sr *ShipmentRepository) UpdateByStatus(domain *shipments.Domain, status string) (shipments.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := sr.collection.UpdateOne(ctx, bson.M{
+		"_id":    domain.ID,
+		"status": status,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return shipments.Domain{}, err
+	}
+
+	if result.MatchedCount == 0 {
+		return shipments.Domain{}, mongo.ErrNoDocuments
+	}
+
+	return *domain, nil
+}
+
+/*
+Delete
+*/
@@ -15,12 +15,32 @@ type Model struct {
 	BatchID         primitive.ObjectID `bson:"batchID,omitempty"`
 	Address         string             `bson:"address"`
 	Status          string             `bson:"status"`
+	Quantity        float64            `bson:"quantity"`
+	PricePerKg      float64            `bson:"pricePerKg"`
 	TotalPrice      float64            `bson:"totalPrice"`
+	ActualQuantity  float64            `bson:"actualQuantity,omitempty"`
+	FinalPrice      float64            `bson:"finalPrice,omitempty"`
+	PriceDifference float64            `bson:"priceDifference,omitempty"`
+	SettledAt       primitive.DateTime `bson:"settledAt,omitempty"`
+	Offers          []OfferModel       `bson:"offers,omitempty"`
 	CreatedAt       primitive.DateTime `bson:"createdAt"`
 	UpdatedAt       primitive.DateTime `bson:"updatedAt,omitempty"`
+	Version         int                `bson:"version"`
+}
+
+type OfferModel struct {
+	AuthorID   primitive.ObjectID `bson:"authorID"`
+	Role       string             `bson:"role"`
+	PricePerKg float64            `bson:"pricePerKg"`
+	CreatedAt  primitive.DateTime `bson:"createdAt"`
 }
 
 func FromDomain(domain *transactions.Domain) *Model {
+	var offers []OfferModel
+	for _, offer := range domain.Offers {
+		offers = append(offers, OfferModel(offer))
+	}
+
 	return &Model{
 		ID:              domain.ID,
 		BuyerID:         domain.BuyerID,
@@ -30,13 +50,26 @@ func FromDomain(domain *transactions.Domain) *Model {
 		RegionID:        domain.RegionID,
 		Address:         domain.Address,
 		Status:          domain.Status,
+		Quantity:        domain.Quantity,
+		PricePerKg:      domain.PricePerKg,
 		TotalPrice:      domain.TotalPrice,
+		ActualQuantity:  domain.ActualQuantity,
+		FinalPrice:      domain.FinalPrice,
+		PriceDifference: domain.PriceDifference,
+		SettledAt:       domain.SettledAt,
+		Offers:          offers,
 		CreatedAt:       domain.CreatedAt,
 		UpdatedAt:       domain.UpdatedAt,
+		Version:         domain.Version,
 	}
 }
 
 func (model *Model) ToDomain() transactions.Domain {
+	var offers []transactions.Offer
+	for _, offer := range model.Offers {
+		offers = append(offers, transactions.Offer(offer))
+	}
+
 	return transactions.Domain{
 		ID:              model.ID,
 		BuyerID:         model.BuyerID,
@@ -46,9 +79,17 @@ func (model *Model) ToDomain() transactions.Domain {
 		RegionID:        model.RegionID,
 		Address:         model.Address,
 		Status:          model.Status,
+		Quantity:        model.Quantity,
+		PricePerKg:      model.PricePerKg,
 		TotalPrice:      model.TotalPrice,
+		ActualQuantity:  model.ActualQuantity,
+		FinalPrice:      model.FinalPrice,
+		PriceDifference: model.PriceDifference,
+		SettledAt:       model.SettledAt,
+		Offers:          offers,
 		CreatedAt:       model.CreatedAt,
 		UpdatedAt:       model.UpdatedAt,
+		Version:         model.Version,
 	}
 }
 
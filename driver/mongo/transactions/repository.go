@@ -4,7 +4,9 @@ import (
 	"context"
 	"crop_connect/business/transactions"
 	"crop_connect/constant"
+	mongoDriver "crop_connect/driver/mongo"
 	"crop_connect/dto"
+	"crop_connect/helper"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
@@ -58,14 +60,24 @@ var (
 			"as":           "harvest_info",
 		},
 	}
+
+	// a paid transaction is still an accepted deal in the statistics
+	acceptedStatuses = bson.A{constant.TransactionStatusAccepted, constant.TransactionStatusPaid, constant.TransactionStatusPartiallyRefunded}
 )
 
+// exceedQuantity matches transactions asking for more than the remaining quantity, transactions made before quantities were tracked have no quantity and always match.
+func exceedQuantity(remainingQuantity float64) bson.M {
+	return bson.M{
+		"$not": bson.M{"$lte": remainingQuantity},
+	}
+}
+
 /*
 Create
 */
 
-func (tr *TransactionRepository) Create(domain *transactions.Domain) (transactions.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+func (tr *TransactionRepository) Create(ctx context.Context, domain *transactions.Domain) (transactions.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
 	_, err := tr.collection.InsertOne(ctx, FromDomain(domain))
@@ -350,8 +362,8 @@ func (tr *TransactionRepository) StatisticByYear(farmerID primitive.ObjectID, ye
 						"$sum": bson.M{
 							"$cond": bson.A{
 								bson.M{
-									"$eq": bson.A{
-										"$status", constant.TransactionStatusAccepted,
+									"$in": bson.A{
+										"$status", acceptedStatuses,
 									},
 								}, 1, 0,
 							},
@@ -363,7 +375,7 @@ func (tr *TransactionRepository) StatisticByYear(farmerID primitive.ObjectID, ye
 					"totalIncome": bson.M{
 						"$sum": bson.M{
 							"$cond": bson.A{
-								bson.M{"$eq": bson.A{"$status", constant.TransactionStatusAccepted}},
+								bson.M{"$in": bson.A{"$status", acceptedStatuses}},
 								"$totalPrice", 0},
 						},
 					},
@@ -443,7 +455,7 @@ func (tr *TransactionRepository) StatisticTopProvince(year int, limit int) ([]tr
 				"totalAccepted": bson.M{
 					"$sum": bson.M{
 						"$cond": bson.A{
-							bson.M{"$eq": bson.A{"$status", constant.TransactionStatusAccepted}},
+							bson.M{"$in": bson.A{"$status", acceptedStatuses}},
 							1, 0},
 					},
 				},
@@ -553,7 +565,7 @@ func (tr *TransactionRepository) CountByCommodityCode(Code primitive.ObjectID) (
 	pipeline := []interface{}{
 		bson.M{
 			"$match": bson.M{
-				"status": constant.TransactionStatusAccepted,
+				"status": bson.M{"$in": acceptedStatuses},
 			},
 		}, lookupProposal, lookupCommodity,
 		bson.M{
@@ -616,59 +628,144 @@ func (tr *TransactionRepository) GetByBuyerIDBatchIDAndStatus(buyerID primitive.
 	return transaction.ToDomain(), nil
 }
 
+func (tr *TransactionRepository) GetAcceptedByBatchID(batchID primitive.ObjectID) ([]transactions.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := tr.collection.Find(ctx, bson.M{
+		"batchID": batchID,
+		"status":  bson.M{"$in": acceptedStatuses},
+	})
+	if err != nil {
+		return []transactions.Domain{}, err
+	}
+
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return []transactions.Domain{}, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
+func (tr *TransactionRepository) GetPendingCreatedBefore(date primitive.DateTime) ([]transactions.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := tr.collection.Find(ctx, bson.M{
+		"status":    constant.TransactionStatusPending,
+		"createdAt": bson.M{"$lt": date},
+	})
+	if err != nil {
+		return []transactions.Domain{}, err
+	}
+
+	err = cursor.All(ctx, &result)
+	if err != nil {
+		return []transactions.Domain{}, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
 /*
 Update
 */
 
-func (tr *TransactionRepository) Update(domain *transactions.Domain) (transactions.Domain, error) {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+func (tr *TransactionRepository) Update(ctx context.Context, domain *transactions.Domain) (transactions.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
-	_, err := tr.collection.UpdateOne(ctx, bson.M{
-		"_id": domain.ID,
+	model := FromDomain(domain)
+	model.Version = domain.Version + 1
+
+	result, err := tr.collection.UpdateOne(ctx, bson.M{
+		"_id":     domain.ID,
+		"version": mongoDriver.VersionFilter(domain.Version),
 	}, bson.M{
-		"$set": FromDomain(domain),
+		"$set": model,
 	})
 
 	if err != nil {
 		return transactions.Domain{}, err
 	}
 
+	if result.MatchedCount == 0 {
+		return transactions.Domain{}, helper.NewConflictError("transaksi", domain.ID)
+	}
+
+	domain.Version = model.Version
 	return *domain, nil
 }
 
-func (tr *TransactionRepository) RejectPendingByProposalID(proposalID primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+// rejectPending rejects the pending transactions matching filter and returns them, so the caller can record each transition and tell their buyers.
+func (tr *TransactionRepository) rejectPending(ctx context.Context, filter bson.M) ([]transactions.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
 	defer cancel()
 
-	_, err := tr.collection.UpdateMany(ctx, bson.M{
-		"proposalID": proposalID,
-		"status":     constant.TransactionStatusPending,
+	filter["status"] = constant.TransactionStatusPending
+
+	var result []Model
+	cursor, err := tr.collection.Find(ctx, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, model := range result {
+		ids = append(ids, model.ID)
+	}
+
+	updatedAt := primitive.NewDateTimeFromTime(time.Now())
+	_, err = tr.collection.UpdateMany(ctx, bson.M{
+		"_id":    bson.M{"$in": ids},
+		"status": constant.TransactionStatusPending,
 	}, bson.M{
 		"$set": bson.M{
 			"status":    constant.TransactionStatusRejected,
-			"updatedAt": primitive.NewDateTimeFromTime(time.Now()),
+			"updatedAt": updatedAt,
+		},
+		"$inc": bson.M{
+			"version": 1,
 		},
 	})
+	if err != nil {
+		return nil, err
+	}
 
-	return err
-}
+	rejected := []transactions.Domain{}
+	for _, model := range result {
+		model.Status = constant.TransactionStatusRejected
+		model.UpdatedAt = updatedAt
+		model.Version++
+		rejected = append(rejected, model.ToDomain())
+	}
 
-func (tr *TransactionRepository) RejectPendingByBatchID(batchID primitive.ObjectID) error {
-	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
-	defer cancel()
+	return rejected, nil
+}
 
-	_, err := tr.collection.UpdateMany(ctx, bson.M{
-		"batchID": batchID,
-		"status":  constant.TransactionStatusPending,
-	}, bson.M{
-		"$set": bson.M{
-			"status":    constant.TransactionStatusRejected,
-			"updatedAt": primitive.NewDateTimeFromTime(time.Now()),
-		},
+func (tr *TransactionRepository) RejectPendingByProposalID(ctx context.Context, proposalID primitive.ObjectID, remainingQuantity float64) ([]transactions.Domain, error) {
+	return tr.rejectPending(ctx, bson.M{
+		"proposalID": proposalID,
+		"quantity":   exceedQuantity(remainingQuantity),
 	})
+}
 
-	return err
+func (tr *TransactionRepository) RejectPendingByBatchID(ctx context.Context, batchID primitive.ObjectID, remainingQuantity float64) ([]transactions.Domain, error) {
+	return tr.rejectPending(ctx, bson.M{
+		"batchID":  batchID,
+		"quantity": exceedQuantity(remainingQuantity),
+	})
 }
 
 /*
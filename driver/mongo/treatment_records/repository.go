@@ -3,6 +3,7 @@ package treatment_records
 import (
 	"context"
 	treatmentRecord "crop_connect/business/treatment_records"
+	"crop_connect/constant"
 	"crop_connect/dto"
 	"time"
 
@@ -23,6 +24,8 @@ func NewRepository(db *mongo.Database) treatmentRecord.Repository {
 }
 
 var (
+	openStatuses = bson.A{constant.TreatmentRecordStatusWaitingResponse, constant.TreatmentRecordStatusPending, constant.TreatmentRecordStatusRevision}
+
 	lookupBatch = bson.M{
 		"$lookup": bson.M{
 			"from":         "batchs",
@@ -409,6 +412,48 @@ func (trr *TreatmentRecordRepository) Update(domain *treatmentRecord.Domain) (tr
 	return *domain, nil
 }
 
+func (trr *TreatmentRecordRepository) CloseOpenByBatchID(ctx context.Context, batchID primitive.ObjectID) ([]treatmentRecord.Domain, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := trr.collection.Find(ctx, bson.M{
+		"batchID": batchID,
+		"status":  bson.M{"$in": openStatuses},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	if len(result) == 0 {
+		return nil, nil
+	}
+
+	ids := []primitive.ObjectID{}
+	for _, model := range result {
+		ids = append(ids, model.ID)
+	}
+
+	_, err = trr.collection.UpdateMany(ctx, bson.M{
+		"_id":    bson.M{"$in": ids},
+		"status": bson.M{"$in": openStatuses},
+	}, bson.M{
+		"$set": bson.M{
+			"status":    constant.TreatmentRecordStatusClosed,
+			"updatedAt": primitive.NewDateTimeFromTime(time.Now()),
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
 /*
 Delete
 */
@@ -0,0 +1,37 @@
+package unit_of_work
+
+import (
+	"context"
+	unitOfWork "crop_connect/business/unit_of_work"
+	"time"
+
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type UnitOfWork struct {
+	client *mongo.Client
+}
+
+func NewUnitOfWork(db *mongo.Database) unitOfWork.UnitOfWork {
+	return &UnitOfWork{
+		client: db.Client(),
+	}
+}
+
+// Execute needs a replica set, WithTransaction retries fn on a TransientTransactionError and the commit on an UnknownTransactionCommitResult.
+func (uow *UnitOfWork) Execute(fn func(ctx context.Context) error) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 60*time.Second)
+	defer cancel()
+
+	session, err := uow.client.StartSession()
+	if err != nil {
+		return err
+	}
+	defer session.EndSession(ctx)
+
+	_, err = session.WithTransaction(ctx, func(sessionContext mongo.SessionContext) (interface{}, error) {
+		return nil, fn(sessionContext)
+	})
+
+	return err
+}
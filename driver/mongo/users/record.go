@@ -2,50 +2,120 @@ package users
 
 import (
 	"crop_connect/business/users"
+	"crop_connect/constant"
 
 	"go.mongodb.org/mongo-driver/bson/primitive"
 )
 
+type AreaModel struct {
+	Province string `bson:"province"`
+	Regency  string `bson:"regency"`
+}
+
+type TwoFactorModel struct {
+	Secret        string             `bson:"secret"`
+	RecoveryCodes []string           `bson:"recoveryCodes"`
+	LastStep      int64              `bson:"lastStep"`
+	Failures      int                `bson:"failures"`
+	LockedUntil   primitive.DateTime `bson:"lockedUntil,omitempty"`
+	EnabledAt     primitive.DateTime `bson:"enabledAt,omitempty"`
+}
+
 type Model struct {
-	ID          primitive.ObjectID `bson:"_id"`
-	RegionID    primitive.ObjectID `bson:"regionID"`
-	Name        string             `bson:"name"`
-	Email       string             `bson:"email"`
-	Description string             `bson:"description"`
-	PhoneNumber string             `bson:"phoneNumber"`
-	Password    string             `bson:"password"`
-	Role        string             `bson:"role"`
-	CreatedAt   primitive.DateTime `bson:"createdAt"`
-	UpdatedAt   primitive.DateTime `bson:"updatedAt,omitempty"`
+	ID              primitive.ObjectID `bson:"_id"`
+	RegionID        primitive.ObjectID `bson:"regionID"`
+	Name            string             `bson:"name"`
+	Email           string             `bson:"email"`
+	Description     string             `bson:"description"`
+	PhoneNumber     string             `bson:"phoneNumber"`
+	Password        string             `bson:"password"`
+	Role            string             `bson:"role"`
+	Areas           []AreaModel        `bson:"areas"`
+	Status          string             `bson:"status"`
+	SuspendReason   string             `bson:"suspendReason"`
+	RatingAverage   float64            `bson:"ratingAverage,omitempty"`
+	RatingCount     int                `bson:"ratingCount,omitempty"`
+	EmailVerifiedAt primitive.DateTime `bson:"emailVerifiedAt,omitempty"`
+	SuspendedAt     primitive.DateTime `bson:"suspendedAt"`
+	TwoFactor       TwoFactorModel     `bson:"twoFactor"`
+	DeletedAt       primitive.DateTime `bson:"deletedAt,omitempty"`
+	CreatedAt       primitive.DateTime `bson:"createdAt"`
+	UpdatedAt       primitive.DateTime `bson:"updatedAt,omitempty"`
+}
+
+func fromAreaDomain(areas []users.Area) []AreaModel {
+	var result []AreaModel
+	for _, area := range areas {
+		result = append(result, AreaModel{
+			Province: area.Province,
+			Regency:  area.Regency,
+		})
+	}
+	return result
+}
+
+func toAreaDomain(models []AreaModel) []users.Area {
+	var result []users.Area
+	for _, model := range models {
+		result = append(result, users.Area{
+			Province: model.Province,
+			Regency:  model.Regency,
+		})
+	}
+	return result
 }
 
 func FromDomain(domain *users.Domain) *Model {
 	return &Model{
-		ID:          domain.ID,
-		RegionID:    domain.RegionID,
-		Name:        domain.Name,
-		Email:       domain.Email,
-		Description: domain.Description,
-		PhoneNumber: domain.PhoneNumber,
-		Password:    domain.Password,
-		Role:        domain.Role,
-		CreatedAt:   domain.CreatedAt,
-		UpdatedAt:   domain.UpdatedAt,
+		ID:              domain.ID,
+		RegionID:        domain.RegionID,
+		Name:            domain.Name,
+		Email:           domain.Email,
+		Description:     domain.Description,
+		PhoneNumber:     domain.PhoneNumber,
+		Password:        domain.Password,
+		Role:            domain.Role,
+		Areas:           fromAreaDomain(domain.Areas),
+		Status:          domain.Status,
+		SuspendReason:   domain.SuspendReason,
+		RatingAverage:   domain.RatingAverage,
+		RatingCount:     domain.RatingCount,
+		EmailVerifiedAt: domain.EmailVerifiedAt,
+		SuspendedAt:     domain.SuspendedAt,
+		TwoFactor:       TwoFactorModel(domain.TwoFactor),
+		DeletedAt:       domain.DeletedAt,
+		CreatedAt:       domain.CreatedAt,
+		UpdatedAt:       domain.UpdatedAt,
 	}
 }
 
+// ToDomain treats users created before account statuses existed as active.
 func (model *Model) ToDomain() users.Domain {
+	status := model.Status
+	if status == "" {
+		status = constant.UserStatusActive
+	}
+
 	return users.Domain{
-		ID:          model.ID,
-		RegionID:    model.RegionID,
-		Name:        model.Name,
-		Email:       model.Email,
-		Description: model.Description,
-		PhoneNumber: model.PhoneNumber,
-		Password:    model.Password,
-		Role:        model.Role,
-		CreatedAt:   model.CreatedAt,
-		UpdatedAt:   model.UpdatedAt,
+		ID:              model.ID,
+		RegionID:        model.RegionID,
+		Name:            model.Name,
+		Email:           model.Email,
+		Description:     model.Description,
+		PhoneNumber:     model.PhoneNumber,
+		Password:        model.Password,
+		Role:            model.Role,
+		Areas:           toAreaDomain(model.Areas),
+		Status:          status,
+		SuspendReason:   model.SuspendReason,
+		RatingAverage:   model.RatingAverage,
+		RatingCount:     model.RatingCount,
+		EmailVerifiedAt: model.EmailVerifiedAt,
+		SuspendedAt:     model.SuspendedAt,
+		TwoFactor:       users.TwoFactor(model.TwoFactor),
+		DeletedAt:       model.DeletedAt,
+		CreatedAt:       model.CreatedAt,
+		UpdatedAt:       model.UpdatedAt,
 	}
 }
 
@@ -3,12 +3,15 @@ package users
 import (
 	"context"
 	"crop_connect/business/users"
+	"crop_connect/constant"
+	mongoDriver "crop_connect/driver/mongo"
 	"crop_connect/dto"
 	"time"
 
 	"go.mongodb.org/mongo-driver/bson"
 	"go.mongodb.org/mongo-driver/bson/primitive"
 	"go.mongodb.org/mongo-driver/mongo"
+	"go.mongodb.org/mongo-driver/mongo/options"
 )
 
 type UserRepository struct {
@@ -235,6 +238,34 @@ func (ur *UserRepository) GetFarmerByID(id primitive.ObjectID) (users.Domain, er
 	return result.ToDomain(), err
 }
 
+func (ur *UserRepository) GetActiveValidatorsByArea(province string, regency string) ([]users.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result []Model
+	cursor, err := ur.collection.Find(ctx, bson.M{
+		"role": constant.RoleValidator,
+		"status": bson.M{
+			"$nin": []string{constant.UserStatusUnverified, constant.UserStatusSuspended, constant.UserStatusDeleted},
+		},
+		"areas": bson.M{
+			"$elemMatch": bson.M{
+				"province": province,
+				"regency":  bson.M{"$in": []string{regency, ""}},
+			},
+		},
+	}, options.Find().SetSort(bson.M{"createdAt": 1}))
+	if err != nil {
+		return []users.Domain{}, err
+	}
+
+	if err = cursor.All(ctx, &result); err != nil {
+		return []users.Domain{}, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
 func (ur *UserRepository) StatisticNewUserByYear(year int) ([]dto.StatisticByYear, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
 	defer cancel()
@@ -331,6 +362,77 @@ func (ur *UserRepository) Update(domain *users.Domain) (users.Domain, error) {
 	return *domain, nil
 }
 
+// UpdateTwoFactorStep only saves a step later than the last accepted one, mongo.ErrNoDocuments means the code was already used.
+func (ur *UserRepository) UpdateTwoFactorStep(id primitive.ObjectID, step int64) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := ur.collection.UpdateOne(ctx, bson.M{
+		"_id":                id,
+		"twoFactor.lastStep": bson.M{"$lt": step},
+	}, bson.M{
+		"$set":   bson.M{"twoFactor.lastStep": step, "twoFactor.failures": 0},
+		"$unset": bson.M{"twoFactor.lockedUntil": ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+// RemoveRecoveryCode spends a recovery code, mongo.ErrNoDocuments means the account does not have it (anymore).
+func (ur *UserRepository) RemoveRecoveryCode(id primitive.ObjectID, hash string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	result, err := ur.collection.UpdateOne(ctx, bson.M{
+		"_id":                     id,
+		"twoFactor.recoveryCodes": hash,
+	}, bson.M{
+		"$pull":  bson.M{"twoFactor.recoveryCodes": hash},
+		"$set":   bson.M{"twoFactor.failures": 0},
+		"$unset": bson.M{"twoFactor.lockedUntil": ""},
+	})
+	if err != nil {
+		return err
+	}
+
+	if result.MatchedCount == 0 {
+		return mongo.ErrNoDocuments
+	}
+
+	return nil
+}
+
+func (ur *UserRepository) UpdateTwoFactorFailures(id primitive.ObjectID, failures int, lockedUntil primitive.DateTime) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := ur.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, bson.M{
+		"$set": bson.M{"twoFactor.failures": failures, "twoFactor.lockedUntil": lockedUntil},
+	})
+
+	return err
+}
+
+func (ur *UserRepository) AddRating(ctx context.Context, id primitive.ObjectID, score int) error {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := ur.collection.UpdateOne(ctx, bson.M{
+		"_id": id,
+	}, mongoDriver.AddRatingPipeline(score))
+
+	return err
+}
+
 /*
 Delete
 */
@@ -0,0 +1,112 @@
+package webhooks
+
+import (
+	"crop_connect/business/webhooks"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+type Model struct {
+	ID          primitive.ObjectID `bson:"_id"`
+	URL         string             `bson:"url"`
+	Secret      string             `bson:"secret"`
+	Events      []string           `bson:"events"`
+	Description string             `bson:"description,omitempty"`
+	IsActive    bool               `bson:"isActive"`
+	CreatedAt   primitive.DateTime `bson:"createdAt"`
+	UpdatedAt   primitive.DateTime `bson:"updatedAt,omitempty"`
+}
+
+type DeliveryModel struct {
+	ID             primitive.ObjectID `bson:"_id"`
+	WebhookID      primitive.ObjectID `bson:"webhookID"`
+	EventID        primitive.ObjectID `bson:"eventID"`
+	Event          string             `bson:"event"`
+	Payload        string             `bson:"payload"`
+	Status         string             `bson:"status"`
+	Attempts       int                `bson:"attempts"`
+	ResponseStatus int                `bson:"responseStatus,omitempty"`
+	LastError      string             `bson:"lastError,omitempty"`
+	ReplayOf       primitive.ObjectID `bson:"replayOf,omitempty"`
+	CreatedAt      primitive.DateTime `bson:"createdAt"`
+	UpdatedAt      primitive.DateTime `bson:"updatedAt,omitempty"`
+	DeliveredAt    primitive.DateTime `bson:"deliveredAt,omitempty"`
+}
+
+func FromDomain(domain *webhooks.Domain) *Model {
+	return &Model{
+		ID:          domain.ID,
+		URL:         domain.URL,
+		Secret:      domain.Secret,
+		Events:      domain.Events,
+		Description: domain.Description,
+		IsActive:    domain.IsActive,
+		CreatedAt:   domain.CreatedAt,
+		UpdatedAt:   domain.UpdatedAt,
+	}
+}
+
+func (model *Model) ToDomain() webhooks.Domain {
+	return webhooks.Domain{
+		ID:          model.ID,
+		URL:         model.URL,
+		Secret:      model.Secret,
+		Events:      model.Events,
+		Description: model.Description,
+		IsActive:    model.IsActive,
+		CreatedAt:   model.CreatedAt,
+		UpdatedAt:   model.UpdatedAt,
+	}
+}
+
+func ToDomainArray(model []Model) []webhooks.Domain {
+	var domain []webhooks.Domain
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
+
+func FromDeliveryDomain(domain *webhooks.Delivery) *DeliveryModel {
+	return &DeliveryModel{
+		ID:             domain.ID,
+		WebhookID:      domain.WebhookID,
+		EventID:        domain.EventID,
+		Event:          domain.Event,
+		Payload:        domain.Payload,
+		Status:         domain.Status,
+		Attempts:       domain.Attempts,
+		ResponseStatus: domain.ResponseStatus,
+		LastError:      domain.LastError,
+		ReplayOf:       domain.ReplayOf,
+		CreatedAt:      domain.CreatedAt,
+		UpdatedAt:      domain.UpdatedAt,
+		DeliveredAt:    domain.DeliveredAt,
+	}
+}
+
+func (model *DeliveryModel) ToDomain() webhooks.Delivery {
+	return webhooks.Delivery{
+		ID:             model.ID,
+		WebhookID:      model.WebhookID,
+		EventID:        model.EventID,
+		Event:          model.Event,
+		Payload:        model.Payload,
+		Status:         model.Status,
+		Attempts:       model.Attempts,
+		ResponseStatus: model.ResponseStatus,
+		LastError:      model.LastError,
+		ReplayOf:       model.ReplayOf,
+		CreatedAt:      model.CreatedAt,
+		UpdatedAt:      model.UpdatedAt,
+		DeliveredAt:    model.DeliveredAt,
+	}
+}
+
+func ToDeliveryDomainArray(model []DeliveryModel) []webhooks.Delivery {
+	var domain []webhooks.Delivery
+	for _, v := range model {
+		domain = append(domain, v.ToDomain())
+	}
+	return domain
+}
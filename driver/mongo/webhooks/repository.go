@@ -0,0 +1,241 @@
+package webhooks
+
+import (
+	"context"
+	"crop_connect/business/webhooks"
+	"crop_connect/dto"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson"
+	"go.mongodb.org/mongo-driver/bson/primitive"
+	"go.mongodb.org/mongo-driver/mongo"
+)
+
+type WebhookRepository struct {
+	collection         *mongo.Collection
+	deliveryCollection *mongo.Collection
+}
+
+func NewRepository(db *mongo.Database) webhooks.Repository {
+	return &WebhookRepository{
+		collection:         db.Collection("webhooks"),
+		deliveryCollection: db.Collection("webhook_deliveries"),
+	}
+}
+
+/*
+Util
+*/
+
+func aggregate[T any](ctx context.Context, collection *mongo.Collection, filter bson.M, sort string, order int, skip int64, limit int64) ([]T, int, error) {
+	pipeline := []interface{}{
+		bson.M{"$match": filter},
+	}
+
+	pipelineForCount := append(pipeline, bson.M{"$count": "total"})
+	pipeline = append(pipeline, bson.M{
+		"$sort": bson.M{sort: order},
+	}, bson.M{
+		"$skip": skip,
+	}, bson.M{
+		"$limit": limit,
+	})
+
+	cursor, err := collection.Aggregate(ctx, pipeline)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	cursorCount, err := collection.Aggregate(ctx, pipelineForCount)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var result []T
+	countResult := dto.TotalDocument{}
+
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, 0, err
+	}
+
+	for cursorCount.Next(ctx) {
+		err := cursorCount.Decode(&countResult)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+
+	return result, countResult.Total, nil
+}
+
+/*
+Create
+*/
+
+func (wr *WebhookRepository) Create(domain *webhooks.Domain) (webhooks.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := wr.collection.InsertOne(ctx, FromDomain(domain))
+	if err != nil {
+		return webhooks.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (wr *WebhookRepository) CreateDelivery(ctx context.Context, delivery *webhooks.Delivery) (webhooks.Delivery, error) {
+	ctx, cancel := context.WithTimeout(ctx, 20*time.Second)
+	defer cancel()
+
+	_, err := wr.deliveryCollection.InsertOne(ctx, FromDeliveryDomain(delivery))
+	if err != nil {
+		return webhooks.Delivery{}, err
+	}
+
+	return *delivery, nil
+}
+
+/*
+Read
+*/
+
+func (wr *WebhookRepository) GetByID(id primitive.ObjectID) (webhooks.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result Model
+	err := wr.collection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (wr *WebhookRepository) GetActiveByEvent(event string) ([]webhooks.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	cursor, err := wr.collection.Find(ctx, bson.M{
+		"isActive": true,
+		"events":   event,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var result []Model
+	if err := cursor.All(ctx, &result); err != nil {
+		return nil, err
+	}
+
+	return ToDomainArray(result), nil
+}
+
+func (wr *WebhookRepository) GetByQuery(query webhooks.Query) ([]webhooks.Domain, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.Event != "" {
+		filter["events"] = query.Event
+	}
+
+	if query.IsActive != nil {
+		filter["isActive"] = *query.IsActive
+	}
+
+	result, total, err := aggregate[Model](ctx, wr.collection, filter, query.Sort, query.Order, query.Skip, query.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ToDomainArray(result), total, nil
+}
+
+func (wr *WebhookRepository) GetDeliveryByID(id primitive.ObjectID) (webhooks.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	var result DeliveryModel
+	err := wr.deliveryCollection.FindOne(ctx, bson.M{
+		"_id": id,
+	}).Decode(&result)
+
+	return result.ToDomain(), err
+}
+
+func (wr *WebhookRepository) GetDeliveriesByQuery(query webhooks.DeliveryQuery) ([]webhooks.Delivery, int, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	filter := bson.M{}
+
+	if query.WebhookID != primitive.NilObjectID {
+		filter["webhookID"] = query.WebhookID
+	}
+
+	if query.Event != "" {
+		filter["event"] = query.Event
+	}
+
+	if query.Status != "" {
+		filter["status"] = query.Status
+	}
+
+	result, total, err := aggregate[DeliveryModel](ctx, wr.deliveryCollection, filter, query.Sort, query.Order, query.Skip, query.Limit)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return ToDeliveryDomainArray(result), total, nil
+}
+
+/*
+Update
+*/
+
+func (wr *WebhookRepository) Update(domain *webhooks.Domain) (webhooks.Domain, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := wr.collection.UpdateOne(ctx, bson.M{
+		"_id": domain.ID,
+	}, bson.M{
+		"$set": FromDomain(domain),
+	})
+	if err != nil {
+		return webhooks.Domain{}, err
+	}
+
+	return *domain, nil
+}
+
+func (wr *WebhookRepository) UpdateDelivery(delivery *webhooks.Delivery) (webhooks.Delivery, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := wr.deliveryCollection.UpdateOne(ctx, bson.M{
+		"_id": delivery.ID,
+	}, bson.M{
+		"$set": FromDeliveryDomain(delivery),
+	})
+	if err != nil {
+		return webhooks.Delivery{}, err
+	}
+
+	return *delivery, nil
+}
+
+/*
+Delete
+*/
+
+func (wr *WebhookRepository) Delete(id primitive.ObjectID) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Second)
+	defer cancel()
+
+	_, err := wr.collection.DeleteOne(ctx, bson.M{"_id": id})
+	return err
+}
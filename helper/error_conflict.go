@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"errors"
+	"fmt"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// ConflictError is returned by a repository when the document was changed by another request after it was read.
+type ConflictError struct {
+	Collection string
+	ID         primitive.ObjectID
+}
+
+func NewConflictError(collection string, id primitive.ObjectID) error {
+	return &ConflictError{
+		Collection: collection,
+		ID:         id,
+	}
+}
+
+func (err *ConflictError) Error() string {
+	return fmt.Sprintf("%s dengan id %s telah diubah oleh proses lain", err.Collection, err.ID.Hex())
+}
+
+func IsConflictError(err error) bool {
+	var conflictError *ConflictError
+	return errors.As(err, &conflictError)
+}
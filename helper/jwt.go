@@ -1,6 +1,7 @@
 package helper
 
 import (
+	"crop_connect/constant"
 	"crop_connect/util"
 	"errors"
 	"strings"
@@ -12,28 +13,33 @@ import (
 )
 
 type JWTCustomClaims struct {
-	UID  string `json:"uid"`
-	Role string `json:"role"`
+	UID       string `json:"uid"`
+	Role      string `json:"role"`
+	SessionID string `json:"sid,omitempty"`
+	Type      string `json:"type,omitempty"`
 	jwt.RegisteredClaims
 }
 
 var JWTSecretKey = util.GetConfig("JWT_SECRET_KEY")
 
-func GenerateToken(uid string, role string) string {
+// GenerateToken signs a token of the given type, tokenID becomes the jti claim used to revoke the token.
+func GenerateToken(uid string, role string, sessionID string, tokenID string, tokenType string, expiresAt time.Time) string {
 	claims := JWTCustomClaims{
 		uid,
 		role,
+		sessionID,
+		tokenType,
 		jwt.RegisteredClaims{
+			ID:        tokenID,
 			Issuer:    "crop_connect",
 			IssuedAt:  jwt.NewNumericDate(time.Now()),
 			NotBefore: jwt.NewNumericDate(time.Now()),
-			ExpiresAt: jwt.NewNumericDate(time.Now().Add(24 * time.Hour)),
+			ExpiresAt: jwt.NewNumericDate(expiresAt),
 		},
 	}
 
 	token, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(JWTSecretKey))
-	BearerToken := "Bearer " + token
-	return BearerToken
+	return token
 }
 
 func GetPayloadToken(token string) (JWTCustomClaims, error) {
@@ -56,7 +62,12 @@ func GetPayloadToken(token string) (JWTCustomClaims, error) {
 	return claims, nil
 }
 
+// GetPayloadFromToken returns the claims the auth middleware kept for a request authenticated by api key, otherwise the claims of the bearer token.
 func GetPayloadFromToken(c echo.Context) (JWTCustomClaims, error) {
+	if claims, ok := c.Get(constant.ContextKeyAPIKeyClaims).(JWTCustomClaims); ok {
+		return claims, nil
+	}
+
 	authHeader := c.Request().Header.Get("Authorization")
 	token := strings.Replace(authHeader, "Bearer ", "", -1)
 
@@ -69,10 +80,7 @@ func GetPayloadFromToken(c echo.Context) (JWTCustomClaims, error) {
 }
 
 func GetUIDFromToken(c echo.Context) (primitive.ObjectID, error) {
-	authHeader := c.Request().Header.Get("Authorization")
-	token := strings.Replace(authHeader, "Bearer ", "", -1)
-
-	claims, err := GetPayloadToken(token)
+	claims, err := GetPayloadFromToken(c)
 	if err != nil {
 		return primitive.NilObjectID, err
 	}
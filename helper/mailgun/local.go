@@ -0,0 +1,58 @@
+package mailgun
+
+import (
+	"crop_connect/util"
+	"fmt"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Local writes every email to a file instead of sending it, for development without a mailgun account.
+type Local struct {
+	Directory   string
+	EmailSender string
+}
+
+func InitLocal(directory string, emailSender string) Function {
+	if directory == "" {
+		directory = "mails"
+	}
+
+	return &Local{
+		Directory:   directory,
+		EmailSender: util.ReplaceUnderScoreWithSpace(emailSender),
+	}
+}
+
+func (l *Local) SendOneMailUsingTemplate(subject string, template string, receipentEmail string, plainText string, variable map[string]string) (string, string, error) {
+	mailTemplate, err := GetTemplate(template)
+	if err != nil {
+		return "", "", err
+	}
+
+	if subject == "" {
+		subject = mailTemplate.Subject
+	}
+
+	if plainText == "" {
+		plainText, err = mailTemplate.Render(variable)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	if err := os.MkdirAll(l.Directory, 0755); err != nil {
+		return "", "", err
+	}
+
+	id := util.GenerateUUID()
+	content := fmt.Sprintf("From: %s\nTo: %s\nSubject: %s\nTemplate: %s\nDate: %s\n\n%s\n", l.EmailSender, receipentEmail, subject, template, time.Now().Format(time.RFC1123Z), plainText)
+
+	filename := filepath.Join(l.Directory, fmt.Sprintf("%d-%s-%s.eml", time.Now().Unix(), template, id))
+	if err := os.WriteFile(filename, []byte(content), 0644); err != nil {
+		return "", "", err
+	}
+
+	return "mail written to " + filename, id, nil
+}
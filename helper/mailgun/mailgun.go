@@ -2,9 +2,7 @@ package mailgun
 
 import (
 	"context"
-	"crop_connect/constant"
 	"crop_connect/util"
-	"errors"
 	"time"
 
 	"github.com/mailgun/mailgun-go/v3"
@@ -31,8 +29,20 @@ func Init(emailDomain string, emailSender string, mailgunKey string) Function {
 }
 
 func (mg *Mailgun) SendOneMailUsingTemplate(subject string, template string, receipentEmail string, plainText string, variable map[string]string) (string, string, error) {
-	if !util.CheckStringOnArray([]string{constant.MailgunForgotPasswordTemplate}, template) {
-		return "", "", errors.New("template tidak tersedia")
+	mailTemplate, err := GetTemplate(template)
+	if err != nil {
+		return "", "", err
+	}
+
+	if subject == "" {
+		subject = mailTemplate.Subject
+	}
+
+	if plainText == "" {
+		plainText, err = mailTemplate.Render(variable)
+		if err != nil {
+			return "", "", err
+		}
 	}
 
 	ctx, cancel := context.WithTimeout(context.Background(), 30*time.Second)
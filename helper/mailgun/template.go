@@ -0,0 +1,67 @@
+package mailgun
+
+import (
+	"bytes"
+	"crop_connect/constant"
+	"errors"
+	"strings"
+	"text/template"
+)
+
+// Template is an email the app is allowed to send. Mailgun renders its own stored template with the same name,
+// Body is the plain-text version sent alongside it and the only content written by the local sender.
+type Template struct {
+	Subject string
+	Body    string
+}
+
+var templates = map[string]Template{
+	constant.MailgunForgotPasswordTemplate: {
+		Subject: "Lupa password Crop Connect?",
+		Body:    "Buka {{.domain}}/reset-password/{{.token}} untuk mengganti password anda. Link berlaku selama 24 jam.",
+	},
+	constant.MailgunEmailVerificationTemplate: {
+		Subject: "Verifikasi email Crop Connect",
+		Body:    "Halo {{.name}}, buka {{.domain}}/verify-email/{{.token}} untuk memverifikasi email anda. Link berlaku selama 24 jam.",
+	},
+	constant.MailgunTransactionDecisionTemplate: {
+		Subject: "Keputusan transaksi Crop Connect",
+		Body:    "Halo {{.name}}, transaksi anda dengan id {{.transactionID}} {{.decision}}.",
+	},
+	constant.MailgunProposalValidationTemplate: {
+		Subject: "Hasil validasi proposal Crop Connect",
+		Body:    "Halo {{.name}}, proposal {{.proposal}} telah {{.decision}} oleh validator. {{.rejectReason}}",
+	},
+	constant.MailgunTreatmentRecordRequestTemplate: {
+		Subject: "Permintaan riwayat perawatan Crop Connect",
+		Body:    "Halo {{.name}}, validator meminta riwayat perawatan ke-{{.number}} untuk batch {{.batch}} sebelum {{.date}}.",
+	},
+	constant.MailgunHarvestApprovalTemplate: {
+		Subject: "Hasil panen disetujui Crop Connect",
+		Body:    "Halo {{.name}}, hasil panen batch {{.batch}} sebesar {{.totalHarvest}} kg telah disetujui oleh validator.",
+	},
+}
+
+func GetTemplate(name string) (Template, error) {
+	mailTemplate, ok := templates[name]
+	if !ok {
+		return Template{}, errors.New("template tidak tersedia")
+	}
+
+	return mailTemplate, nil
+}
+
+// Render fills the plain-text body, a variable missing from the map is an error instead of an empty string.
+func (t Template) Render(variable map[string]string) (string, error) {
+	body, err := template.New("body").Option("missingkey=error").Parse(t.Body)
+	if err != nil {
+		return "", err
+	}
+
+	var result bytes.Buffer
+	if err := body.Execute(&result, variable); err != nil {
+		return "", errors.New("variabel template tidak lengkap")
+	}
+
+	return strings.TrimSpace(result.String()), nil
+}
@@ -0,0 +1,67 @@
+package payment_gateway
+
+import (
+	"crop_connect/business/payments"
+	"crop_connect/constant"
+	"crop_connect/util"
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.mongodb.org/mongo-driver/bson/primitive"
+)
+
+// Local is a fake gateway for development, nothing is charged and the payment is confirmed by posting the callback manually.
+type Local struct {
+	CallbackToken string
+	InvoiceExpiry time.Duration
+}
+
+type localCallback struct {
+	ExternalID string `json:"externalID"`
+	Status     string `json:"status"`
+}
+
+func InitLocal(callbackToken string) payments.Gateway {
+	return &Local{
+		CallbackToken: callbackToken,
+		InvoiceExpiry: 24 * time.Hour,
+	}
+}
+
+func (l *Local) Name() string {
+	return constant.PaymentGatewayLocal
+}
+
+func (l *Local) CreateInvoice(domain *payments.Domain) (payments.Invoice, error) {
+	return payments.Invoice{
+		ExternalID: fmt.Sprintf("%s-%s", constant.PaymentGatewayLocal, util.GenerateUUID()),
+		ExpiredAt:  primitive.NewDateTimeFromTime(time.Now().Add(l.InvoiceExpiry)),
+	}, nil
+}
+
+func (l *Local) Refund(domain *payments.Domain) error {
+	if domain.Gateway != constant.PaymentGatewayLocal {
+		return errors.New("pembayaran tidak dibuat oleh gateway ini")
+	}
+
+	return nil
+}
+
+func (l *Local) ParseCallback(token string, body []byte) (payments.Callback, error) {
+	if l.CallbackToken == "" || subtle.ConstantTimeCompare([]byte(token), []byte(l.CallbackToken)) != 1 {
+		return payments.Callback{}, errors.New("token callback tidak valid")
+	}
+
+	callback := localCallback{}
+	if err := json.Unmarshal(body, &callback); err != nil {
+		return payments.Callback{}, err
+	}
+
+	return payments.Callback{
+		ExternalID: callback.ExternalID,
+		Status:     callback.Status,
+	}, nil
+}
@@ -0,0 +1,72 @@
+package helper
+
+import (
+	"bytes"
+	"crop_connect/constant"
+	"encoding/base64"
+	"image/png"
+	"time"
+
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	totpPeriod = 30
+	totpSkew   = 1
+	qrCodeSize = 256
+)
+
+type TOTPKey struct {
+	Secret string
+	URL    string
+	QRCode string
+}
+
+// GenerateTOTPKey creates a secret for the account, the otpauth url is also drawn as a png qr code in a data uri for authenticator apps.
+func GenerateTOTPKey(accountName string) (TOTPKey, error) {
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      constant.TwoFactorIssuer,
+		AccountName: accountName,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	image, err := key.Image(qrCodeSize, qrCodeSize)
+	if err != nil {
+		return TOTPKey{}, err
+	}
+
+	var buffer bytes.Buffer
+	if err := png.Encode(&buffer, image); err != nil {
+		return TOTPKey{}, err
+	}
+
+	return TOTPKey{
+		Secret: key.Secret(),
+		URL:    key.URL(),
+		QRCode: "data:image/png;base64," + base64.StdEncoding.EncodeToString(buffer.Bytes()),
+	}, nil
+}
+
+// ValidateTOTP returns the time step the code belongs to, one step around now is accepted for clock drift.
+// The step lets the caller reject a code that has already been used.
+func ValidateTOTP(code string, secret string, now time.Time) (int64, bool) {
+	step := now.Unix() / totpPeriod
+	for offset := int64(-totpSkew); offset <= totpSkew; offset++ {
+		isValid, err := totp.ValidateCustom(code, secret, time.Unix((step+offset)*totpPeriod, 0).UTC(), totp.ValidateOpts{
+			Period:    totpPeriod,
+			Digits:    otp.DigitsSix,
+			Algorithm: otp.AlgorithmSHA1,
+		})
+		if err == nil && isValid {
+			return step + offset, true
+		}
+	}
+
+	return 0, false
+}
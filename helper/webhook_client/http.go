@@ -0,0 +1,45 @@
+package webhook_client
+
+import (
+	"bytes"
+	"crop_connect/business/webhooks"
+	"io"
+	"net/http"
+	"time"
+)
+
+type HTTP struct {
+	client *http.Client
+}
+
+func Init(timeout time.Duration) webhooks.Client {
+	if timeout <= 0 {
+		timeout = 10 * time.Second
+	}
+
+	return &HTTP{
+		client: &http.Client{Timeout: timeout},
+	}
+}
+
+func (h *HTTP) Post(url string, header map[string]string, body []byte) (int, error) {
+	req, err := http.NewRequest(http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return 0, err
+	}
+
+	for key, value := range header {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := h.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	// the body is drained so the connection can be reused, only the status decides the result
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 64<<10))
+
+	return resp.StatusCode, nil
+}
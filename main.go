@@ -5,38 +5,72 @@ import (
 	"crypto/tls"
 	"fmt"
 	"net/http"
+	"strconv"
 	"time"
 
 	_middleware "crop_connect/app/middleware"
+	_realtime "crop_connect/app/realtime"
 	_route "crop_connect/app/route"
+	_scheduler "crop_connect/app/scheduler"
+	_worker "crop_connect/app/worker"
+	_constant "crop_connect/constant"
 	_driver "crop_connect/driver"
-	_mongo "crop_connect/driver/mongo"
 	"crop_connect/helper/cloudinary"
 	"crop_connect/helper/mailgun"
-	"crop_connect/seeds"
+	"crop_connect/helper/payment_gateway"
+	"crop_connect/helper/webhook_client"
 	_util "crop_connect/util"
 
+	_apiKeyUseCase "crop_connect/business/api_keys"
+	_auditEventUseCase "crop_connect/business/audit_events"
 	_batchUseCase "crop_connect/business/batchs"
 	_commodityUseCase "crop_connect/business/commodities"
+	_conversationUseCase "crop_connect/business/conversations"
+	_disputeUseCase "crop_connect/business/disputes"
+	_emailVerificationUseCase "crop_connect/business/email_verifications"
+	_emailUseCase "crop_connect/business/emails"
 	_forgotPasswordUseCase "crop_connect/business/forgot_password"
 	_harvestUseCase "crop_connect/business/harvests"
+	_jobHistoryUseCase "crop_connect/business/job_histories"
+	_jobUseCase "crop_connect/business/jobs"
+	_notificationUseCase "crop_connect/business/notifications"
+	_paymentUseCase "crop_connect/business/payments"
+	_policyUseCase "crop_connect/business/policies"
 	_proposalUseCase "crop_connect/business/proposals"
+	_ratingUseCase "crop_connect/business/ratings"
 	_regionUseCase "crop_connect/business/regions"
+	_sessionUseCase "crop_connect/business/sessions"
+	_shipmentUseCase "crop_connect/business/shipments"
 	_transactionUseCase "crop_connect/business/transactions"
 	_treatmentRecordUseCase "crop_connect/business/treatment_records"
 	_userUseCase "crop_connect/business/users"
+	_webhookUseCase "crop_connect/business/webhooks"
 
+	_apiKeyController "crop_connect/controller/api_keys"
+	_auditEventController "crop_connect/controller/audit_events"
 	_batchController "crop_connect/controller/batchs"
 	_commodityController "crop_connect/controller/commodities"
+	_conversationController "crop_connect/controller/conversations"
+	_disputeController "crop_connect/controller/disputes"
+	_emailVerificationController "crop_connect/controller/email_verifications"
+	_eventController "crop_connect/controller/events"
 	_forgotPasswordController "crop_connect/controller/forgot_password"
 	_harvestController "crop_connect/controller/harvests"
+	_jobHistoryController "crop_connect/controller/job_histories"
+	_notificationController "crop_connect/controller/notifications"
+	_paymentController "crop_connect/controller/payments"
+	_policyController "crop_connect/controller/policies"
 	_proposalController "crop_connect/controller/proposals"
+	_ratingController "crop_connect/controller/ratings"
 	_regionController "crop_connect/controller/regions"
+	_shipmentController "crop_connect/controller/shipments"
 	_transactionController "crop_connect/controller/transactions"
 	_treatmentRecordController "crop_connect/controller/treatment_records"
 	_userController "crop_connect/controller/users"
+	_webhookController "crop_connect/controller/webhooks"
 
 	"github.com/labstack/echo/v4"
+	"go.mongodb.org/mongo-driver/bson/primitive"
 	"golang.org/x/crypto/acme"
 	"golang.org/x/crypto/acme/autocert"
 )
@@ -46,34 +80,48 @@ func main() {
 	e := echo.New()
 
 	fmt.Println("Initializing database and services...")
-	database := _mongo.Init(_util.GetConfig("DB_NAME"))
 	cloudinary := cloudinary.Init(_util.GetConfig("CLOUDINARY_UPLOAD_FOLDER"))
-	mailgun := mailgun.Init(_util.GetConfig("MAILGUN_DOMAIN"), _util.GetConfig("MAILGUN_SENDER_EMAIL"), _util.GetConfig("MAILGUN_PRIVATE_API_KEY"))
+	var mailer mailgun.Function
+	if _util.GetConfig("MAIL_DRIVER") == "local" {
+		mailer = mailgun.InitLocal(_util.GetConfig("MAIL_LOCAL_DIRECTORY"), _util.GetConfig("MAILGUN_SENDER_EMAIL"))
+	} else {
+		mailer = mailgun.Init(_util.GetConfig("MAILGUN_DOMAIN"), _util.GetConfig("MAILGUN_SENDER_EMAIL"), _util.GetConfig("MAILGUN_PRIVATE_API_KEY"))
+	}
+	paymentGateway := payment_gateway.InitLocal(_util.GetConfig("PAYMENT_CALLBACK_TOKEN"))
+	eventHub := _realtime.NewHub()
+	webhookClient := webhook_client.Init(10 * time.Second)
 
 	fmt.Println("Initializing repositories...")
-	userRepository := _driver.NewUserRepository(database)
-	commodityRepository := _driver.NewCommodityRepository(database)
-	proposalRepository := _driver.NewProposalRepository(database)
-	transactionRepository := _driver.NewTransactionRepository(database)
-	batchRepository := _driver.NewBatchRepository(database)
-	treatmentRecordRepository := _driver.NewTreatmentRecordRepository(database)
-	harvestRepository := _driver.NewHarvestRepository(database)
-	regionRepository := _driver.NewRegionRepository(database)
-	forgotPasswordRepository := _driver.NewForgotPasswordRepository(database)
+	repositories := _driver.NewRepositories(_util.GetConfig("DB_DRIVER"), _util.GetConfig("DB_NAME"))
 
 	fmt.Println("Initializing usecases...")
-	userUseCase := _userUseCase.NewUseCase(userRepository, regionRepository)
-	commodityUsecase := _commodityUseCase.NewUseCase(commodityRepository, userRepository, cloudinary)
-	proposalUseCase := _proposalUseCase.NewUseCase(proposalRepository, commodityRepository, regionRepository)
-	transactionUseCase := _transactionUseCase.NewUseCase(transactionRepository, batchRepository, commodityRepository, proposalRepository)
-	batchUseCase := _batchUseCase.NewUseCase(batchRepository, proposalRepository, commodityRepository)
-	treatmentRecordUseCase := _treatmentRecordUseCase.NewUseCase(treatmentRecordRepository, batchRepository, proposalRepository, commodityRepository, cloudinary)
-	harvestUseCase := _harvestUseCase.NewUseCase(harvestRepository, batchRepository, treatmentRecordRepository, transactionRepository, proposalRepository, commodityRepository, cloudinary)
-	regionUseCase := _regionUseCase.NewUseCase(regionRepository)
-	ForgotPasswordUseCase := _forgotPasswordUseCase.NewUseCase(forgotPasswordRepository, userRepository, mailgun)
+	sessionUseCase := _sessionUseCase.NewUseCase(repositories.Session, repositories.RevokedToken, repositories.UnitOfWork)
+	userUseCase := _userUseCase.NewUseCase(repositories.User, repositories.Region, sessionUseCase)
+	jobUseCase := _jobUseCase.NewUseCase(repositories.Job)
+	emailUseCase := _emailUseCase.NewUseCase(repositories.User, jobUseCase)
+	webhookUseCase := _webhookUseCase.NewUseCase(repositories.Webhook, jobUseCase, webhookClient, repositories.UnitOfWork)
+	policyUseCase := _policyUseCase.NewUseCase(repositories.Commodity, repositories.Proposal, repositories.Batch)
+	apiKeyUseCase := _apiKeyUseCase.NewUseCase(repositories.APIKey, repositories.User, policyUseCase)
+	commodityUsecase := _commodityUseCase.NewUseCase(repositories.Commodity, repositories.User, jobUseCase, cloudinary)
+	proposalUseCase := _proposalUseCase.NewUseCase(repositories.Proposal, repositories.Commodity, repositories.Region, repositories.User, repositories.Notification, repositories.AuditEvent, eventHub, emailUseCase, webhookUseCase, repositories.UnitOfWork)
+	transactionUseCase := _transactionUseCase.NewUseCase(repositories.Transaction, repositories.Batch, repositories.Commodity, repositories.Proposal, repositories.TreatmentRecord, repositories.Notification, repositories.AuditEvent, eventHub, emailUseCase, jobUseCase, webhookUseCase, policyUseCase, repositories.UnitOfWork)
+	batchUseCase := _batchUseCase.NewUseCase(repositories.Batch, repositories.Proposal, repositories.Commodity, repositories.Notification, repositories.AuditEvent)
+	treatmentRecordUseCase := _treatmentRecordUseCase.NewUseCase(repositories.TreatmentRecord, repositories.Batch, repositories.Proposal, repositories.Commodity, repositories.Notification, repositories.AuditEvent, eventHub, emailUseCase, jobUseCase, policyUseCase, cloudinary)
+	harvestUseCase := _harvestUseCase.NewUseCase(repositories.Harvest, repositories.Batch, repositories.TreatmentRecord, repositories.Transaction, repositories.Proposal, repositories.Commodity, repositories.Shipment, repositories.User, repositories.Notification, repositories.AuditEvent, eventHub, emailUseCase, webhookUseCase, jobUseCase, policyUseCase, cloudinary, repositories.UnitOfWork)
+	regionUseCase := _regionUseCase.NewUseCase(repositories.Region)
+	ForgotPasswordUseCase := _forgotPasswordUseCase.NewUseCase(repositories.ForgotPassword, repositories.User, jobUseCase, sessionUseCase)
+	paymentUseCase := _paymentUseCase.NewUseCase(repositories.Payment, repositories.Transaction, repositories.Proposal, repositories.Commodity, repositories.AuditEvent, eventHub, paymentGateway, jobUseCase, repositories.UnitOfWork)
+	shipmentUseCase := _shipmentUseCase.NewUseCase(repositories.Shipment, repositories.Transaction)
+	notificationUseCase := _notificationUseCase.NewUseCase(repositories.Notification)
+	jobHistoryUseCase := _jobHistoryUseCase.NewUseCase(repositories.JobHistory)
+	emailVerificationUseCase := _emailVerificationUseCase.NewUseCase(repositories.EmailVerification, repositories.User, jobUseCase)
+	auditEventUseCase := _auditEventUseCase.NewUseCase(repositories.AuditEvent)
+	disputeUseCase := _disputeUseCase.NewUseCase(repositories.Dispute, repositories.Transaction, repositories.Shipment, repositories.Proposal, repositories.Notification, repositories.AuditEvent, jobUseCase, cloudinary, repositories.UnitOfWork)
+	ratingUseCase := _ratingUseCase.NewUseCase(repositories.Rating, repositories.Transaction, repositories.Shipment, repositories.Proposal, repositories.Commodity, repositories.User, repositories.Notification, repositories.UnitOfWork)
+	conversationUseCase := _conversationUseCase.NewUseCase(repositories.Conversation, repositories.Commodity, repositories.Proposal, repositories.Transaction, repositories.Notification, jobUseCase, cloudinary, repositories.UnitOfWork)
 
 	fmt.Println("Initializing controllers...")
-	userController := _userController.NewController(userUseCase, regionUseCase)
+	userController := _userController.NewController(userUseCase, regionUseCase, sessionUseCase, emailVerificationUseCase)
 	commodityController := _commodityController.NewController(commodityUsecase, userUseCase, proposalUseCase, regionUseCase)
 	proposalController := _proposalController.NewController(proposalUseCase, commodityUsecase, userUseCase, regionUseCase)
 	transactionController := _transactionController.NewController(transactionUseCase, proposalUseCase, commodityUsecase, userUseCase, batchUseCase, regionUseCase)
@@ -82,24 +130,111 @@ func main() {
 	harvestController := _harvestController.NewController(harvestUseCase, batchUseCase, transactionUseCase, proposalUseCase, commodityUsecase, userUseCase, regionUseCase)
 	regionController := _regionController.NewController(regionUseCase)
 	forgotPasswordController := _forgotPasswordController.NewController(ForgotPasswordUseCase)
+	paymentController := _paymentController.NewController(paymentUseCase, transactionUseCase)
+	shipmentController := _shipmentController.NewController(shipmentUseCase)
+	notificationController := _notificationController.NewController(notificationUseCase)
+	jobHistoryController := _jobHistoryController.NewController(jobHistoryUseCase)
+	emailVerificationController := _emailVerificationController.NewController(emailVerificationUseCase)
+	policyController := _policyController.NewController(policyUseCase)
+	auditEventController := _auditEventController.NewController(auditEventUseCase)
+	disputeController := _disputeController.NewController(disputeUseCase)
+	ratingController := _ratingController.NewController(ratingUseCase)
+	conversationController := _conversationController.NewController(conversationUseCase)
+	eventController := _eventController.NewController(eventHub)
+	apiKeyController := _apiKeyController.NewController(apiKeyUseCase, policyUseCase)
+	webhookController := _webhookController.NewController(webhookUseCase, _util.GetConfig("WEBHOOK_TEST_RECEIVER_SECRET"), _util.GetConfig("WEBHOOK_TEST_RECEIVER_DIRECTORY"))
+
+	repositories.Seed(regionUseCase)
 
-	seeds.SeedDatabase(database, regionUseCase)
+	fmt.Println("Starting job worker...")
+	workerCount, _ := strconv.Atoi(_util.GetConfig("JOB_WORKER_COUNT"))
+	jobWorker := _worker.NewPool(repositories.Job, map[string]_jobUseCase.Handler{
+		_constant.JobTypeSendEmail:      _jobUseCase.SendEmailHandler(mailer),
+		_constant.JobTypeDeleteImages:   _jobUseCase.DeleteImagesHandler(cloudinary),
+		_constant.JobTypeRefundPayment:  _jobUseCase.RefundPaymentHandler(paymentUseCase.RefundTransaction, paymentUseCase.RefundPayment),
+		_constant.JobTypeDeliverWebhook: _jobUseCase.DeliverWebhookHandler(webhookUseCase.Deliver),
+	}, workerCount, e.Logger)
+	jobWorker.Start()
+
+	fmt.Println("Starting scheduler...")
+	transactionExpireDays, err := strconv.Atoi(_util.GetConfig("SCHEDULER_TRANSACTION_EXPIRE_DAYS"))
+	if err != nil || transactionExpireDays < 1 {
+		transactionExpireDays = 7
+	}
+
+	scheduler := _scheduler.NewScheduler(repositories.JobHistory, []_scheduler.Job{
+		{
+			Name:     _constant.ScheduledJobExpireTransactions,
+			Interval: _scheduler.ParseInterval(_util.GetConfig("SCHEDULER_EXPIRE_TRANSACTIONS_INTERVAL"), time.Hour),
+			Run: func() (int, error) {
+				totalExpired, _, err := transactionUseCase.ExpirePending(primitive.NewDateTimeFromTime(time.Now().AddDate(0, 0, -transactionExpireDays)))
+				return totalExpired, err
+			},
+		},
+		{
+			Name:     _constant.ScheduledJobMarkOverdueBatchs,
+			Interval: _scheduler.ParseInterval(_util.GetConfig("SCHEDULER_MARK_OVERDUE_BATCHS_INTERVAL"), time.Hour),
+			Run: func() (int, error) {
+				totalOverdue, _, err := batchUseCase.MarkOverdue()
+				return totalOverdue, err
+			},
+		},
+		{
+			Name:     _constant.ScheduledJobPurgeForgotPasswords,
+			Interval: _scheduler.ParseInterval(_util.GetConfig("SCHEDULER_PURGE_FORGOT_PASSWORDS_INTERVAL"), 24*time.Hour),
+			Run: func() (int, error) {
+				totalDeleted, _, err := ForgotPasswordUseCase.PurgeExpired()
+				return totalDeleted, err
+			},
+		},
+		{
+			Name:     _constant.ScheduledJobPurgeSessions,
+			Interval: _scheduler.ParseInterval(_util.GetConfig("SCHEDULER_PURGE_SESSIONS_INTERVAL"), 24*time.Hour),
+			Run: func() (int, error) {
+				totalDeleted, _, err := sessionUseCase.PurgeExpired()
+				return totalDeleted, err
+			},
+		},
+		{
+			Name:     _constant.ScheduledJobPurgeEmailVerifications,
+			Interval: _scheduler.ParseInterval(_util.GetConfig("SCHEDULER_PURGE_EMAIL_VERIFICATIONS_INTERVAL"), 24*time.Hour),
+			Run: func() (int, error) {
+				totalDeleted, _, err := emailVerificationUseCase.PurgeExpired()
+				return totalDeleted, err
+			},
+		},
+	}, e.Logger)
+	scheduler.Start()
 
 	fmt.Println("Initializing middlewares...")
 	_middleware.InitLogger(e)
+	_middleware.InitAuth(sessionUseCase, userUseCase, policyUseCase, apiKeyUseCase)
 	_middleware.InitCORS(e)
 
 	fmt.Println("Initializing routes...")
 	routeController := _route.ControllerList{
-		UserController:            userController,
-		CommodityController:       commodityController,
-		ProposalController:        proposalController,
-		TransactionController:     transactionController,
-		BatchController:           batchController,
-		TreatmentRecordController: treatmentRecordController,
-		HarvestController:         harvestController,
-		RegionController:          regionController,
-		ForgotPasswordController:  forgotPasswordController,
+		UserController:              userController,
+		CommodityController:         commodityController,
+		ProposalController:          proposalController,
+		TransactionController:       transactionController,
+		BatchController:             batchController,
+		TreatmentRecordController:   treatmentRecordController,
+		HarvestController:           harvestController,
+		RegionController:            regionController,
+		ForgotPasswordController:    forgotPasswordController,
+		PaymentController:           paymentController,
+		ShipmentController:          shipmentController,
+		NotificationController:      notificationController,
+		JobHistoryController:        jobHistoryController,
+		EmailVerificationController: emailVerificationController,
+		PolicyController:            policyController,
+		AuditEventController:        auditEventController,
+		DisputeController:           disputeController,
+		RatingController:            ratingController,
+		ConversationController:      conversationController,
+		EventController:             eventController,
+		WebhookController:           webhookController,
+		APIKeyController:            apiKeyController,
 	}
 	routeController.Init(e)
 
@@ -108,7 +243,9 @@ func main() {
 	go func() {
 		appPort := fmt.Sprintf(":%s", _util.GetConfig("APP_PORT"))
 		if _util.GetConfig("APP_ENV") == "development" {
-			e.Logger.Fatal(e.Start(appPort))
+			if err := e.Start(appPort); err != http.ErrServerClosed {
+				e.Logger.Fatal(err)
+			}
 		} else {
 			autoTLSManager := autocert.Manager{
 				Prompt:     autocert.AcceptTOS,
@@ -125,13 +262,27 @@ func main() {
 				},
 			}
 
-			e.Logger.Fatal(s.ListenAndServeTLS("", ""))
+			if err := s.ListenAndServeTLS("", ""); err != http.ErrServerClosed {
+				e.Logger.Fatal(err)
+			}
 		}
 	}()
 
-	wait := _util.GracefulShutdown(context.Background(), 2*time.Second, map[string]_util.Operation{
+	wait := _util.GracefulShutdown(context.Background(), 10*time.Second, map[string]_util.Operation{
 		"database": func(ctx context.Context) error {
-			return _mongo.Close(database)
+			// the running jobs still need the database to record their result
+			jobWorker.Wait()
+			scheduler.Wait()
+			return repositories.Close()
+		},
+		"job-worker": func(ctx context.Context) error {
+			return jobWorker.Shutdown(ctx)
+		},
+		"scheduler": func(ctx context.Context) error {
+			return scheduler.Shutdown(ctx)
+		},
+		"event-hub": func(ctx context.Context) error {
+			return eventHub.Shutdown(ctx)
 		},
 		"http-server": func(ctx context.Context) error {
 			return e.Shutdown(context.Background())
@@ -15,3 +15,8 @@ func SeedDatabase(db *mongo.Database, regionUC regionDomain.UseCase) {
 		regionSeed.Seed(regionUC)
 	}
 }
+
+func SeedMemoryDatabase(regionUC regionDomain.UseCase) {
+	fmt.Println("Seeding regions...")
+	regionSeed.Seed(regionUC)
+}
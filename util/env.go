@@ -1,17 +1,21 @@
 package util
 
 import (
+	"errors"
+	"io/fs"
 	"log"
 	"strings"
 
 	"github.com/spf13/viper"
 )
 
+// GetConfig reads key from the .env file, without one, e.g. when the tests run, it is only read from the environment.
 func GetConfig(key string) string {
 	viper.AddConfigPath(".")
 	viper.SetConfigFile(".env")
+	viper.AutomaticEnv()
 
-	if err := viper.ReadInConfig(); err != nil {
+	if err := viper.ReadInConfig(); err != nil && !errors.Is(err, fs.ErrNotExist) {
 		log.Fatalf("error when reading config: %s", err)
 	}
 